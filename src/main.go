@@ -1,47 +1,133 @@
 package main
 
 import (
+	"bufio"
+	"context"
 	"flag"
 	"fmt"
 	"net/http"
+	"os"
+	"strings"
 	"time"
 
 	"posterr/src/router"
+	storageauth "posterr/src/storage/auth"
 	storagedb "posterr/src/storage/db"
+	storagememory "posterr/src/storage/memory"
+	storagenotifications "posterr/src/storage/notifications"
 	storageposterr "posterr/src/storage/posterr"
 	storageusers "posterr/src/storage/users"
+	"posterr/src/stream"
+	"posterr/src/types"
 
 	"github.com/rs/cors"
 	"github.com/sirupsen/logrus"
 )
 
+const (
+	postgresStorage = "postgres"
+	memoryStorage   = "memory"
+)
+
 var (
-	initDB = flag.Bool("init-db", false, "creates a database and its tables")
-	port   = flag.Int("port", 3000, "application port ")
+	initDB  = flag.Bool("init-db", false, "creates a database and its tables")
+	migrate = flag.String("migrate", "", "runs database migrations and exits: up, down or status")
+	port    = flag.Int("port", 3000, "application port ")
+	storage = flag.String("storage", postgresStorage, "storage backend: postgres or memory")
+
+	editWindow = flag.Duration("edit-window", storageposterr.DefaultEditWindow, "how long after their creation posts can be edited")
+
+	setPassword = flag.String("set-password", "", "sets the password of a user, read from stdin, and exits")
+
+	requestTimeout = flag.Duration("request-timeout", 30*time.Second, "default deadline of a request, 0 disables it")
+	routeTimeouts  = flag.String("route-timeouts", "", "comma separated deadlines per route name, e.g. SearchContent=2s,ListHomeContent=1s")
+
+	defaultPool         = storagedb.DefaultPoolConfig()
+	dbMaxConns          = flag.Int("db-max-conns", int(defaultPool.MaxConns), "maximum number of database connections")
+	dbMinConns          = flag.Int("db-min-conns", int(defaultPool.MinConns), "minimum number of open database connections")
+	dbMaxConnLifetime   = flag.Duration("db-max-conn-lifetime", defaultPool.MaxConnLifetime, "duration after which a database connection is replaced")
+	dbHealthCheckPeriod = flag.Duration("db-health-check-period", defaultPool.HealthCheckPeriod, "interval between health checks of idle database connections")
 )
 
 func main() {
 	flag.Parse()
 
-	db := storagedb.NewDatabase(storagedb.DatabaseName)
-	if *initDB {
-		if err := db.InitializeDB(); err != nil {
+	var posts types.Posterr
+	var users types.Users
+	var auth types.Auth
+	var notifications types.Notifications
+	switch *storage {
+	case postgresStorage:
+		db := storagedb.NewDatabase(storagedb.DatabaseName, storagedb.PoolConfig{
+			MaxConns:          int32(*dbMaxConns),
+			MinConns:          int32(*dbMinConns),
+			MaxConnLifetime:   *dbMaxConnLifetime,
+			HealthCheckPeriod: *dbHealthCheckPeriod,
+		})
+		defer db.Close()
+
+		if *initDB {
+			if err := db.InitializeDB(); err != nil {
+				logrus.Fatalf("An error occurred: %s", err)
+			}
+		}
+
+		if len(*migrate) > 0 {
+			if err := runMigrations(db, *migrate); err != nil {
+				logrus.Fatalf("An error occurred: %s", err)
+			}
+			return
+		}
+
+		pool, err := db.Connect()
+		if err != nil {
 			logrus.Fatalf("An error occurred: %s", err)
 		}
+
+		posts = storageposterr.NewPosterrBacked(pool, *editWindow)
+		users = storageusers.NewUserBacked(pool)
+		auth = storageauth.NewAuthBacked(pool)
+		notifications = storagenotifications.NewNotificationsBacked(pool)
+
+		if len(*setPassword) > 0 {
+			if err := runSetPassword(auth, *setPassword); err != nil {
+				logrus.Fatalf("An error occurred: %s", err)
+			}
+			return
+		}
+	case memoryStorage:
+		store := storagememory.NewStore()
+		posts = storageposterr.NewPosterrMemory(store, *editWindow)
+		users = storageusers.NewUserMemory(store)
+		auth = storageauth.NewAuthMemory(store)
+		notifications = storagenotifications.NewNotificationsMemory(store)
+	default:
+		logrus.Fatalf("An error occurred: invalid storage %s", *storage)
 	}
 
-	posts := storageposterr.NewPosterrBacked(db)
-	users := storageusers.NewUserBacked(db)
+	hub := stream.NewHub(stream.DefaultHistorySize)
+	posts = stream.NewPublishingPosterr(posts, users, hub)
+	users = stream.NewRefreshingUsers(users, hub)
+
+	timeouts, err := router.ParseRouteTimeouts(*routeTimeouts)
+	if err != nil {
+		logrus.Fatalf("An error occurred: %s", err)
+	}
 
-	r := router.CreateRoutes(posts, users)
+	r := router.CreateRoutes(posts, users, auth, notifications, hub, router.RouteTimeouts{
+		Default: *requestTimeout,
+		Routes:  timeouts,
+	})
 	c := cors.New(cors.Options{
 		AllowedOrigins: []string{"*"},
 		AllowedMethods: []string{
 			http.MethodGet,
 			http.MethodPost,
+			http.MethodPut,
+			http.MethodPatch,
 			http.MethodDelete,
 		},
-		AllowedHeaders:   []string{"Accept", "Content-Type"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "Last-Event-ID"},
 		AllowCredentials: false,
 	})
 	handler := c.Handler(r)
@@ -55,3 +141,34 @@ func main() {
 	}
 	logrus.Fatal(s.ListenAndServe())
 }
+
+func runMigrations(db storagedb.ConnectDB, direction string) error {
+	if direction != storagedb.MigrateStatus {
+		return db.Migrate(direction)
+	}
+
+	status, err := db.MigrationStatus()
+	if err != nil {
+		return err
+	}
+
+	for _, s := range status {
+		appliedAt := "pending"
+		if s.AppliedAt != nil {
+			appliedAt = s.AppliedAt.Format(time.RFC3339)
+		}
+		fmt.Printf("%04d_%s\t%s\n", s.Version, s.Name, appliedAt)
+	}
+
+	return nil
+}
+
+func runSetPassword(auth types.Auth, username string) error {
+	fmt.Printf("Password for %s: ", username)
+	password, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return fmt.Errorf("could not read password: %w", err)
+	}
+
+	return auth.SetPassword(context.Background(), username, strings.TrimRight(password, "\r\n"))
+}
@@ -0,0 +1,6 @@
+package auth
+
+type LoginDTO struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
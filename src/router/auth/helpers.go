@@ -0,0 +1,46 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"posterr/src/router/response"
+	storageauth "posterr/src/storage/auth"
+)
+
+const (
+	authorizationHeader = "Authorization"
+	bearerPrefix        = "Bearer "
+)
+
+// parseBearerToken returns the token of the Authorization header, if any
+func parseBearerToken(r *http.Request) (string, bool) {
+	header := r.Header.Get(authorizationHeader)
+	if !strings.HasPrefix(header, bearerPrefix) {
+		return "", false
+	}
+
+	token := strings.TrimSpace(strings.TrimPrefix(header, bearerPrefix))
+	return token, len(token) > 0
+}
+
+func getStatusCodeFromError(err error) int {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return http.StatusGatewayTimeout
+	}
+
+	// storage errors may be wrapped, e.g., along with the Postgres error causing them
+	var codedErr response.CodedError
+	if !errors.As(err, &codedErr) {
+		return http.StatusInternalServerError
+	}
+
+	switch codedErr.(type) {
+	case storageauth.InvalidCredentialsError, storageauth.InvalidTokenError:
+		return http.StatusUnauthorized
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,62 @@
+package auth
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"posterr/src/router/response"
+	"posterr/src/types"
+
+	"github.com/sirupsen/logrus"
+)
+
+type login struct {
+	auth   types.Auth
+	logger *logrus.Entry
+}
+
+func NewLoginHandler(auth types.Auth) *login {
+	return &login{
+		auth:   auth,
+		logger: logrus.WithFields(logrus.Fields{"routes": "Login"}),
+	}
+}
+
+func (h *login) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		h.logger.Errorf("Request failed: %s", err)
+		response.WriteError(rw, http.StatusInternalServerError, err)
+
+		return
+	}
+
+	dto := LoginDTO{}
+	err = json.Unmarshal(body, &dto)
+	if err != nil {
+		h.logger.Errorf("Request failed: %s", err)
+		response.WriteError(rw, http.StatusBadRequest,
+			response.NewError(response.InvalidRequestBodyCode, "invalid request body: body must be a valid JSON"))
+
+		return
+	}
+
+	session, err := h.auth.Login(r.Context(), dto.Username, dto.Password)
+	if err != nil {
+		h.logger.Errorf("Request failed: %s", err)
+		response.WriteError(rw, getStatusCodeFromError(err), err)
+
+		return
+	}
+
+	sessionBytes, err := json.Marshal(session)
+	if err != nil {
+		h.logger.Errorf("Request failed: %s", err)
+		response.WriteError(rw, http.StatusInternalServerError, err)
+
+		return
+	}
+
+	rw.Write(sessionBytes)
+}
@@ -0,0 +1,36 @@
+package auth
+
+import (
+	"net/http"
+
+	"posterr/src/router/response"
+	"posterr/src/types"
+
+	"github.com/sirupsen/logrus"
+)
+
+type logout struct {
+	auth   types.Auth
+	logger *logrus.Entry
+}
+
+func NewLogoutHandler(auth types.Auth) *logout {
+	return &logout{
+		auth:   auth,
+		logger: logrus.WithFields(logrus.Fields{"routes": "Logout"}),
+	}
+}
+
+func (h *logout) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	token, _ := parseBearerToken(r)
+
+	err := h.auth.Logout(r.Context(), token)
+	if err != nil {
+		h.logger.Errorf("Request failed: %s", err)
+		response.WriteError(rw, getStatusCodeFromError(err), err)
+
+		return
+	}
+
+	rw.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,52 @@
+package auth
+
+import (
+	"net/http"
+
+	"posterr/src/router/response"
+	"posterr/src/types"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+// NewAuthenticateMiddleware puts the user a bearer token belongs to into
+// the request context. Requests without a token are served anonymously,
+// while requests with an invalid token are rejected
+func NewAuthenticateMiddleware(auth types.Auth) mux.MiddlewareFunc {
+	logger := logrus.WithFields(logrus.Fields{"middleware": "Authenticate"})
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			token, exists := parseBearerToken(r)
+			if !exists {
+				next.ServeHTTP(rw, r)
+				return
+			}
+
+			username, err := auth.Authenticate(r.Context(), token)
+			if err != nil {
+				logger.Errorf("Request failed: %s", err)
+				response.WriteError(rw, getStatusCodeFromError(err), err)
+
+				return
+			}
+
+			next.ServeHTTP(rw, r.WithContext(types.ContextWithUser(r.Context(), username)))
+		})
+	}
+}
+
+// RequireUser rejects requests without an authenticated user
+func RequireUser(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if _, exists := types.UserFromContext(r.Context()); !exists {
+			response.WriteError(rw, http.StatusUnauthorized,
+				response.NewError(response.UnauthorizedCode, "missing bearer token"))
+
+			return
+		}
+
+		next.ServeHTTP(rw, r)
+	})
+}
@@ -1,11 +1,12 @@
 package content
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
 	"io/ioutil"
 	"net/http"
 
+	"posterr/src/router/response"
 	"posterr/src/types"
 
 	"github.com/sirupsen/logrus"
@@ -27,8 +28,7 @@ func (h *createContent) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 	body, err := ioutil.ReadAll(r.Body)
 	if err != nil {
 		h.logger.Errorf("Request failed: %s", err)
-		rw.WriteHeader(http.StatusInternalServerError)
-		rw.Write([]byte("internal server error"))
+		response.WriteError(rw, http.StatusInternalServerError, err)
 
 		return
 	}
@@ -37,44 +37,40 @@ func (h *createContent) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 	err = json.Unmarshal(body, &dto)
 	if err != nil {
 		h.logger.Errorf("Request failed: %s", err)
-		rw.WriteHeader(http.StatusInternalServerError)
-		rw.Write([]byte("internal server error"))
+		response.WriteError(rw, http.StatusBadRequest,
+			response.NewError(response.InvalidRequestBodyCode, "invalid request body: body must be a valid JSON"))
 
 		return
 	}
 
 	if len(dto.Content) == 0 && len(dto.RepostedID) == 0 {
-		rw.WriteHeader(http.StatusBadRequest)
 		h.logger.Error("Request failed: either content or reposted_id should have a value")
-		message := fmt.Sprintf("could not complete write content operation: " +
-			"either content or reposted_id should have a value")
-		rw.Write([]byte(message))
+		response.WriteError(rw, http.StatusBadRequest,
+			response.NewError(response.InvalidRequestCode, "either content or reposted_id should have a value"))
 
 		return
 	}
 
-	h.WriteContent(rw, dto)
+	username, _ := types.UserFromContext(r.Context())
+	h.WriteContent(r.Context(), rw, username, dto)
 }
 
-func (h *createContent) WriteContent(rw http.ResponseWriter, dto PostContentDTO) {
+func (h *createContent) WriteContent(ctx context.Context, rw http.ResponseWriter, username string, dto PostContentDTO) {
 	var err error
 	if len(dto.RepostedID) == 0 {
 		// if RepostedID is empty, this is a regular post
-		_, err = h.posts.WriteContent(dto.Username, dto.Content)
+		_, err = h.posts.WriteContent(ctx, username, dto.Content)
 	} else if len(dto.Content) == 0 {
 		// if Content is empty, this is a repost
-		_, err = h.posts.WriteRepostContent(dto.Username, dto.RepostedID)
+		_, err = h.posts.WriteRepostContent(ctx, username, dto.RepostedID)
 	} else {
 		// otherwise, this is a quoted-repost
-		_, err = h.posts.WriteQuoteRepostContent(dto.Username, dto.Content, dto.RepostedID)
+		_, err = h.posts.WriteQuoteRepostContent(ctx, username, dto.Content, dto.RepostedID)
 	}
 
 	if err != nil {
-		statusCode := getStatusCodeFromError(err)
-		rw.WriteHeader(statusCode)
 		h.logger.Errorf("Request failed: %s", err)
-		message := fmt.Sprintf("could not complete write content operation: %s", err.Error())
-		rw.Write([]byte(message))
+		response.WriteError(rw, getStatusCodeFromError(err), err)
 
 		return
 	}
@@ -1,7 +1,14 @@
 package content
 
 type PostContentDTO struct {
-	Username   string `json:"username"`
 	Content    string `json:"content"`
 	RepostedID string `json:"reposted_id"`
 }
+
+type PostReplyDTO struct {
+	Content string `json:"content"`
+}
+
+type EditContentDTO struct {
+	Content string `json:"content"`
+}
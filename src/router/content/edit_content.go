@@ -0,0 +1,67 @@
+package content
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"posterr/src/router/response"
+	"posterr/src/types"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+type editContent struct {
+	posts  types.Posterr
+	logger *logrus.Entry
+}
+
+func NewEditContentHandler(posts types.Posterr) *editContent {
+	return &editContent{
+		posts:  posts,
+		logger: logrus.WithFields(logrus.Fields{"routes": "EditContent"}),
+	}
+}
+
+func (h *editContent) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	postId := vars["postId"]
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		h.logger.Errorf("Request failed: %s", err)
+		response.WriteError(rw, http.StatusInternalServerError, err)
+
+		return
+	}
+
+	dto := EditContentDTO{}
+	err = json.Unmarshal(body, &dto)
+	if err != nil {
+		h.logger.Errorf("Request failed: %s", err)
+		response.WriteError(rw, http.StatusBadRequest,
+			response.NewError(response.InvalidRequestBodyCode, "invalid request body: body must be a valid JSON"))
+
+		return
+	}
+
+	if len(dto.Content) == 0 {
+		h.logger.Error("Request failed: content should have a value")
+		response.WriteError(rw, http.StatusBadRequest,
+			response.NewError(response.InvalidRequestCode, "content should have a value"))
+
+		return
+	}
+
+	username, _ := types.UserFromContext(r.Context())
+	err = h.posts.EditContent(r.Context(), username, postId, dto.Content)
+	if err != nil {
+		h.logger.Errorf("Request failed: %s", err)
+		response.WriteError(rw, getStatusCodeFromError(err), err)
+
+		return
+	}
+
+	rw.WriteHeader(http.StatusNoContent)
+}
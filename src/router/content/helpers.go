@@ -1,18 +1,26 @@
 package content
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"strconv"
+	"time"
 
+	"posterr/src/router/response"
 	storageposterr "posterr/src/storage/posterr"
 )
 
 const (
-	usernameQuery = "username"
-	limitQuery    = "limit"
-	offsetQuery   = "offset"
-	textQuery     = "text"
-	toggleQuery   = "toggle"
+	cursorQuery      = "cursor"
+	lastEventIdQuery = "last_event_id"
+	limitQuery       = "limit"
+	offsetQuery      = "offset"
+	orderQuery       = "order"
+	sortQuery        = "sort"
+	textQuery        = "text"
+	toggleQuery      = "toggle"
+	windowQuery      = "window"
 )
 
 func parseQueryParam(param string, r *http.Request) string {
@@ -35,15 +43,39 @@ func parseIntQueryParam(param string, r *http.Request) (int, error) {
 	return 0, nil
 }
 
+func parseDurationQueryParam(param string, r *http.Request) (time.Duration, error) {
+	paramValue, exists := r.Form[param]
+	if exists {
+		return time.ParseDuration(paramValue[0])
+	}
+	return 0, nil
+}
+
 func parseBoolQueryParam(param string, r *http.Request) bool {
 	_, exists := r.Form[param]
 	return exists
 }
 
 func getStatusCodeFromError(err error) int {
-	switch err.(type) {
-	case storageposterr.PostExceededMaximumCharsError, storageposterr.InvalidToggleError:
+	if errors.Is(err, context.DeadlineExceeded) {
+		return http.StatusGatewayTimeout
+	}
+
+	// storage errors may be wrapped, e.g., along with the Postgres error causing them
+	var codedErr response.CodedError
+	if !errors.As(err, &codedErr) {
+		return http.StatusInternalServerError
+	}
+
+	switch codedErr.(type) {
+	case storageposterr.PostExceededMaximumCharsError, storageposterr.InvalidToggleError,
+		storageposterr.InvalidOrderError, storageposterr.InvalidCursorError, storageposterr.InvalidSortError,
+		storageposterr.InvalidHashtagError, storageposterr.InvalidTrendingWindowError,
+		storageposterr.AlreadyLikedError, storageposterr.NotLikedError, storageposterr.RepostNotEditableError,
+		storageposterr.AlreadyBookmarkedError, storageposterr.NotBookmarkedError:
 		return http.StatusBadRequest
+	case storageposterr.NotPostAuthorError, storageposterr.BlockedByAuthorError, storageposterr.EditWindowExpiredError:
+		return http.StatusForbidden
 	case storageposterr.UserDoesNotExistError, storageposterr.PostIdDoesNotExistError:
 		return http.StatusNotFound
 	case storageposterr.ExceededMaximumDailyPostsError:
@@ -0,0 +1,76 @@
+package content
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"posterr/src/router/response"
+	"posterr/src/types"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+type listBookmarkedContent struct {
+	posts  types.Posterr
+	logger *logrus.Entry
+}
+
+func NewListBookmarkedContentHandler(posts types.Posterr) *listBookmarkedContent {
+	return &listBookmarkedContent{
+		posts:  posts,
+		logger: logrus.WithFields(logrus.Fields{"routes": "ListBookmarkedContent"}),
+	}
+}
+
+func (h *listBookmarkedContent) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	username := vars["username"]
+
+	// bookmarks are only visible to their owner
+	if actingUser, _ := types.UserFromContext(r.Context()); actingUser != username {
+		h.logger.Errorf("Request failed: %s is not allowed to act as %s", actingUser, username)
+		response.WriteError(rw, http.StatusForbidden,
+			response.NewError(response.ForbiddenCode, "not allowed to act as another user"))
+
+		return
+	}
+
+	err := r.ParseForm()
+	if err != nil {
+		h.logger.Errorf("Error parsing form: %s", err)
+		response.WriteError(rw, http.StatusBadRequest,
+			response.NewError(response.InvalidQueryParamCode, "invalid query parameters"))
+
+		return
+	}
+
+	offset, err := parseIntQueryParam(offsetQuery, r)
+	if err != nil {
+		h.logger.Errorf("Error parsing offset: %s", err)
+		response.WriteError(rw, http.StatusBadRequest,
+			response.NewError(response.InvalidQueryParamCode, "invalid offset: offset must be an integer"))
+
+		return
+	}
+
+	cursor := parseQueryParam(cursorQuery, r)
+
+	bookmarkedPosts, err := h.posts.ListBookmarkedContent(r.Context(), username, types.Page{Offset: offset, Cursor: cursor})
+	if err != nil {
+		h.logger.Errorf("Request failed: %s", err)
+		response.WriteError(rw, getStatusCodeFromError(err), err)
+
+		return
+	}
+
+	postsBytes, err := json.Marshal(bookmarkedPosts)
+	if err != nil {
+		h.logger.Errorf("Request failed: %s", err)
+		response.WriteError(rw, http.StatusInternalServerError, err)
+
+		return
+	}
+
+	rw.Write(postsBytes)
+}
@@ -0,0 +1,47 @@
+package content
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"posterr/src/router/response"
+	"posterr/src/types"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+type listContentLikers struct {
+	posts  types.Posterr
+	logger *logrus.Entry
+}
+
+func NewListContentLikersHandler(posts types.Posterr) *listContentLikers {
+	return &listContentLikers{
+		posts:  posts,
+		logger: logrus.WithFields(logrus.Fields{"routes": "ListContentLikers"}),
+	}
+}
+
+func (h *listContentLikers) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	postId := vars["postId"]
+
+	likers, err := h.posts.ListPostLikers(r.Context(), postId)
+	if err != nil {
+		h.logger.Errorf("Request failed: %s", err)
+		response.WriteError(rw, getStatusCodeFromError(err), err)
+
+		return
+	}
+
+	likersBytes, err := json.Marshal(likers)
+	if err != nil {
+		h.logger.Errorf("Request failed: %s", err)
+		response.WriteError(rw, http.StatusInternalServerError, err)
+
+		return
+	}
+
+	rw.Write(likersBytes)
+}
@@ -0,0 +1,47 @@
+package content
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"posterr/src/router/response"
+	"posterr/src/types"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+type listContentRevisions struct {
+	posts  types.Posterr
+	logger *logrus.Entry
+}
+
+func NewListContentRevisionsHandler(posts types.Posterr) *listContentRevisions {
+	return &listContentRevisions{
+		posts:  posts,
+		logger: logrus.WithFields(logrus.Fields{"routes": "ListContentRevisions"}),
+	}
+}
+
+func (h *listContentRevisions) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	postId := vars["postId"]
+
+	revisions, err := h.posts.ListContentRevisions(r.Context(), postId)
+	if err != nil {
+		h.logger.Errorf("Request failed: %s", err)
+		response.WriteError(rw, getStatusCodeFromError(err), err)
+
+		return
+	}
+
+	revisionsBytes, err := json.Marshal(revisions)
+	if err != nil {
+		h.logger.Errorf("Request failed: %s", err)
+		response.WriteError(rw, http.StatusInternalServerError, err)
+
+		return
+	}
+
+	rw.Write(revisionsBytes)
+}
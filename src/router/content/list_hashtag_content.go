@@ -0,0 +1,67 @@
+package content
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"posterr/src/router/response"
+	"posterr/src/types"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+type listHashtagContent struct {
+	posts  types.Posterr
+	logger *logrus.Entry
+}
+
+func NewListHashtagContentHandler(posts types.Posterr) *listHashtagContent {
+	return &listHashtagContent{
+		posts:  posts,
+		logger: logrus.WithFields(logrus.Fields{"routes": "ListHashtagContent"}),
+	}
+}
+
+func (h *listHashtagContent) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	hashtag := vars["hashtag"]
+
+	err := r.ParseForm()
+	if err != nil {
+		h.logger.Errorf("Error parsing form: %s", err)
+		response.WriteError(rw, http.StatusBadRequest,
+			response.NewError(response.InvalidQueryParamCode, "invalid query parameters"))
+
+		return
+	}
+
+	offset, err := parseIntQueryParam(offsetQuery, r)
+	if err != nil {
+		h.logger.Errorf("Error parsing offset: %s", err)
+		response.WriteError(rw, http.StatusBadRequest,
+			response.NewError(response.InvalidQueryParamCode, "invalid offset: offset must be an integer"))
+
+		return
+	}
+
+	cursor := parseQueryParam(cursorQuery, r)
+
+	hashtagPosts, err := h.posts.ListHashtagContent(r.Context(), hashtag, types.Page{Offset: offset, Cursor: cursor})
+	if err != nil {
+		h.logger.Errorf("Request failed: %s", err)
+		response.WriteError(rw, getStatusCodeFromError(err), err)
+
+		return
+	}
+
+	postsBytes, err := json.Marshal(hashtagPosts)
+	if err != nil {
+		h.logger.Errorf("Request failed: %s", err)
+		response.WriteError(rw, http.StatusInternalServerError, err)
+
+		return
+	}
+
+	rw.Write(postsBytes)
+}
@@ -2,9 +2,9 @@ package content
 
 import (
 	"encoding/json"
-	"fmt"
 	"net/http"
 
+	"posterr/src/router/response"
 	"posterr/src/types"
 
 	"github.com/sirupsen/logrus"
@@ -26,27 +26,31 @@ func (h *listHomeContent) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 	err := r.ParseForm()
 	if err != nil {
 		h.logger.Errorf("Error parsing form: %s", err)
-		rw.WriteHeader(http.StatusInternalServerError)
-		rw.Write([]byte("internal server error"))
+		response.WriteError(rw, http.StatusBadRequest,
+			response.NewError(response.InvalidQueryParamCode, "invalid query parameters"))
+
+		return
 	}
 
 	offset, err := parseIntQueryParam(offsetQuery, r)
 	if err != nil {
 		h.logger.Errorf("Error parsing offset: %s", err)
-		rw.WriteHeader(http.StatusInternalServerError)
-		rw.Write([]byte("internal server error"))
+		response.WriteError(rw, http.StatusBadRequest,
+			response.NewError(response.InvalidQueryParamCode, "invalid offset: offset must be an integer"))
+
+		return
 	}
 
-	username := parseQueryParam(usernameQuery, r)
+	cursor := parseQueryParam(cursorQuery, r)
+	// the home page is always the one of the authenticated user, if any,
+	// so that no one lists posts of private users on behalf of their followers
+	username, _ := types.UserFromContext(r.Context())
 	toggle := parseBoolQueryParam(toggleQuery, r)
 
-	posts, err := h.posts.ListHomePageContent(username, offset, toggle)
+	posts, err := h.posts.ListHomePageContent(r.Context(), username, types.Page{Offset: offset, Cursor: cursor}, toggle)
 	if err != nil {
-		statusCode := getStatusCodeFromError(err)
-		rw.WriteHeader(statusCode)
 		h.logger.Errorf("Request failed: %s", err)
-		message := fmt.Sprintf("could not complete list home page content operation: %s", err.Error())
-		rw.Write([]byte(message))
+		response.WriteError(rw, getStatusCodeFromError(err), err)
 
 		return
 	}
@@ -54,8 +58,7 @@ func (h *listHomeContent) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 	postsBytes, err := json.Marshal(posts)
 	if err != nil {
 		h.logger.Errorf("Request failed: %s", err)
-		rw.WriteHeader(http.StatusInternalServerError)
-		rw.Write([]byte("internal server error"))
+		response.WriteError(rw, http.StatusInternalServerError, err)
 
 		return
 	}
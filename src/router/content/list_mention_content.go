@@ -0,0 +1,67 @@
+package content
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"posterr/src/router/response"
+	"posterr/src/types"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+type listMentionContent struct {
+	posts  types.Posterr
+	logger *logrus.Entry
+}
+
+func NewListMentionContentHandler(posts types.Posterr) *listMentionContent {
+	return &listMentionContent{
+		posts:  posts,
+		logger: logrus.WithFields(logrus.Fields{"routes": "ListMentionContent"}),
+	}
+}
+
+func (h *listMentionContent) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	username := vars["username"]
+
+	err := r.ParseForm()
+	if err != nil {
+		h.logger.Errorf("Error parsing form: %s", err)
+		response.WriteError(rw, http.StatusBadRequest,
+			response.NewError(response.InvalidQueryParamCode, "invalid query parameters"))
+
+		return
+	}
+
+	offset, err := parseIntQueryParam(offsetQuery, r)
+	if err != nil {
+		h.logger.Errorf("Error parsing offset: %s", err)
+		response.WriteError(rw, http.StatusBadRequest,
+			response.NewError(response.InvalidQueryParamCode, "invalid offset: offset must be an integer"))
+
+		return
+	}
+
+	cursor := parseQueryParam(cursorQuery, r)
+
+	mentionPosts, err := h.posts.ListMentionContent(r.Context(), username, types.Page{Offset: offset, Cursor: cursor})
+	if err != nil {
+		h.logger.Errorf("Request failed: %s", err)
+		response.WriteError(rw, getStatusCodeFromError(err), err)
+
+		return
+	}
+
+	postsBytes, err := json.Marshal(mentionPosts)
+	if err != nil {
+		h.logger.Errorf("Request failed: %s", err)
+		response.WriteError(rw, http.StatusInternalServerError, err)
+
+		return
+	}
+
+	rw.Write(postsBytes)
+}
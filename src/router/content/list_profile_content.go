@@ -2,9 +2,9 @@ package content
 
 import (
 	"encoding/json"
-	"fmt"
 	"net/http"
 
+	"posterr/src/router/response"
 	"posterr/src/types"
 
 	"github.com/gorilla/mux"
@@ -30,23 +30,27 @@ func (h *listProfileContent) ServeHTTP(rw http.ResponseWriter, r *http.Request)
 	err := r.ParseForm()
 	if err != nil {
 		h.logger.Errorf("Error parsing form: %s", err)
-		rw.WriteHeader(http.StatusInternalServerError)
-		rw.Write([]byte("internal server error"))
+		response.WriteError(rw, http.StatusBadRequest,
+			response.NewError(response.InvalidQueryParamCode, "invalid query parameters"))
+
+		return
 	}
 
 	offset, err := parseIntQueryParam(offsetQuery, r)
 	if err != nil {
 		h.logger.Errorf("Error parsing offset: %s", err)
-		rw.WriteHeader(http.StatusInternalServerError)
-		rw.Write([]byte("internal server error"))
+		response.WriteError(rw, http.StatusBadRequest,
+			response.NewError(response.InvalidQueryParamCode, "invalid offset: offset must be an integer"))
+
+		return
 	}
 
-	profilePosts, err := h.posts.ListProfileContent(username, offset)
+	cursor := parseQueryParam(cursorQuery, r)
+
+	profilePosts, err := h.posts.ListProfileContent(r.Context(), username, types.Page{Offset: offset, Cursor: cursor})
 	if err != nil {
-		rw.WriteHeader(http.StatusInternalServerError)
 		h.logger.Errorf("Request failed: %s", err)
-		message := fmt.Sprintf("could not complete list profile content operation: %s", err.Error())
-		rw.Write([]byte(message))
+		response.WriteError(rw, getStatusCodeFromError(err), err)
 
 		return
 	}
@@ -54,8 +58,7 @@ func (h *listProfileContent) ServeHTTP(rw http.ResponseWriter, r *http.Request)
 	postsBytes, err := json.Marshal(profilePosts)
 	if err != nil {
 		h.logger.Errorf("Request failed: %s", err)
-		rw.WriteHeader(http.StatusInternalServerError)
-		rw.Write([]byte("internal server error"))
+		response.WriteError(rw, http.StatusInternalServerError, err)
 
 		return
 	}
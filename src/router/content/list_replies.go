@@ -0,0 +1,61 @@
+package content
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"posterr/src/router/response"
+	"posterr/src/types"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+type listReplies struct {
+	posts  types.Posterr
+	logger *logrus.Entry
+}
+
+func NewListRepliesHandler(posts types.Posterr) *listReplies {
+	return &listReplies{
+		posts:  posts,
+		logger: logrus.WithFields(logrus.Fields{"routes": "ListReplies"}),
+	}
+}
+
+func (h *listReplies) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	postId := vars["postId"]
+
+	err := r.ParseForm()
+	if err != nil {
+		h.logger.Errorf("Error parsing form: %s", err)
+		response.WriteError(rw, http.StatusBadRequest,
+			response.NewError(response.InvalidQueryParamCode, "invalid query parameters"))
+
+		return
+	}
+
+	order := parseQueryParam(orderQuery, r)
+	if len(order) == 0 {
+		order = types.Ascending
+	}
+
+	replies, err := h.posts.ListReplies(r.Context(), postId, order)
+	if err != nil {
+		h.logger.Errorf("Request failed: %s", err)
+		response.WriteError(rw, getStatusCodeFromError(err), err)
+
+		return
+	}
+
+	repliesBytes, err := json.Marshal(replies)
+	if err != nil {
+		h.logger.Errorf("Request failed: %s", err)
+		response.WriteError(rw, http.StatusInternalServerError, err)
+
+		return
+	}
+
+	rw.Write(repliesBytes)
+}
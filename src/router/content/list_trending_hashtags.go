@@ -0,0 +1,70 @@
+package content
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"posterr/src/router/response"
+	"posterr/src/types"
+
+	"github.com/sirupsen/logrus"
+)
+
+type listTrendingHashtags struct {
+	posts  types.Posterr
+	logger *logrus.Entry
+}
+
+func NewListTrendingHashtagsHandler(posts types.Posterr) *listTrendingHashtags {
+	return &listTrendingHashtags{
+		posts:  posts,
+		logger: logrus.WithFields(logrus.Fields{"routes": "ListTrendingHashtags"}),
+	}
+}
+
+func (h *listTrendingHashtags) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	err := r.ParseForm()
+	if err != nil {
+		h.logger.Errorf("Error parsing form: %s", err)
+		response.WriteError(rw, http.StatusBadRequest,
+			response.NewError(response.InvalidQueryParamCode, "invalid query parameters"))
+
+		return
+	}
+
+	window, err := parseDurationQueryParam(windowQuery, r)
+	if err != nil {
+		h.logger.Errorf("Error parsing window: %s", err)
+		response.WriteError(rw, http.StatusBadRequest,
+			response.NewError(response.InvalidQueryParamCode, "invalid window: window must be a duration, e.g. 6h"))
+
+		return
+	}
+
+	limit, err := parseIntQueryParam(limitQuery, r)
+	if err != nil {
+		h.logger.Errorf("Error parsing limit: %s", err)
+		response.WriteError(rw, http.StatusBadRequest,
+			response.NewError(response.InvalidQueryParamCode, "invalid limit: limit must be an integer"))
+
+		return
+	}
+
+	hashtags, err := h.posts.ListTrendingHashtags(r.Context(), window, limit)
+	if err != nil {
+		h.logger.Errorf("Request failed: %s", err)
+		response.WriteError(rw, getStatusCodeFromError(err), err)
+
+		return
+	}
+
+	hashtagsBytes, err := json.Marshal(hashtags)
+	if err != nil {
+		h.logger.Errorf("Request failed: %s", err)
+		response.WriteError(rw, http.StatusInternalServerError, err)
+
+		return
+	}
+
+	rw.Write(hashtagsBytes)
+}
@@ -0,0 +1,47 @@
+package content
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"posterr/src/router/response"
+	"posterr/src/types"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+type readContent struct {
+	posts  types.Posterr
+	logger *logrus.Entry
+}
+
+func NewReadContentHandler(posts types.Posterr) *readContent {
+	return &readContent{
+		posts:  posts,
+		logger: logrus.WithFields(logrus.Fields{"routes": "ReadContent"}),
+	}
+}
+
+func (h *readContent) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	postId := vars["postId"]
+
+	post, err := h.posts.GetContent(r.Context(), postId)
+	if err != nil {
+		h.logger.Errorf("Request failed: %s", err)
+		response.WriteError(rw, getStatusCodeFromError(err), err)
+
+		return
+	}
+
+	postBytes, err := json.Marshal(post)
+	if err != nil {
+		h.logger.Errorf("Request failed: %s", err)
+		response.WriteError(rw, http.StatusInternalServerError, err)
+
+		return
+	}
+
+	rw.Write(postBytes)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 
+	"posterr/src/router/response"
 	"posterr/src/types"
 
 	"github.com/sirupsen/logrus"
@@ -26,36 +27,50 @@ func (h *searchContent) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 	err := r.ParseForm()
 	if err != nil {
 		h.logger.Errorf("Error parsing form: %s", err)
-		rw.WriteHeader(http.StatusInternalServerError)
-		rw.Write([]byte("internal server error"))
+		response.WriteError(rw, http.StatusBadRequest,
+			response.NewError(response.InvalidQueryParamCode, "invalid query parameters"))
+
+		return
 	}
 
 	limit, err := parseIntQueryParam(limitQuery, r)
+	if err == nil && limit < 0 {
+		err = fmt.Errorf("negative limit %d", limit)
+	}
 	if err != nil {
 		h.logger.Errorf("Error parsing limit: %s", err)
-		rw.WriteHeader(http.StatusInternalServerError)
-		rw.Write([]byte("internal server error"))
+		response.WriteError(rw, http.StatusBadRequest,
+			response.NewError(response.InvalidQueryParamCode, "invalid limit: limit must be a non negative integer"))
 
 		return
 	}
 
 	offset, err := parseIntQueryParam(offsetQuery, r)
+	if err == nil && offset < 0 {
+		err = fmt.Errorf("negative offset %d", offset)
+	}
 	if err != nil {
 		h.logger.Errorf("Error parsing offset: %s", err)
-		rw.WriteHeader(http.StatusInternalServerError)
-		rw.Write([]byte("internal server error"))
+		response.WriteError(rw, http.StatusBadRequest,
+			response.NewError(response.InvalidQueryParamCode, "invalid offset: offset must be a non negative integer"))
+
+		return
+	}
+
+	query, err := parseSearchQuery(parseQueryParam(textQuery, r), parseQueryParam(sortQuery, r))
+	if err != nil {
+		h.logger.Errorf("Error parsing text: %s", err)
+		response.WriteError(rw, http.StatusBadRequest, err)
 
 		return
 	}
 
-	text := parseQueryParam(textQuery, r)
+	cursor := parseQueryParam(cursorQuery, r)
 
-	posts, err := h.posts.SearchContent(text, limit, offset)
+	posts, err := h.posts.SearchContent(r.Context(), query, limit, types.Page{Offset: offset, Cursor: cursor})
 	if err != nil {
-		rw.WriteHeader(http.StatusInternalServerError)
 		h.logger.Errorf("Request failed: %s", err)
-		message := fmt.Sprintf("could not complete search content operation: %s", err.Error())
-		rw.Write([]byte(message))
+		response.WriteError(rw, getStatusCodeFromError(err), err)
 
 		return
 	}
@@ -63,8 +78,7 @@ func (h *searchContent) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 	postsBytes, err := json.Marshal(posts)
 	if err != nil {
 		h.logger.Errorf("Request failed: %s", err)
-		rw.WriteHeader(http.StatusInternalServerError)
-		rw.Write([]byte("internal server error"))
+		response.WriteError(rw, http.StatusInternalServerError, err)
 
 		return
 	}
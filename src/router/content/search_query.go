@@ -0,0 +1,97 @@
+package content
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"posterr/src/router/response"
+	"posterr/src/types"
+)
+
+const (
+	fromOperator  = "from:"
+	sinceOperator = "since:"
+	untilOperator = "until:"
+	dateLayout    = "2006-01-02"
+)
+
+/*
+	The text of a search supports the following operators:
+	- "some phrase": posts containing the words in sequence;
+	- -term or -"some phrase": posts not containing them;
+	- from:username: posts of a given username;
+	- since:YYYY-MM-DD and until:YYYY-MM-DD: posts created within the dates, both inclusive, in UTC.
+	Any other word is a term, which posts must contain.
+*/
+
+// parseSearchQuery parses the text of a search. If sort is empty, posts are sorted
+// by relevance when there are terms or phrases to match, and by recency otherwise
+func parseSearchQuery(text, sort string) (types.SearchQuery, error) {
+	var query types.SearchQuery
+	for _, token := range splitSearchText(text) {
+		switch {
+		case strings.HasPrefix(token, `-"`):
+			query.Exclude = appendPhrase(query.Exclude, strings.Trim(token[1:], `"`))
+		case strings.HasPrefix(token, "-") && len(token) > 1:
+			query.Exclude = append(query.Exclude, token[1:])
+		case strings.HasPrefix(token, `"`):
+			query.Phrases = appendPhrase(query.Phrases, strings.Trim(token, `"`))
+		case strings.HasPrefix(token, fromOperator) && len(token) > len(fromOperator):
+			query.From = strings.TrimPrefix(token, fromOperator)
+		case strings.HasPrefix(token, sinceOperator):
+			since, err := parseSearchDate(strings.TrimPrefix(token, sinceOperator))
+			if err != nil {
+				return types.SearchQuery{}, err
+			}
+			query.Since = since
+		case strings.HasPrefix(token, untilOperator):
+			until, err := parseSearchDate(strings.TrimPrefix(token, untilOperator))
+			if err != nil {
+				return types.SearchQuery{}, err
+			}
+			// until is inclusive, while the query excludes it
+			query.Until = until.AddDate(0, 0, 1)
+		default:
+			query.Terms = append(query.Terms, token)
+		}
+	}
+
+	query.Sort = sort
+	if len(query.Sort) == 0 {
+		query.Sort = types.Recent
+		if len(query.Terms) > 0 || len(query.Phrases) > 0 {
+			query.Sort = types.Relevance
+		}
+	}
+
+	return query, nil
+}
+
+// splitSearchText splits a text by spaces, except for the ones within quotes
+func splitSearchText(text string) []string {
+	var quoted bool
+	return strings.FieldsFunc(text, func(r rune) bool {
+		if r == '"' {
+			quoted = !quoted
+		}
+		return !quoted && (r == ' ' || r == '\t' || r == '\n')
+	})
+}
+
+func appendPhrase(phrases []string, phrase string) []string {
+	phrase = strings.TrimSpace(phrase)
+	if len(phrase) == 0 {
+		return phrases
+	}
+	return append(phrases, phrase)
+}
+
+func parseSearchDate(value string) (time.Time, error) {
+	date, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return time.Time{}, response.NewError(response.InvalidQueryParamCode,
+			fmt.Sprintf("invalid date %s: dates must be formatted as YYYY-MM-DD", value))
+	}
+	return date, nil
+}
@@ -0,0 +1,193 @@
+package content
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"posterr/src/router/response"
+	"posterr/src/stream"
+	"posterr/src/types"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	lastEventIdHeader = "Last-Event-ID"
+	heartbeatInterval = 15 * time.Second
+)
+
+type streamHomeContent struct {
+	hub    *stream.Hub
+	users  types.Users
+	logger *logrus.Entry
+}
+
+func NewStreamHomeContentHandler(hub *stream.Hub, users types.Users) *streamHomeContent {
+	return &streamHomeContent{
+		hub:    hub,
+		users:  users,
+		logger: logrus.WithFields(logrus.Fields{"routes": "StreamHomeContent"}),
+	}
+}
+
+/*
+New posts are sent as Server-Sent Events:
+
+	id: {event id}
+	event: post
+	data: {post}
+
+If some of the posts after the resumed event were missed, a reset event
+is sent first, after which the client should reload the home page.
+Comments are sent as heartbeats, so idle connections are kept open.
+*/
+func (h *streamHomeContent) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	err := r.ParseForm()
+	if err != nil {
+		h.logger.Errorf("Error parsing form: %s", err)
+		response.WriteError(rw, http.StatusBadRequest,
+			response.NewError(response.InvalidQueryParamCode, "invalid query parameters"))
+
+		return
+	}
+
+	// EventSource sends the header when reconnecting, while the query parameter
+	// allows resuming after the page is reloaded
+	lastEventIdValue := r.Header.Get(lastEventIdHeader)
+	if len(lastEventIdValue) == 0 {
+		lastEventIdValue = parseQueryParam(lastEventIdQuery, r)
+	}
+
+	var lastEventId uint64
+	resume := len(lastEventIdValue) > 0
+	if resume {
+		lastEventId, err = strconv.ParseUint(lastEventIdValue, 10, 64)
+		if err != nil {
+			h.logger.Errorf("Error parsing last event id: %s", err)
+			response.WriteError(rw, http.StatusBadRequest,
+				response.NewError(response.InvalidQueryParamCode, "invalid last event id: last event id must be an integer"))
+
+			return
+		}
+	}
+
+	flusher, ok := rw.(http.Flusher)
+	if !ok {
+		h.logger.Errorf("Request failed: streaming is not supported")
+		response.WriteError(rw, http.StatusInternalServerError, fmt.Errorf("streaming is not supported"))
+
+		return
+	}
+
+	username, _ := types.UserFromContext(r.Context())
+	toggle := parseBoolQueryParam(toggleQuery, r)
+
+	// the relations are loaded after subscribing, so that no change is missed
+	subscription := h.hub.Subscribe(username, lastEventId, resume)
+	defer h.hub.Unsubscribe(subscription)
+
+	relations, err := h.users.GetUserRelations(r.Context(), username)
+	if err != nil {
+		h.logger.Errorf("Request failed: %s", err)
+		response.WriteError(rw, http.StatusInternalServerError, err)
+
+		return
+	}
+
+	rw.Header().Set("Content-Type", "text/event-stream")
+	rw.Header().Set("Cache-Control", "no-cache")
+	rw.Header().Set("Connection", "keep-alive")
+	rw.WriteHeader(http.StatusOK)
+
+	if subscription.Missed {
+		fmt.Fprint(rw, "event: reset\ndata: {}\n\n")
+	}
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-subscription.Refresh:
+			if relations, err = h.users.GetUserRelations(r.Context(), username); err != nil {
+				h.logger.Errorf("Request failed: %s", err)
+				return
+			}
+		case <-heartbeat.C:
+			if _, err = fmt.Fprint(rw, ": heartbeat\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case event, open := <-subscription.Events:
+			// the subscriber fell behind, so the client must resume
+			if !open {
+				return
+			}
+
+			if !shouldDeliver(event, relations, username, toggle) {
+				continue
+			}
+
+			postBytes, err := json.Marshal(visiblePost(event, relations, username))
+			if err != nil {
+				h.logger.Errorf("Request failed: %s", err)
+				return
+			}
+
+			if _, err = fmt.Fprintf(rw, "id: %d\nevent: post\ndata: %s\n\n", event.ID, postBytes); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// shouldDeliver checks if a post belongs to the home page of the subscriber with relations,
+// according to toggle. Relations are kept by the subscriber, so no storage is queried per post.
+// As in the home page, posts of the users the subscriber muted or blocked are not delivered,
+// nor posts of the private users the subscriber doesn't follow
+func shouldDeliver(event stream.Event, relations types.PosterrRelations, username string, toggle bool) bool {
+	author := event.Post.Username
+	_, blocked := relations.Blocked[author]
+	_, muted := relations.Muted[author]
+	if blocked || muted || !canSee(event, relations, username, author) {
+		return false
+	}
+
+	if toggle == types.All {
+		return true
+	}
+
+	_, following := relations.Following[author]
+	return following
+}
+
+// visiblePost returns the post of event as seen by the subscriber with relations: as in the home page,
+// embedded posts of the private users the subscriber doesn't follow are replaced by tombstones.
+// The embedded posts are copied, since the event is shared by every subscriber
+func visiblePost(event stream.Event, relations types.PosterrRelations, username string) types.PosterrContent {
+	post := event.Post
+	for embedded := &post; embedded.Reposted != nil; embedded = embedded.Reposted {
+		reposted := *embedded.Reposted
+		if !reposted.Deleted && !canSee(event, relations, username, reposted.Username) {
+			reposted = types.PosterrContent{ID: reposted.ID, Deleted: true}
+		}
+		embedded.Reposted = &reposted
+	}
+
+	return post
+}
+
+// canSee checks if the subscriber with relations can see the posts of author,
+// who is only seen by themselves and their followers if they were private
+func canSee(event stream.Event, relations types.PosterrRelations, username, author string) bool {
+	_, private := event.Private[author]
+	_, following := relations.Following[author]
+	return !private || following || author == username
+}
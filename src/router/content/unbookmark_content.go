@@ -0,0 +1,39 @@
+package content
+
+import (
+	"net/http"
+
+	"posterr/src/router/response"
+	"posterr/src/types"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+type unbookmarkContent struct {
+	posts  types.Posterr
+	logger *logrus.Entry
+}
+
+func NewUnbookmarkContentHandler(posts types.Posterr) *unbookmarkContent {
+	return &unbookmarkContent{
+		posts:  posts,
+		logger: logrus.WithFields(logrus.Fields{"routes": "UnbookmarkContent"}),
+	}
+}
+
+func (h *unbookmarkContent) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	postId := vars["postId"]
+
+	username, _ := types.UserFromContext(r.Context())
+	err := h.posts.UnbookmarkPost(r.Context(), username, postId)
+	if err != nil {
+		h.logger.Errorf("Request failed: %s", err)
+		response.WriteError(rw, getStatusCodeFromError(err), err)
+
+		return
+	}
+
+	rw.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,39 @@
+package content
+
+import (
+	"net/http"
+
+	"posterr/src/router/response"
+	"posterr/src/types"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+type unlikeContent struct {
+	posts  types.Posterr
+	logger *logrus.Entry
+}
+
+func NewUnlikeContentHandler(posts types.Posterr) *unlikeContent {
+	return &unlikeContent{
+		posts:  posts,
+		logger: logrus.WithFields(logrus.Fields{"routes": "UnlikeContent"}),
+	}
+}
+
+func (h *unlikeContent) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	postId := vars["postId"]
+
+	username, _ := types.UserFromContext(r.Context())
+	err := h.posts.UnlikePost(r.Context(), username, postId)
+	if err != nil {
+		h.logger.Errorf("Request failed: %s", err)
+		response.WriteError(rw, getStatusCodeFromError(err), err)
+
+		return
+	}
+
+	rw.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,88 @@
+package response
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// Codes of errors raised by the router itself.
+// Errors raised by the storage backends define their own codes
+const (
+	InternalErrorCode      = "internal_error"
+	InvalidRequestCode     = "invalid_request"
+	InvalidRequestBodyCode = "invalid_request_body"
+	InvalidQueryParamCode  = "invalid_query_parameter"
+	UnauthorizedCode       = "unauthorized"
+	ForbiddenCode          = "forbidden"
+	TimeoutCode            = "timeout"
+)
+
+// ErrorBody is the body of every failed request
+type ErrorBody struct {
+	Code    string            `json:"code"`
+	Message string            `json:"message"`
+	Details map[string]string `json:"details,omitempty"`
+}
+
+// CodedError is implemented by errors which can be told apart by a stable code
+type CodedError interface {
+	error
+	Code() string
+}
+
+// DetailedError is implemented by errors which carry the values that caused them
+type DetailedError interface {
+	Details() map[string]string
+}
+
+type routerError struct {
+	code    string
+	message string
+}
+
+func (e routerError) Error() string {
+	return e.message
+}
+
+func (e routerError) Code() string {
+	return e.code
+}
+
+// NewError returns an error with a given code, for failures detected by the router
+func NewError(code, message string) error {
+	return routerError{code, message}
+}
+
+// WriteError writes err as an ErrorBody. Errors without a code
+// are reported as internal errors, without exposing their message
+func WriteError(rw http.ResponseWriter, statusCode int, err error) {
+	body := ErrorBody{
+		Code:    InternalErrorCode,
+		Message: "internal server error",
+	}
+
+	var codedErr CodedError
+	var detailedErr DetailedError
+	if errors.As(err, &codedErr) {
+		body.Code = codedErr.Code()
+		body.Message = codedErr.Error()
+		if errors.As(err, &detailedErr) {
+			body.Details = detailedErr.Details()
+		}
+	} else if errors.Is(err, context.DeadlineExceeded) {
+		body.Code = TimeoutCode
+		body.Message = "request timed out"
+	}
+
+	bodyBytes, err := json.Marshal(body)
+	if err != nil {
+		rw.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(statusCode)
+	rw.Write(bodyBytes)
+}
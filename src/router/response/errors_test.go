@@ -0,0 +1,55 @@
+package response
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	storageposterr "posterr/src/storage/posterr"
+
+	assertions "github.com/stretchr/testify/assert"
+)
+
+func TestWriteError(t *testing.T) {
+	assert := assertions.New(t)
+
+	writeError := func(statusCode int, err error) (*httptest.ResponseRecorder, ErrorBody) {
+		rw := httptest.NewRecorder()
+		WriteError(rw, statusCode, err)
+
+		body := ErrorBody{}
+		assert.NoError(json.Unmarshal(rw.Body.Bytes(), &body))
+		return rw, body
+	}
+
+	t.Run("Should write the code and details of a storage error", func(t *testing.T) {
+		err := fmt.Errorf("wrapped: %w", storageposterr.InvalidCursorError{})
+		rw, body := writeError(http.StatusBadRequest, err)
+		assert.Equal(http.StatusBadRequest, rw.Code)
+		assert.Equal("application/json", rw.Header().Get("Content-Type"))
+		assert.Equal("invalid_cursor", body.Code)
+		assert.Equal(storageposterr.InvalidCursorError{}.Error(), body.Message)
+		assert.Contains(body.Details, "cursor")
+	})
+
+	t.Run("Should write a router error", func(t *testing.T) {
+		_, body := writeError(http.StatusForbidden, NewError(ForbiddenCode, "not allowed"))
+		assert.Equal(ForbiddenCode, body.Code)
+		assert.Equal("not allowed", body.Message)
+		assert.Empty(body.Details)
+	})
+
+	t.Run("Should not expose errors without a code", func(t *testing.T) {
+		_, body := writeError(http.StatusInternalServerError, fmt.Errorf("connection refused"))
+		assert.Equal(InternalErrorCode, body.Code)
+		assert.Equal("internal server error", body.Message)
+	})
+
+	t.Run("Should write a timeout", func(t *testing.T) {
+		_, body := writeError(http.StatusGatewayTimeout, fmt.Errorf("query: %w", context.DeadlineExceeded))
+		assert.Equal(TimeoutCode, body.Code)
+	})
+}
@@ -3,20 +3,40 @@ package router
 import (
 	"net/http"
 
+	routerauth "posterr/src/router/auth"
 	routercontent "posterr/src/router/content"
 	routeruser "posterr/src/router/user"
+	"posterr/src/stream"
 	"posterr/src/types"
 
 	"github.com/gorilla/mux"
 )
 
-func CreateRoutes(posts types.Posterr, users types.Users) *mux.Router {
+const streamHomeContentRoute = "StreamHomeContent"
+
+// streamingRoutes keep their connections open, so they have no deadline unless one is set by name
+var streamingRoutes = map[string]struct{}{
+	streamHomeContentRoute: {},
+}
+
+func CreateRoutes(posts types.Posterr, users types.Users, auth types.Auth, notifications types.Notifications, hub *stream.Hub, timeouts RouteTimeouts) *mux.Router {
 	r := mux.NewRouter()
+	r.Use(timeoutMiddleware(timeouts))
+	r.Use(routerauth.NewAuthenticateMiddleware(auth))
+
+	r.Path("/posterr/auth/login").
+		Methods(http.MethodPost).
+		Name("Login").
+		Handler(routerauth.NewLoginHandler(auth))
+	r.Path("/posterr/auth/logout").
+		Methods(http.MethodPost).
+		Name("Logout").
+		Handler(routerauth.RequireUser(routerauth.NewLogoutHandler(auth)))
 
 	r.Path("/posterr/content").
 		Methods(http.MethodPost).
 		Name("CreateContent").
-		Handler(routercontent.NewCreateContentHandler(posts))
+		Handler(routerauth.RequireUser(routercontent.NewCreateContentHandler(posts)))
 	r.Path("/posterr/content").
 		Methods(http.MethodGet).
 		Name("SearchContent").
@@ -25,28 +45,152 @@ func CreateRoutes(posts types.Posterr, users types.Users) *mux.Router {
 		Methods(http.MethodGet).
 		Name("ListHomeContent").
 		Handler(routercontent.NewListHomeContentHandler(posts))
+	r.Path("/posterr/content/post/{postId}").
+		Methods(http.MethodGet).
+		Name("ReadContent").
+		Handler(routercontent.NewReadContentHandler(posts))
+	r.Path("/posterr/content/post/{postId}/revisions").
+		Methods(http.MethodGet).
+		Name("ListContentRevisions").
+		Handler(routercontent.NewListContentRevisionsHandler(posts))
+	r.Path("/posterr/content/{postId}").
+		Methods(http.MethodDelete).
+		Name("DeleteContent").
+		Handler(routerauth.RequireUser(routercontent.NewDeleteContentHandler(posts)))
+	r.Path("/posterr/content/{postId}").
+		Methods(http.MethodPatch).
+		Name("EditContent").
+		Handler(routerauth.RequireUser(routercontent.NewEditContentHandler(posts)))
+	r.Path("/posterr/content/{postId}/replies").
+		Methods(http.MethodPost).
+		Name("CreateReply").
+		Handler(routerauth.RequireUser(routercontent.NewCreateReplyHandler(posts)))
+	r.Path("/posterr/content/{postId}/replies").
+		Methods(http.MethodGet).
+		Name("ListReplies").
+		Handler(routercontent.NewListRepliesHandler(posts))
+	r.Path("/posterr/content/{postId}/like").
+		Methods(http.MethodPost).
+		Name("LikeContent").
+		Handler(routerauth.RequireUser(routercontent.NewLikeContentHandler(posts)))
+	r.Path("/posterr/content/{postId}/like").
+		Methods(http.MethodDelete).
+		Name("UnlikeContent").
+		Handler(routerauth.RequireUser(routercontent.NewUnlikeContentHandler(posts)))
+	r.Path("/posterr/content/{postId}/bookmark").
+		Methods(http.MethodPost).
+		Name("BookmarkContent").
+		Handler(routerauth.RequireUser(routercontent.NewBookmarkContentHandler(posts)))
+	r.Path("/posterr/content/{postId}/bookmark").
+		Methods(http.MethodDelete).
+		Name("UnbookmarkContent").
+		Handler(routerauth.RequireUser(routercontent.NewUnbookmarkContentHandler(posts)))
+	r.Path("/posterr/content/{postId}/likes").
+		Methods(http.MethodGet).
+		Name("ListContentLikers").
+		Handler(routercontent.NewListContentLikersHandler(posts))
 	r.Path("/posterr/content/{username}").
 		Methods(http.MethodGet).
 		Name("ListProfileContent").
 		Handler(routercontent.NewListProfileContentHandler(posts))
 
+	r.Path("/posterr/stream/home").
+		Methods(http.MethodGet).
+		Name(streamHomeContentRoute).
+		Handler(routerauth.RequireUser(routercontent.NewStreamHomeContentHandler(hub, users)))
+
+	r.Path("/posterr/hashtags/trending").
+		Methods(http.MethodGet).
+		Name("ListTrendingHashtags").
+		Handler(routercontent.NewListTrendingHashtagsHandler(posts))
+	r.Path("/posterr/hashtags/{hashtag}").
+		Methods(http.MethodGet).
+		Name("ListHashtagContent").
+		Handler(routercontent.NewListHashtagContentHandler(posts))
+
+	r.Path("/posterr/users").
+		Methods(http.MethodPost).
+		Name("CreateUser").
+		Handler(routeruser.NewCreateUserHandler(users))
 	r.Path("/posterr/users/{username}").
 		Methods(http.MethodGet).
 		Name("ReadUser").
 		Handler(routeruser.NewReadUserHandler(users))
+	r.Path("/posterr/users/{username}").
+		Methods(http.MethodPatch).
+		Name("UpdateUser").
+		Handler(routerauth.RequireUser(routeruser.NewUpdateUserHandler(users)))
 	r.Path("/posterr/users/{username}/followers").
 		Methods(http.MethodGet).
 		Name("ListFollowers").
 		Handler(routeruser.NewListFollowersHandler(users))
+	r.Path("/posterr/users/{username}/following").
+		Methods(http.MethodGet).
+		Name("ListFollowing").
+		Handler(routeruser.NewListFollowingHandler(users))
+	r.Path("/posterr/users/{username}/mentions").
+		Methods(http.MethodGet).
+		Name("ListMentionContent").
+		Handler(routercontent.NewListMentionContentHandler(posts))
+	r.Path("/posterr/users/{username}/bookmarks").
+		Methods(http.MethodGet).
+		Name("ListBookmarkedContent").
+		Handler(routerauth.RequireUser(routercontent.NewListBookmarkedContentHandler(posts)))
 
 	r.Path("/posterr/users/{username}/follow").
 		Methods(http.MethodPost).
 		Name("FollowUser").
-		Handler(routeruser.NewFollowUserHandler(users))
+		Handler(routerauth.RequireUser(routeruser.NewFollowUserHandler(users)))
 	r.Path("/posterr/users/{username}/unfollow").
 		Methods(http.MethodPost).
 		Name("UnfollowUser").
-		Handler(routeruser.NewUnfollowUserHandler(users))
+		Handler(routerauth.RequireUser(routeruser.NewUnfollowUserHandler(users)))
+	r.Path("/posterr/users/{username}/block").
+		Methods(http.MethodPost).
+		Name("BlockUser").
+		Handler(routerauth.RequireUser(routeruser.NewBlockUserHandler(users)))
+	r.Path("/posterr/users/{username}/unblock").
+		Methods(http.MethodPost).
+		Name("UnblockUser").
+		Handler(routerauth.RequireUser(routeruser.NewUnblockUserHandler(users)))
+	r.Path("/posterr/users/{username}/mute").
+		Methods(http.MethodPost).
+		Name("MuteUser").
+		Handler(routerauth.RequireUser(routeruser.NewMuteUserHandler(users)))
+	r.Path("/posterr/users/{username}/unmute").
+		Methods(http.MethodPost).
+		Name("UnmuteUser").
+		Handler(routerauth.RequireUser(routeruser.NewUnmuteUserHandler(users)))
+
+	r.Path("/posterr/users/{username}/privacy").
+		Methods(http.MethodPut).
+		Name("SetUserPrivacy").
+		Handler(routerauth.RequireUser(routeruser.NewSetUserPrivacyHandler(users)))
+	r.Path("/posterr/users/{username}/follow-requests").
+		Methods(http.MethodGet).
+		Name("ListFollowRequests").
+		Handler(routerauth.RequireUser(routeruser.NewListFollowRequestsHandler(users)))
+	r.Path("/posterr/users/{username}/follow-requests/{requester}/approve").
+		Methods(http.MethodPost).
+		Name("ApproveFollowRequest").
+		Handler(routerauth.RequireUser(routeruser.NewApproveFollowRequestHandler(users)))
+	r.Path("/posterr/users/{username}/follow-requests/{requester}/reject").
+		Methods(http.MethodPost).
+		Name("RejectFollowRequest").
+		Handler(routerauth.RequireUser(routeruser.NewRejectFollowRequestHandler(users)))
+
+	r.Path("/posterr/users/{username}/notifications").
+		Methods(http.MethodGet).
+		Name("ListNotifications").
+		Handler(routerauth.RequireUser(routeruser.NewListNotificationsHandler(notifications)))
+	r.Path("/posterr/users/{username}/notifications/read").
+		Methods(http.MethodPost).
+		Name("ReadAllNotifications").
+		Handler(routerauth.RequireUser(routeruser.NewReadAllNotificationsHandler(notifications)))
+	r.Path("/posterr/users/{username}/notifications/{notificationId}/read").
+		Methods(http.MethodPost).
+		Name("ReadNotification").
+		Handler(routerauth.RequireUser(routeruser.NewReadNotificationHandler(notifications)))
 
 	return r
 }
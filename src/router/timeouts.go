@@ -0,0 +1,73 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// RouteTimeouts sets the deadline of each request context by route name.
+// Routes not listed in Routes use Default, except for streaming routes,
+// which have no deadline. A zero duration disables the deadline
+type RouteTimeouts struct {
+	Default time.Duration
+	Routes  map[string]time.Duration
+}
+
+// ParseRouteTimeouts parses a comma separated list of route deadlines,
+// e.g. "SearchContent=2s,ListHomeContent=500ms"
+func ParseRouteTimeouts(value string) (map[string]time.Duration, error) {
+	timeouts := make(map[string]time.Duration)
+	if len(value) == 0 {
+		return timeouts, nil
+	}
+
+	for _, entry := range strings.Split(value, ",") {
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid route timeout %s: expected {route}={duration}", entry)
+		}
+
+		timeout, err := time.ParseDuration(strings.TrimSpace(parts[1]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid route timeout %s: %w", entry, err)
+		}
+
+		timeouts[strings.TrimSpace(parts[0])] = timeout
+	}
+
+	return timeouts, nil
+}
+
+// timeoutMiddleware cancels the request context
+// once the deadline of the matched route is reached
+func timeoutMiddleware(timeouts RouteTimeouts) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			timeout := timeouts.Default
+			if route := mux.CurrentRoute(r); route != nil {
+				if _, isStreaming := streamingRoutes[route.GetName()]; isStreaming {
+					timeout = 0
+				}
+
+				if routeTimeout, exists := timeouts.Routes[route.GetName()]; exists {
+					timeout = routeTimeout
+				}
+			}
+
+			if timeout <= 0 {
+				next.ServeHTTP(rw, r)
+				return
+			}
+
+			ctx, cancel := context.WithTimeout(r.Context(), timeout)
+			defer cancel()
+
+			next.ServeHTTP(rw, r.WithContext(ctx))
+		})
+	}
+}
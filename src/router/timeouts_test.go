@@ -0,0 +1,39 @@
+package router
+
+import (
+	"testing"
+	"time"
+
+	assertions "github.com/stretchr/testify/assert"
+)
+
+func TestParseRouteTimeouts(t *testing.T) {
+	assert := assertions.New(t)
+
+	tests := []struct {
+		name     string
+		value    string
+		expected map[string]time.Duration
+		valid    bool
+	}{
+		{"Should parse no route timeouts", "", map[string]time.Duration{}, true},
+		{"Should parse a single route timeout", "SearchContent=2s", map[string]time.Duration{"SearchContent": 2 * time.Second}, true},
+		{"Should parse route timeouts surrounded by spaces", "A=1s, B= 2s ,C =500ms",
+			map[string]time.Duration{"A": time.Second, "B": 2 * time.Second, "C": 500 * time.Millisecond}, true},
+		{"Should not parse a route timeout without a duration", "SearchContent", nil, false},
+		{"Should not parse an invalid duration", "SearchContent=fast", nil, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			timeouts, err := ParseRouteTimeouts(test.value)
+			if !test.valid {
+				assert.Error(err)
+				return
+			}
+
+			assert.NoError(err)
+			assert.Equal(test.expected, timeouts)
+		})
+	}
+}
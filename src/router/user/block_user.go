@@ -0,0 +1,56 @@
+package user
+
+import (
+	"net/http"
+
+	"posterr/src/router/response"
+	"posterr/src/types"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+type blockUser struct {
+	users  types.Users
+	logger *logrus.Entry
+}
+
+func NewBlockUserHandler(users types.Users) *blockUser {
+	return &blockUser{
+		users:  users,
+		logger: logrus.WithFields(logrus.Fields{"routes": "BlockUser"}),
+	}
+}
+
+func (h *blockUser) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	err := r.ParseForm()
+	if err != nil {
+		h.logger.Errorf("Error parsing form: %s", err)
+		response.WriteError(rw, http.StatusBadRequest,
+			response.NewError(response.InvalidQueryParamCode, "invalid query parameters"))
+
+		return
+	}
+
+	vars := mux.Vars(r)
+	username := vars["username"]
+	targetUsername := parseQueryParam(targetUsernameQuery, r)
+
+	if !isActingUser(r, username) {
+		h.logger.Errorf("Request failed: %s is not allowed to act as %s", actingUser(r), username)
+		response.WriteError(rw, http.StatusForbidden,
+			response.NewError(response.ForbiddenCode, "not allowed to act as another user"))
+
+		return
+	}
+
+	err = h.users.BlockUser(r.Context(), targetUsername, username)
+	if err != nil {
+		h.logger.Errorf("Request failed: %s", err)
+		response.WriteError(rw, getStatusCodeFromError(err), err)
+
+		return
+	}
+
+	rw.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,60 @@
+package user
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"posterr/src/router/response"
+	storageauth "posterr/src/storage/auth"
+	"posterr/src/types"
+
+	"github.com/sirupsen/logrus"
+)
+
+type createUser struct {
+	users  types.Users
+	logger *logrus.Entry
+}
+
+func NewCreateUserHandler(users types.Users) *createUser {
+	return &createUser{
+		users:  users,
+		logger: logrus.WithFields(logrus.Fields{"routes": "CreateUser"}),
+	}
+}
+
+func (h *createUser) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		h.logger.Errorf("Request failed: %s", err)
+		response.WriteError(rw, http.StatusInternalServerError, err)
+
+		return
+	}
+
+	dto := CreateUserDTO{}
+	err = json.Unmarshal(body, &dto)
+	if err != nil {
+		h.logger.Errorf("Request failed: %s", err)
+		response.WriteError(rw, http.StatusBadRequest,
+			response.NewError(response.InvalidRequestBodyCode, "invalid request body: body must be a valid JSON"))
+
+		return
+	}
+
+	// the user and their password are stored together, so that no user is left without one
+	passwordHash, err := storageauth.HashPassword(dto.Password)
+	if err == nil {
+		err = h.users.RegisterUser(r.Context(), dto.Username, passwordHash)
+	}
+
+	if err != nil {
+		h.logger.Errorf("Request failed: %s", err)
+		response.WriteError(rw, getStatusCodeFromError(err), err)
+
+		return
+	}
+
+	rw.WriteHeader(http.StatusCreated)
+}
@@ -0,0 +1,19 @@
+package user
+
+type CreateUserDTO struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type SetUserPrivacyDTO struct {
+	Private *bool `json:"private"`
+}
+
+// UpdateUserProfileDTO only changes the fields present in the body
+type UpdateUserProfileDTO struct {
+	DisplayName *string `json:"display_name"`
+	Bio         *string `json:"bio"`
+	Location    *string `json:"location"`
+	Website     *string `json:"website"`
+	AvatarURL   *string `json:"avatar_url"`
+}
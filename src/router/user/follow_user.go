@@ -1,9 +1,9 @@
 package user
 
 import (
-	"fmt"
 	"net/http"
 
+	"posterr/src/router/response"
 	"posterr/src/types"
 
 	"github.com/gorilla/mux"
@@ -26,21 +26,43 @@ func (h *followUser) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 	err := r.ParseForm()
 	if err != nil {
 		h.logger.Errorf("Error parsing form: %s", err)
-		rw.WriteHeader(http.StatusInternalServerError)
-		rw.Write([]byte("internal server error"))
+		response.WriteError(rw, http.StatusBadRequest,
+			response.NewError(response.InvalidQueryParamCode, "invalid query parameters"))
+
+		return
 	}
 
 	vars := mux.Vars(r)
 	username := vars["username"]
 	targetUsername := parseQueryParam(targetUsernameQuery, r)
 
-	err = h.users.FollowUser(targetUsername, username)
+	if !isActingUser(r, username) {
+		h.logger.Errorf("Request failed: %s is not allowed to act as %s", actingUser(r), username)
+		response.WriteError(rw, http.StatusForbidden,
+			response.NewError(response.ForbiddenCode, "not allowed to act as another user"))
+
+		return
+	}
+
+	err = h.users.FollowUser(r.Context(), targetUsername, username)
+	if err != nil {
+		h.logger.Errorf("Request failed: %s", err)
+		response.WriteError(rw, getStatusCodeFromError(err), err)
+
+		return
+	}
+
+	// following private users is pending until they approve it
+	isFollowing, err := h.users.IsFollowingUser(r.Context(), targetUsername, username)
 	if err != nil {
-		statusCode := getStatusCodeFromError(err)
-		rw.WriteHeader(statusCode)
 		h.logger.Errorf("Request failed: %s", err)
-		message := fmt.Sprintf("could not complete follow/unfollow operation: %s", err.Error())
-		rw.Write([]byte(message))
+		response.WriteError(rw, getStatusCodeFromError(err), err)
+
+		return
+	}
+
+	if !isFollowing {
+		rw.WriteHeader(http.StatusAccepted)
 
 		return
 	}
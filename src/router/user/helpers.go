@@ -1,13 +1,22 @@
 package user
 
 import (
+	"context"
+	"errors"
 	"net/http"
+	"strconv"
 
+	"posterr/src/router/response"
+	storageauth "posterr/src/storage/auth"
+	storagenotifications "posterr/src/storage/notifications"
 	storageusers "posterr/src/storage/users"
+	"posterr/src/types"
 )
 
 const (
+	cursorQuery         = "cursor"
 	targetUsernameQuery = "target"
+	unreadQuery         = "unread"
 )
 
 func parseQueryParam(param string, r *http.Request) string {
@@ -18,12 +27,53 @@ func parseQueryParam(param string, r *http.Request) string {
 	return ""
 }
 
+func parseBoolQueryParam(param string, r *http.Request) (bool, error) {
+	paramValue, exists := r.Form[param]
+	if exists {
+		return strconv.ParseBool(paramValue[0])
+	}
+	return false, nil
+}
+
+// actingUser returns the authenticated user of a request
+func actingUser(r *http.Request) string {
+	username, _ := types.UserFromContext(r.Context())
+	return username
+}
+
+// isActingUser checks if username is the authenticated user of a request
+func isActingUser(r *http.Request, username string) bool {
+	authenticated, exists := types.UserFromContext(r.Context())
+	return exists && authenticated == username
+}
+
 func getStatusCodeFromError(err error) int {
-	switch err.(type) {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return http.StatusGatewayTimeout
+	}
+
+	// storage errors may be wrapped, e.g., along with the Postgres error causing them
+	var codedErr response.CodedError
+	if !errors.As(err, &codedErr) {
+		return http.StatusInternalServerError
+	}
+
+	switch codedErr.(type) {
 	case storageusers.SelfFollowError,
-		storageusers.UserAlreadyFollowsError, storageusers.UserDoesNotFollowError:
+		storageusers.UserAlreadyFollowsError, storageusers.UserDoesNotFollowError,
+		storageusers.SelfBlockError, storageusers.UserAlreadyBlockedError, storageusers.UserNotBlockedError,
+		storageusers.SelfMuteError, storageusers.UserAlreadyMutedError, storageusers.UserNotMutedError,
+		storageusers.FollowRequestAlreadyExistsError,
+		storageusers.ProfileFieldTooLongError, storageusers.InvalidProfileFieldError,
+		storageusers.InvalidUsernameError, storageusers.UsernameExceededMaximumCharsError,
+		storageauth.PasswordTooShortError, storageusers.InvalidCursorError, storagenotifications.InvalidCursorError:
 		return http.StatusBadRequest
-	case storageusers.UserDoesNotExistError:
+	case storageusers.UserBlockedError:
+		return http.StatusForbidden
+	case storageusers.UserAlreadyExistsError:
+		return http.StatusConflict
+	case storageusers.UserDoesNotExistError, storageusers.FollowRequestDoesNotExistError,
+		storagenotifications.NotificationDoesNotExistError:
 		return http.StatusNotFound
 	default:
 		return http.StatusInternalServerError
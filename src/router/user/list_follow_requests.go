@@ -0,0 +1,66 @@
+package user
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"posterr/src/router/response"
+	"posterr/src/types"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+type listFollowRequests struct {
+	users  types.Users
+	logger *logrus.Entry
+}
+
+func NewListFollowRequestsHandler(users types.Users) *listFollowRequests {
+	return &listFollowRequests{
+		users:  users,
+		logger: logrus.WithFields(logrus.Fields{"routes": "ListFollowRequests"}),
+	}
+}
+
+func (h *listFollowRequests) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	err := r.ParseForm()
+	if err != nil {
+		h.logger.Errorf("Error parsing form: %s", err)
+		response.WriteError(rw, http.StatusBadRequest,
+			response.NewError(response.InvalidQueryParamCode, "invalid query parameters"))
+
+		return
+	}
+
+	vars := mux.Vars(r)
+	username := vars["username"]
+
+	if !isActingUser(r, username) {
+		h.logger.Errorf("Request failed: %s is not allowed to act as %s", actingUser(r), username)
+		response.WriteError(rw, http.StatusForbidden,
+			response.NewError(response.ForbiddenCode, "not allowed to act as another user"))
+
+		return
+	}
+
+	cursor := parseQueryParam(cursorQuery, r)
+
+	requests, err := h.users.ListFollowRequests(r.Context(), username, types.Page{Cursor: cursor})
+	if err != nil {
+		h.logger.Errorf("Request failed: %s", err)
+		response.WriteError(rw, getStatusCodeFromError(err), err)
+
+		return
+	}
+
+	requestsBytes, err := json.Marshal(requests)
+	if err != nil {
+		h.logger.Errorf("Request failed: %s", err)
+		response.WriteError(rw, http.StatusInternalServerError, err)
+
+		return
+	}
+
+	rw.Write(requestsBytes)
+}
@@ -2,9 +2,9 @@ package user
 
 import (
 	"encoding/json"
-	"fmt"
 	"net/http"
 
+	"posterr/src/router/response"
 	"posterr/src/types"
 
 	"github.com/gorilla/mux"
@@ -24,16 +24,23 @@ func NewListFollowersHandler(users types.Users) *listFollowers {
 }
 
 func (h *listFollowers) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	err := r.ParseForm()
+	if err != nil {
+		h.logger.Errorf("Error parsing form: %s", err)
+		response.WriteError(rw, http.StatusBadRequest,
+			response.NewError(response.InvalidQueryParamCode, "invalid query parameters"))
+
+		return
+	}
+
 	vars := mux.Vars(r)
 	username := vars["username"]
+	cursor := parseQueryParam(cursorQuery, r)
 
-	followers, err := h.users.ListFollowers(username)
+	followers, err := h.users.ListFollowers(r.Context(), username, types.Page{Cursor: cursor})
 	if err != nil {
-		statusCode := getStatusCodeFromError(err)
-		rw.WriteHeader(statusCode)
 		h.logger.Errorf("Request failed: %s", err)
-		message := fmt.Sprintf("could not complete follow/unfollow operation: %s", err.Error())
-		rw.Write([]byte(message))
+		response.WriteError(rw, getStatusCodeFromError(err), err)
 
 		return
 	}
@@ -41,8 +48,7 @@ func (h *listFollowers) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 	followersBytes, err := json.Marshal(followers)
 	if err != nil {
 		h.logger.Errorf("Request failed: %s", err)
-		rw.WriteHeader(http.StatusInternalServerError)
-		rw.Write([]byte("internal server error"))
+		response.WriteError(rw, http.StatusInternalServerError, err)
 
 		return
 	}
@@ -0,0 +1,57 @@
+package user
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"posterr/src/router/response"
+	"posterr/src/types"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+type listFollowing struct {
+	users  types.Users
+	logger *logrus.Entry
+}
+
+func NewListFollowingHandler(users types.Users) *listFollowing {
+	return &listFollowing{
+		users:  users,
+		logger: logrus.WithFields(logrus.Fields{"routes": "ListFollowing"}),
+	}
+}
+
+func (h *listFollowing) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	err := r.ParseForm()
+	if err != nil {
+		h.logger.Errorf("Error parsing form: %s", err)
+		response.WriteError(rw, http.StatusBadRequest,
+			response.NewError(response.InvalidQueryParamCode, "invalid query parameters"))
+
+		return
+	}
+
+	vars := mux.Vars(r)
+	username := vars["username"]
+	cursor := parseQueryParam(cursorQuery, r)
+
+	following, err := h.users.ListFollowing(r.Context(), username, types.Page{Cursor: cursor})
+	if err != nil {
+		h.logger.Errorf("Request failed: %s", err)
+		response.WriteError(rw, getStatusCodeFromError(err), err)
+
+		return
+	}
+
+	followingBytes, err := json.Marshal(following)
+	if err != nil {
+		h.logger.Errorf("Request failed: %s", err)
+		response.WriteError(rw, http.StatusInternalServerError, err)
+
+		return
+	}
+
+	rw.Write(followingBytes)
+}
@@ -0,0 +1,75 @@
+package user
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"posterr/src/router/response"
+	"posterr/src/types"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+type listNotifications struct {
+	notifications types.Notifications
+	logger        *logrus.Entry
+}
+
+func NewListNotificationsHandler(notifications types.Notifications) *listNotifications {
+	return &listNotifications{
+		notifications: notifications,
+		logger:        logrus.WithFields(logrus.Fields{"routes": "ListNotifications"}),
+	}
+}
+
+func (h *listNotifications) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	err := r.ParseForm()
+	if err != nil {
+		h.logger.Errorf("Error parsing form: %s", err)
+		response.WriteError(rw, http.StatusBadRequest,
+			response.NewError(response.InvalidQueryParamCode, "invalid query parameters"))
+
+		return
+	}
+
+	vars := mux.Vars(r)
+	username := vars["username"]
+
+	if !isActingUser(r, username) {
+		h.logger.Errorf("Request failed: %s is not allowed to act as %s", actingUser(r), username)
+		response.WriteError(rw, http.StatusForbidden,
+			response.NewError(response.ForbiddenCode, "not allowed to act as another user"))
+
+		return
+	}
+
+	unreadOnly, err := parseBoolQueryParam(unreadQuery, r)
+	if err != nil {
+		h.logger.Errorf("Error parsing unread: %s", err)
+		response.WriteError(rw, http.StatusBadRequest,
+			response.NewError(response.InvalidQueryParamCode, "invalid unread: unread must be either true or false"))
+
+		return
+	}
+
+	cursor := parseQueryParam(cursorQuery, r)
+
+	notifications, err := h.notifications.ListNotifications(r.Context(), username, unreadOnly, types.Page{Cursor: cursor})
+	if err != nil {
+		h.logger.Errorf("Request failed: %s", err)
+		response.WriteError(rw, getStatusCodeFromError(err), err)
+
+		return
+	}
+
+	notificationsBytes, err := json.Marshal(notifications)
+	if err != nil {
+		h.logger.Errorf("Request failed: %s", err)
+		response.WriteError(rw, http.StatusInternalServerError, err)
+
+		return
+	}
+
+	rw.Write(notificationsBytes)
+}
@@ -0,0 +1,46 @@
+package user
+
+import (
+	"net/http"
+
+	"posterr/src/router/response"
+	"posterr/src/types"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+type readAllNotifications struct {
+	notifications types.Notifications
+	logger        *logrus.Entry
+}
+
+func NewReadAllNotificationsHandler(notifications types.Notifications) *readAllNotifications {
+	return &readAllNotifications{
+		notifications: notifications,
+		logger:        logrus.WithFields(logrus.Fields{"routes": "ReadAllNotifications"}),
+	}
+}
+
+func (h *readAllNotifications) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	username := vars["username"]
+
+	if !isActingUser(r, username) {
+		h.logger.Errorf("Request failed: %s is not allowed to act as %s", actingUser(r), username)
+		response.WriteError(rw, http.StatusForbidden,
+			response.NewError(response.ForbiddenCode, "not allowed to act as another user"))
+
+		return
+	}
+
+	err := h.notifications.MarkAllNotificationsRead(r.Context(), username)
+	if err != nil {
+		h.logger.Errorf("Request failed: %s", err)
+		response.WriteError(rw, getStatusCodeFromError(err), err)
+
+		return
+	}
+
+	rw.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,56 @@
+package user
+
+import (
+	"net/http"
+	"strconv"
+
+	"posterr/src/router/response"
+	"posterr/src/types"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+type readNotification struct {
+	notifications types.Notifications
+	logger        *logrus.Entry
+}
+
+func NewReadNotificationHandler(notifications types.Notifications) *readNotification {
+	return &readNotification{
+		notifications: notifications,
+		logger:        logrus.WithFields(logrus.Fields{"routes": "ReadNotification"}),
+	}
+}
+
+func (h *readNotification) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	username := vars["username"]
+
+	if !isActingUser(r, username) {
+		h.logger.Errorf("Request failed: %s is not allowed to act as %s", actingUser(r), username)
+		response.WriteError(rw, http.StatusForbidden,
+			response.NewError(response.ForbiddenCode, "not allowed to act as another user"))
+
+		return
+	}
+
+	notificationId, err := strconv.ParseInt(vars["notificationId"], 10, 64)
+	if err != nil {
+		h.logger.Errorf("Error parsing notification id: %s", err)
+		response.WriteError(rw, http.StatusBadRequest,
+			response.NewError(response.InvalidRequestCode, "invalid notification id: notification id must be an integer"))
+
+		return
+	}
+
+	err = h.notifications.MarkNotificationRead(r.Context(), username, notificationId)
+	if err != nil {
+		h.logger.Errorf("Request failed: %s", err)
+		response.WriteError(rw, getStatusCodeFromError(err), err)
+
+		return
+	}
+
+	rw.WriteHeader(http.StatusNoContent)
+}
@@ -2,9 +2,9 @@ package user
 
 import (
 	"encoding/json"
-	"fmt"
 	"net/http"
 
+	"posterr/src/router/response"
 	"posterr/src/types"
 
 	"github.com/gorilla/mux"
@@ -27,13 +27,10 @@ func (h *readUser) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	username := vars["username"]
 
-	user, err := h.users.GetUserProfile(username)
+	user, err := h.users.GetUserProfile(r.Context(), username)
 	if err != nil {
-		statusCode := getStatusCodeFromError(err)
-		rw.WriteHeader(statusCode)
 		h.logger.Errorf("Request failed: %s", err)
-		message := fmt.Sprintf("could not get user details: %s", err)
-		rw.Write([]byte(message))
+		response.WriteError(rw, getStatusCodeFromError(err), err)
 
 		return
 	}
@@ -41,8 +38,7 @@ func (h *readUser) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 	userBytes, err := json.Marshal(user)
 	if err != nil {
 		h.logger.Errorf("Request failed: %s", err)
-		rw.WriteHeader(http.StatusInternalServerError)
-		rw.Write([]byte("internal server error"))
+		response.WriteError(rw, http.StatusInternalServerError, err)
 
 		return
 	}
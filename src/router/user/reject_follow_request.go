@@ -0,0 +1,47 @@
+package user
+
+import (
+	"net/http"
+
+	"posterr/src/router/response"
+	"posterr/src/types"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+type rejectFollowRequest struct {
+	users  types.Users
+	logger *logrus.Entry
+}
+
+func NewRejectFollowRequestHandler(users types.Users) *rejectFollowRequest {
+	return &rejectFollowRequest{
+		users:  users,
+		logger: logrus.WithFields(logrus.Fields{"routes": "RejectFollowRequest"}),
+	}
+}
+
+func (h *rejectFollowRequest) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	username := vars["username"]
+	requester := vars["requester"]
+
+	if !isActingUser(r, username) {
+		h.logger.Errorf("Request failed: %s is not allowed to act as %s", actingUser(r), username)
+		response.WriteError(rw, http.StatusForbidden,
+			response.NewError(response.ForbiddenCode, "not allowed to act as another user"))
+
+		return
+	}
+
+	err := h.users.RejectFollowRequest(r.Context(), username, requester)
+	if err != nil {
+		h.logger.Errorf("Request failed: %s", err)
+		response.WriteError(rw, getStatusCodeFromError(err), err)
+
+		return
+	}
+
+	rw.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,66 @@
+package user
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"posterr/src/router/response"
+	"posterr/src/types"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+type setUserPrivacy struct {
+	users  types.Users
+	logger *logrus.Entry
+}
+
+func NewSetUserPrivacyHandler(users types.Users) *setUserPrivacy {
+	return &setUserPrivacy{
+		users:  users,
+		logger: logrus.WithFields(logrus.Fields{"routes": "SetUserPrivacy"}),
+	}
+}
+
+func (h *setUserPrivacy) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	username := vars["username"]
+
+	if !isActingUser(r, username) {
+		h.logger.Errorf("Request failed: %s is not allowed to act as %s", actingUser(r), username)
+		response.WriteError(rw, http.StatusForbidden,
+			response.NewError(response.ForbiddenCode, "not allowed to act as another user"))
+
+		return
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		h.logger.Errorf("Request failed: %s", err)
+		response.WriteError(rw, http.StatusInternalServerError, err)
+
+		return
+	}
+
+	dto := SetUserPrivacyDTO{}
+	err = json.Unmarshal(body, &dto)
+	if err != nil || dto.Private == nil {
+		h.logger.Errorf("Request failed: invalid body %s", body)
+		response.WriteError(rw, http.StatusBadRequest,
+			response.NewError(response.InvalidRequestBodyCode, "invalid request body: body must be a JSON with a boolean private"))
+
+		return
+	}
+
+	err = h.users.SetUserPrivate(r.Context(), username, *dto.Private)
+	if err != nil {
+		h.logger.Errorf("Request failed: %s", err)
+		response.WriteError(rw, getStatusCodeFromError(err), err)
+
+		return
+	}
+
+	rw.WriteHeader(http.StatusNoContent)
+}
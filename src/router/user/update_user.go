@@ -0,0 +1,88 @@
+package user
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"posterr/src/router/response"
+	"posterr/src/types"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+type updateUser struct {
+	users  types.Users
+	logger *logrus.Entry
+}
+
+func NewUpdateUserHandler(users types.Users) *updateUser {
+	return &updateUser{
+		users:  users,
+		logger: logrus.WithFields(logrus.Fields{"routes": "UpdateUser"}),
+	}
+}
+
+func (h *updateUser) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	username := vars["username"]
+
+	if !isActingUser(r, username) {
+		h.logger.Errorf("Request failed: %s is not allowed to act as %s", actingUser(r), username)
+		response.WriteError(rw, http.StatusForbidden,
+			response.NewError(response.ForbiddenCode, "not allowed to act as another user"))
+
+		return
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		h.logger.Errorf("Request failed: %s", err)
+		response.WriteError(rw, http.StatusInternalServerError, err)
+
+		return
+	}
+
+	dto := UpdateUserProfileDTO{}
+	err = json.Unmarshal(body, &dto)
+	if err != nil {
+		h.logger.Errorf("Request failed: %s", err)
+		response.WriteError(rw, http.StatusBadRequest,
+			response.NewError(response.InvalidRequestBodyCode, "invalid request body: body must be a valid JSON"))
+
+		return
+	}
+
+	err = h.users.UpdateUserProfile(r.Context(), username, types.PosterrProfileUpdate{
+		DisplayName: dto.DisplayName,
+		Bio:         dto.Bio,
+		Location:    dto.Location,
+		Website:     dto.Website,
+		AvatarURL:   dto.AvatarURL,
+	})
+	if err != nil {
+		h.logger.Errorf("Request failed: %s", err)
+		response.WriteError(rw, getStatusCodeFromError(err), err)
+
+		return
+	}
+
+	user, err := h.users.GetUserProfile(r.Context(), username)
+	if err != nil {
+		h.logger.Errorf("Request failed: %s", err)
+		response.WriteError(rw, getStatusCodeFromError(err), err)
+
+		return
+	}
+
+	userBytes, err := json.Marshal(user)
+	if err != nil {
+		h.logger.Errorf("Request failed: %s", err)
+		response.WriteError(rw, http.StatusInternalServerError, err)
+
+		return
+	}
+
+	rw.Write(userBytes)
+}
@@ -0,0 +1,95 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"posterr/src/types"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type authBacked struct {
+	// The connection pool to the database
+	pool *pgxpool.Pool
+}
+
+func NewAuthBacked(pool *pgxpool.Pool) *authBacked {
+	return &authBacked{
+		pool: pool,
+	}
+}
+
+// SetPassword sets or replaces the password of a user
+func (ab *authBacked) SetPassword(ctx context.Context, username, password string) error {
+	if err := ValidatePassword(password); err != nil {
+		return err
+	}
+
+	passwordHash, err := hashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	_, err = ab.pool.Exec(ctx, upsertCredentials, username, passwordHash)
+	if err != nil {
+		err = fmt.Errorf("could not insert into credentials: %w", err)
+		return translateError(err, username)
+	}
+
+	return nil
+}
+
+// Login checks the credentials of a user and starts a new session
+func (ab *authBacked) Login(ctx context.Context, username, password string) (types.PosterrSession, error) {
+	var passwordHash string
+	row := ab.pool.QueryRow(ctx, selectPasswordHash, username)
+	if err := row.Scan(&passwordHash); err != nil {
+		// unknown usernames are verified as well, so that they take as long to be rejected
+		verifyPassword(password, dummyPasswordHash)
+
+		err = fmt.Errorf("could not scan selectPasswordHash rows: %w", err)
+		return types.PosterrSession{}, translateError(err, username)
+	}
+
+	if !verifyPassword(password, passwordHash) {
+		return types.PosterrSession{}, InvalidCredentialsError{}
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		return types.PosterrSession{}, err
+	}
+
+	expiresAt := time.Now().Add(sessionDuration)
+	_, err = ab.pool.Exec(ctx, "INSERT INTO sessions (token_hash, username, expires_at) VALUES ($1, $2, $3)",
+		hashToken(token), username, expiresAt)
+	if err != nil {
+		return types.PosterrSession{}, fmt.Errorf("could not insert into sessions: %w", err)
+	}
+
+	return types.PosterrSession{Token: token, ExpiresAt: expiresAt}, nil
+}
+
+// Logout ends the session of a token
+func (ab *authBacked) Logout(ctx context.Context, token string) error {
+	_, err := ab.pool.Exec(ctx, "DELETE FROM sessions WHERE token_hash = $1", hashToken(token))
+	if err != nil {
+		return fmt.Errorf("could not delete row from sessions: %w", err)
+	}
+
+	return nil
+}
+
+// Authenticate returns the username a valid token belongs to
+func (ab *authBacked) Authenticate(ctx context.Context, token string) (string, error) {
+	var username string
+	row := ab.pool.QueryRow(ctx, selectSessionUser, hashToken(token))
+	if err := row.Scan(&username); err != nil {
+		err = fmt.Errorf("could not scan selectSessionUser rows: %w", err)
+		return "", translateSessionError(err)
+	}
+
+	return username, nil
+}
@@ -0,0 +1,95 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"posterr/src/storage/memory"
+	"posterr/src/types"
+)
+
+type authMemory struct {
+	store *memory.Store
+}
+
+func NewAuthMemory(store *memory.Store) *authMemory {
+	return &authMemory{
+		store: store,
+	}
+}
+
+// SetPassword sets or replaces the password of a user
+func (am *authMemory) SetPassword(ctx context.Context, username, password string) error {
+	if err := ValidatePassword(password); err != nil {
+		return err
+	}
+
+	passwordHash, err := hashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	am.store.Lock()
+	defer am.store.Unlock()
+
+	if _, exists := am.store.Users[username]; !exists {
+		return UserDoesNotExistError{username}
+	}
+
+	am.store.Credentials[username] = passwordHash
+	return nil
+}
+
+// Login checks the credentials of a user and starts a new session.
+// The password is verified without holding the store lock, since hashing it is slow
+func (am *authMemory) Login(ctx context.Context, username, password string) (types.PosterrSession, error) {
+	am.store.RLock()
+	passwordHash, exists := am.store.Credentials[username]
+	am.store.RUnlock()
+
+	if !exists {
+		passwordHash = dummyPasswordHash
+	}
+
+	if !verifyPassword(password, passwordHash) || !exists {
+		return types.PosterrSession{}, InvalidCredentialsError{}
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		return types.PosterrSession{}, err
+	}
+
+	am.store.Lock()
+	defer am.store.Unlock()
+
+	expiresAt := time.Now().Add(sessionDuration)
+	am.store.Sessions[hashToken(token)] = memory.Session{
+		Username:  username,
+		ExpiresAt: expiresAt,
+	}
+
+	return types.PosterrSession{Token: token, ExpiresAt: expiresAt}, nil
+}
+
+// Logout ends the session of a token
+func (am *authMemory) Logout(ctx context.Context, token string) error {
+	am.store.Lock()
+	defer am.store.Unlock()
+
+	delete(am.store.Sessions, hashToken(token))
+	return nil
+}
+
+// Authenticate returns the username a valid token belongs to
+func (am *authMemory) Authenticate(ctx context.Context, token string) (string, error) {
+	am.store.RLock()
+	defer am.store.RUnlock()
+
+	session, exists := am.store.Sessions[hashToken(token)]
+	if !exists || !session.ExpiresAt.After(time.Now()) {
+		return "", InvalidTokenError{}
+	}
+
+	return session.Username, nil
+}
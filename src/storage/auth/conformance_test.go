@@ -0,0 +1,15 @@
+package auth_test
+
+import (
+	"testing"
+
+	"posterr/src/test/conformance"
+)
+
+func TestMemoryConformance(t *testing.T) {
+	conformance.RunAuth(t, conformance.MemoryFactory)
+}
+
+func TestPostgresConformance(t *testing.T) {
+	conformance.RunAuth(t, conformance.PostgresFactory)
+}
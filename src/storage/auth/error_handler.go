@@ -0,0 +1,29 @@
+package auth
+
+import (
+	"errors"
+
+	"posterr/src/storage/pgerrors"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// translateError returns the storage error a Postgres error stands for
+func translateError(err error, username string) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return pgerrors.Wrap(InvalidCredentialsError{}, err)
+	}
+
+	return pgerrors.Translate(err,
+		pgerrors.Rule{Code: pgerrors.ForeignKeyViolation, Constraint: "credentials_username_fkey", Err: UserDoesNotExistError{username}},
+	)
+}
+
+// translateSessionError returns the storage error a Postgres error
+// raised while a session is looked up stands for
+func translateSessionError(err error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return pgerrors.Wrap(InvalidTokenError{}, err)
+	}
+	return err
+}
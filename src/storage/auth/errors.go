@@ -0,0 +1,51 @@
+package auth
+
+import "fmt"
+
+type UserDoesNotExistError struct {
+	username string
+}
+
+func (e UserDoesNotExistError) Error() string {
+	return fmt.Sprintf("username %s is not registered", e.username)
+}
+
+func (e UserDoesNotExistError) Code() string {
+	return "user_not_found"
+}
+
+func (e UserDoesNotExistError) Details() map[string]string {
+	return map[string]string{
+		"username": e.username,
+	}
+}
+
+type PasswordTooShortError struct{}
+
+func (e PasswordTooShortError) Error() string {
+	return fmt.Sprintf("password must have at least %d characters", minPasswordChars)
+}
+
+func (e PasswordTooShortError) Code() string {
+	return "password_too_short"
+}
+
+type InvalidCredentialsError struct{}
+
+func (e InvalidCredentialsError) Error() string {
+	return "invalid username or password"
+}
+
+func (e InvalidCredentialsError) Code() string {
+	return "invalid_credentials"
+}
+
+type InvalidTokenError struct{}
+
+func (e InvalidTokenError) Error() string {
+	return "invalid or expired token"
+}
+
+func (e InvalidTokenError) Code() string {
+	return "invalid_token"
+}
@@ -0,0 +1,106 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+/*
+	Password hashes are stored as:  pbkdf2-sha256${iterations}${salt}${hash}
+	Session tokens are only stored as their SHA-256 hash.
+*/
+
+const (
+	hashAlgorithm    = "pbkdf2-sha256"
+	hashIterations   = 120000
+	hashLength       = 32
+	saltLength       = 16
+	tokenLength      = 32
+	minPasswordChars = 8
+
+	sessionDuration = 24 * time.Hour
+
+	// dummyPasswordHash is verified when logging in as a user without credentials, so that
+	// unknown usernames take as long to be rejected as wrong passwords. Its password is unknown
+	dummyPasswordHash = "pbkdf2-sha256$120000$FH9bvUJNo8a5s+6dbuz1Ag$3p8S5r5pKpUAzSDj7j9KrVqiPxo1u/I6hzgLNVy8WB4"
+)
+
+// hashPassword returns the encoded hash of password using a random salt
+func hashPassword(password string) (string, error) {
+	salt := make([]byte, saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("could not generate salt: %w", err)
+	}
+
+	hash := pbkdf2.Key([]byte(password), salt, hashIterations, hashLength, sha256.New)
+	return fmt.Sprintf("%s$%d$%s$%s", hashAlgorithm, hashIterations,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(hash)), nil
+}
+
+// verifyPassword checks if password matches an encoded hash
+func verifyPassword(password, encodedHash string) bool {
+	parts := strings.Split(encodedHash, "$")
+	if len(parts) != 4 || parts[0] != hashAlgorithm {
+		return false
+	}
+
+	iterations, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return false
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return false
+	}
+
+	expected, err := base64.RawStdEncoding.DecodeString(parts[3])
+	if err != nil {
+		return false
+	}
+
+	hash := pbkdf2.Key([]byte(password), salt, iterations, len(expected), sha256.New)
+	return subtle.ConstantTimeCompare(hash, expected) == 1
+}
+
+// ValidatePassword checks if password can be set to a user
+func ValidatePassword(password string) error {
+	if utf8.RuneCountInString(password) < minPasswordChars {
+		return PasswordTooShortError{}
+	}
+	return nil
+}
+
+// HashPassword validates password and returns its encoded hash,
+// so that credentials can be stored along with other writes
+func HashPassword(password string) (string, error) {
+	if err := ValidatePassword(password); err != nil {
+		return "", err
+	}
+	return hashPassword(password)
+}
+
+// generateToken returns a random session token
+func generateToken() (string, error) {
+	token := make([]byte, tokenLength)
+	if _, err := rand.Read(token); err != nil {
+		return "", fmt.Errorf("could not generate token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(token), nil
+}
+
+// hashToken returns the hash a session token is stored by
+func hashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,16 @@
+package auth
+
+const (
+	upsertCredentials = `INSERT INTO credentials (username, password_hash)
+                 VALUES ($1, $2)
+                 ON CONFLICT (username)
+                 DO UPDATE SET password_hash = EXCLUDED.password_hash, updated_at = NOW()`
+
+	selectPasswordHash = `SELECT password_hash
+                 FROM credentials
+                 WHERE username = $1`
+
+	selectSessionUser = `SELECT username
+                 FROM sessions
+                 WHERE token_hash = $1 AND expires_at > NOW()`
+)
@@ -3,53 +3,101 @@ package db
 import (
 	"context"
 	"fmt"
-	"os"
-	"strings"
+	"sync"
+	"time"
 
+	"posterr/src/storage/pgerrors"
+
+	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 	"github.com/sirupsen/logrus"
 )
 
 /*
-	DATABASE_URL:               postgres://{user}:{password}@{hostname}:{port}/{database-name}
+	Connection URL:             postgres://{user}:{password}@{hostname}:{port}/{database-name}
 */
 
 const (
 	DatabaseName = "posterr"
 
-	connectionURL  = "postgres://localhost:5432"
-	envDatabaseURL = "DATABASE_URL"
-
-	databaseCreationErrorCode = "SQLSTATE 42P04"
-	tableCreationErrorCode    = "SQLSTATE 42P07"
+	connectionURL = "postgres://localhost:5432"
 )
 
+type PoolConfig struct {
+	// Maximum number of connections kept by the pool
+	MaxConns int32
+	// Minimum number of connections kept open by the pool
+	MinConns int32
+	// Duration after which a connection is closed and replaced
+	MaxConnLifetime time.Duration
+	// Interval between health checks of idle connections
+	HealthCheckPeriod time.Duration
+}
+
 type postgresDB struct {
+	sync.Mutex
 	databaseName string
+	config       PoolConfig
+	// The long-lived pool shared by every caller of Connect
+	pool *pgxpool.Pool
 }
 
 type ConnectDB interface {
 	Connect() (*pgxpool.Pool, error)
+	Close()
 	InitializeDB() error
+	Migrate(direction string) error
+	MigrationStatus() ([]MigrationStatus, error)
 }
 
-func NewDatabase(dbName string) *postgresDB {
+func DefaultPoolConfig() PoolConfig {
+	return PoolConfig{
+		MaxConns:          10,
+		MinConns:          2,
+		MaxConnLifetime:   time.Hour,
+		HealthCheckPeriod: time.Minute,
+	}
+}
+
+func NewDatabase(dbName string, config PoolConfig) *postgresDB {
 	return &postgresDB{
 		databaseName: dbName,
+		config:       config,
 	}
 }
 
-// Connect connects to the default database
+// Connect returns the connection pool of the database.
+// The pool is created on the first call and shared by the following ones,
+// thus callers must not close it. Use Close instead, once it is no longer needed
 func (pg *postgresDB) Connect() (*pgxpool.Pool, error) {
-	conn, err := connect(pg.databaseName)
+	pg.Lock()
+	defer pg.Unlock()
+
+	if pg.pool != nil {
+		return pg.pool, nil
+	}
+
+	pool, err := connect(pg.databaseName, pg.config)
 	if err != nil {
 		return nil, err
 	}
 
-	return conn, err
+	pg.pool = pool
+	return pool, nil
 }
 
-// InitializeDB creates a database and its tables
+// Close closes the connection pool of the database, if any
+func (pg *postgresDB) Close() {
+	pg.Lock()
+	defer pg.Unlock()
+
+	if pg.pool != nil {
+		pg.pool.Close()
+		pg.pool = nil
+	}
+}
+
+// InitializeDB creates a database and applies all pending migrations
 func (pg *postgresDB) InitializeDB() error {
 	if err := createDatabase(pg.databaseName); err != nil {
 		if !databaseExists(err) {
@@ -58,61 +106,36 @@ func (pg *postgresDB) InitializeDB() error {
 		logrus.Warn("Database already exists. Skipping...")
 	}
 
-	return pg.createTables()
+	return pg.Migrate(MigrateUp)
 }
 
-// CreateTables creates tables into the database
-func (pg *postgresDB) createTables() error {
-	conn, err := pg.Connect()
+func connect(dbName string, config PoolConfig) (*pgxpool.Pool, error) {
+	poolConfig, err := pgxpool.ParseConfig(fmt.Sprintf("%s/%s", connectionURL, dbName))
 	if err != nil {
-		return fmt.Errorf("database connection failed: %w", err)
+		return nil, fmt.Errorf("could not parse pool config: %w", err)
 	}
-	defer conn.Close()
 
-	if err := createUsersTable(conn); err != nil {
-		if !tableExists(err) {
-			return fmt.Errorf("table users creation failed: %w", err)
-		}
-		logrus.Warn("Table users already exists. Skipping...")
-	}
-
-	if err := createPostsTable(conn); err != nil {
-		if !tableExists(err) {
-			return fmt.Errorf("table posts creation failed: %w", err)
-		}
-		logrus.Warn("Table posts already exists. Skipping...")
-	}
-
-	if err := createFollowersTable(conn); err != nil {
-		if !tableExists(err) {
-			return fmt.Errorf("table followers creation failed: %s", err)
-		}
-		logrus.Warn("Table followers already exists. Skipping...")
-	}
-
-	return nil
-}
-
-func connect(dbName string) (*pgxpool.Pool, error) {
-	err := os.Setenv(envDatabaseURL, fmt.Sprintf("%s/%s", connectionURL, dbName))
-	if err != nil {
-		return nil, fmt.Errorf("failed to set %s: %w", envDatabaseURL, err)
-	}
+	poolConfig.MaxConns = config.MaxConns
+	poolConfig.MinConns = config.MinConns
+	poolConfig.MaxConnLifetime = config.MaxConnLifetime
+	poolConfig.HealthCheckPeriod = config.HealthCheckPeriod
 
-	conn, err := pgxpool.Connect(context.Background(), os.Getenv(envDatabaseURL))
+	pool, err := pgxpool.ConnectConfig(context.Background(), poolConfig)
 	if err != nil {
 		return nil, fmt.Errorf("pool connection failed: %w", err)
 	}
 
-	return conn, err
+	return pool, nil
 }
 
+// createDatabase uses a single short-lived connection, since
+// the database the pool connects to may not exist yet
 func createDatabase(dbName string) error {
-	conn, err := connect("")
+	conn, err := pgx.Connect(context.Background(), fmt.Sprintf("%s/", connectionURL))
 	if err != nil {
 		return fmt.Errorf("database connection failed: %w", err)
 	}
-	defer conn.Close()
+	defer conn.Close(context.Background())
 
 	_, err = conn.Exec(context.Background(), fmt.Sprintf(`CREATE DATABASE %s`, dbName))
 	if err != nil {
@@ -123,65 +146,6 @@ func createDatabase(dbName string) error {
 	return nil
 }
 
-func createUsersTable(conn *pgxpool.Pool) error {
-	table := `CREATE TABLE users(
-        username VARCHAR (14) NOT NULL PRIMARY KEY,
-        joined_at TIMESTAMPTZ DEFAULT NOW())`
-
-	_, err := conn.Exec(context.Background(), table)
-	if err != nil {
-		return err
-	}
-
-	logrus.Info("Table users created!")
-	return nil
-}
-
-func createPostsTable(conn *pgxpool.Pool) error {
-	table := `CREATE TABLE posts(
-        post_id VARCHAR (36) PRIMARY KEY,
-        username VARCHAR (14) NOT NULL REFERENCES users (username),
-        content VARCHAR (777) NULL,
-        reposted_id VARCHAR (36) NULL,
-        created_at TIMESTAMPTZ DEFAULT NOW(),
-        FOREIGN KEY (reposted_id) REFERENCES posts (post_id))`
-
-	_, err := conn.Exec(context.Background(), table)
-	if err != nil {
-		return err
-	}
-
-	logrus.Info("Table posts created!")
-	return nil
-}
-
-func createFollowersTable(conn *pgxpool.Pool) error {
-	table := `CREATE TABLE followers(
-        username VARCHAR (14) NOT NULL,
-        followed_by VARCHAR (14) NOT NULL,
-        FOREIGN KEY (username) REFERENCES users (username),
-        FOREIGN KEY (followed_by) REFERENCES users (username),
-        PRIMARY KEY(username, followed_by))`
-
-	_, err := conn.Exec(context.Background(), table)
-	if err != nil {
-		return err
-	}
-
-	logrus.Info("Table followers created!")
-	return nil
-}
-
 func databaseExists(err error) bool {
-	if strings.Contains(err.Error(), databaseCreationErrorCode) {
-		return true
-	}
-	return false
-}
-
-func tableExists(err error) bool {
-	if strings.Contains(err.Error(), tableCreationErrorCode) {
-		return true
-	}
-	return false
+	return pgerrors.HasCode(err, pgerrors.DuplicateDatabase)
 }
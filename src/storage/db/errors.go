@@ -0,0 +1,27 @@
+package db
+
+import "fmt"
+
+type InvalidMigrationDirectionError struct {
+	direction string
+}
+
+func (e InvalidMigrationDirectionError) Error() string {
+	return fmt.Sprintf("invalid migration direction %s: direction must be either up or down", e.direction)
+}
+
+type InvalidMigrationFileError struct {
+	fileName string
+}
+
+func (e InvalidMigrationFileError) Error() string {
+	return fmt.Sprintf("invalid migration file %s: file name must follow {version}_{name}.(up|down).sql", e.fileName)
+}
+
+type MissingMigrationFileError struct {
+	version int
+}
+
+func (e MissingMigrationFileError) Error() string {
+	return fmt.Sprintf("migration %04d must have both up and down files", e.version)
+}
@@ -0,0 +1,297 @@
+package db
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+/*
+	Migrations are stored in the migrations folder as pairs of files:
+	    {version}_{name}.up.sql
+	    {version}_{name}.down.sql
+	Versions are applied in ascending order and reverted in descending order.
+	The first migrations use IF NOT EXISTS, so deployments created before
+	schema_migrations existed are adopted without errors.
+*/
+
+const (
+	MigrateUp     = "up"
+	MigrateDown   = "down"
+	MigrateStatus = "status"
+
+	// migrationsLockId is an arbitrary key used to serialize concurrent migrations
+	migrationsLockId = 7720220629
+
+	upSuffix   = ".up.sql"
+	downSuffix = ".down.sql"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+type migration struct {
+	version int
+	name    string
+	up      string
+	down    string
+}
+
+type MigrationStatus struct {
+	Version   int
+	Name      string
+	AppliedAt *time.Time
+}
+
+// Migrate applies all pending migrations if direction is MigrateUp
+// or reverts the latest applied migration if direction is MigrateDown
+func (pg *postgresDB) Migrate(direction string) error {
+	migrations, err := loadMigrations()
+	if err != nil {
+		return fmt.Errorf("could not load migrations: %w", err)
+	}
+
+	conn, err := pg.Connect()
+	if err != nil {
+		return fmt.Errorf("database connection failed: %w", err)
+	}
+
+	if err = createMigrationsTable(conn); err != nil {
+		return fmt.Errorf("table schema_migrations creation failed: %w", err)
+	}
+
+	switch direction {
+	case MigrateUp:
+		for _, m := range migrations {
+			if err = migrateUp(conn, m); err != nil {
+				return fmt.Errorf("migration %04d_%s failed: %w", m.version, m.name, err)
+			}
+		}
+	case MigrateDown:
+		for i := len(migrations) - 1; i >= 0; i-- {
+			reverted, err := migrateDown(conn, migrations[i])
+			if err != nil {
+				return fmt.Errorf("migration %04d_%s rollback failed: %w", migrations[i].version, migrations[i].name, err)
+			}
+			if reverted {
+				return nil
+			}
+		}
+		logrus.Info("No migrations to revert")
+	default:
+		return InvalidMigrationDirectionError{direction}
+	}
+
+	return nil
+}
+
+// MigrationStatus returns every known migration and when it was applied.
+// AppliedAt is nil for pending migrations
+func (pg *postgresDB) MigrationStatus() ([]MigrationStatus, error) {
+	migrations, err := loadMigrations()
+	if err != nil {
+		return nil, fmt.Errorf("could not load migrations: %w", err)
+	}
+
+	conn, err := pg.Connect()
+	if err != nil {
+		return nil, fmt.Errorf("database connection failed: %w", err)
+	}
+
+	if err = createMigrationsTable(conn); err != nil {
+		return nil, fmt.Errorf("table schema_migrations creation failed: %w", err)
+	}
+
+	rows, err := conn.Query(context.Background(), "SELECT version, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("could not perform selectMigrations query: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int]time.Time)
+	for rows.Next() {
+		var version int
+		var appliedAt time.Time
+		if err = rows.Scan(&version, &appliedAt); err != nil {
+			return nil, fmt.Errorf("could not scan selectMigrations rows: %w", err)
+		}
+		applied[version] = appliedAt
+	}
+
+	status := make([]MigrationStatus, 0, len(migrations))
+	for _, m := range migrations {
+		s := MigrationStatus{Version: m.version, Name: m.name}
+		if appliedAt, exists := applied[m.version]; exists {
+			s.AppliedAt = &appliedAt
+		}
+		status = append(status, s)
+	}
+
+	return status, nil
+}
+
+func createMigrationsTable(conn *pgxpool.Pool) error {
+	table := `CREATE TABLE IF NOT EXISTS schema_migrations(
+        version INTEGER PRIMARY KEY,
+        name VARCHAR (255) NOT NULL,
+        applied_at TIMESTAMPTZ DEFAULT NOW())`
+
+	_, err := conn.Exec(context.Background(), table)
+	return err
+}
+
+// migrateUp applies a migration within a transaction,
+// unless it has already been applied
+func migrateUp(conn *pgxpool.Pool, m migration) error {
+	return inMigrationTx(conn, func(tx pgx.Tx) error {
+		applied, err := isMigrationApplied(tx, m.version)
+		if err != nil || applied {
+			return err
+		}
+
+		if _, err = tx.Exec(context.Background(), m.up); err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(context.Background(), "INSERT INTO schema_migrations (version, name) VALUES ($1, $2)",
+			m.version, m.name)
+		if err != nil {
+			return err
+		}
+
+		logrus.Infof("Migration %04d_%s applied!", m.version, m.name)
+		return nil
+	})
+}
+
+// migrateDown reverts a migration within a transaction
+// and reports whether it was applied in the first place
+func migrateDown(conn *pgxpool.Pool, m migration) (bool, error) {
+	var reverted bool
+	err := inMigrationTx(conn, func(tx pgx.Tx) error {
+		applied, err := isMigrationApplied(tx, m.version)
+		if err != nil || !applied {
+			return err
+		}
+
+		if _, err = tx.Exec(context.Background(), m.down); err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(context.Background(), "DELETE FROM schema_migrations WHERE version = $1", m.version)
+		if err != nil {
+			return err
+		}
+
+		reverted = true
+		logrus.Infof("Migration %04d_%s reverted!", m.version, m.name)
+		return nil
+	})
+
+	return reverted, err
+}
+
+func inMigrationTx(conn *pgxpool.Pool, fn func(tx pgx.Tx) error) error {
+	tx, err := conn.Begin(context.Background())
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(context.Background())
+
+	if _, err = tx.Exec(context.Background(), "SELECT pg_advisory_xact_lock($1)", migrationsLockId); err != nil {
+		return err
+	}
+
+	if err = fn(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit(context.Background())
+}
+
+func isMigrationApplied(tx pgx.Tx, version int) (bool, error) {
+	var countRows int
+	row := tx.QueryRow(context.Background(), "SELECT COUNT(*) FROM schema_migrations WHERE version = $1", version)
+	if err := row.Scan(&countRows); err != nil {
+		return false, err
+	}
+
+	return countRows == 1, nil
+}
+
+// loadMigrations reads the embedded migration files sorted by version
+func loadMigrations() ([]migration, error) {
+	entries, err := fs.ReadDir(migrationFiles, "migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int]*migration)
+	for _, entry := range entries {
+		fileName := entry.Name()
+
+		var suffix string
+		switch {
+		case strings.HasSuffix(fileName, upSuffix):
+			suffix = upSuffix
+		case strings.HasSuffix(fileName, downSuffix):
+			suffix = downSuffix
+		default:
+			return nil, InvalidMigrationFileError{fileName}
+		}
+
+		parts := strings.SplitN(strings.TrimSuffix(fileName, suffix), "_", 2)
+		if len(parts) != 2 {
+			return nil, InvalidMigrationFileError{fileName}
+		}
+
+		version, err := strconv.Atoi(parts[0])
+		if err != nil {
+			return nil, InvalidMigrationFileError{fileName}
+		}
+
+		content, err := migrationFiles.ReadFile(path.Join("migrations", fileName))
+		if err != nil {
+			return nil, err
+		}
+
+		m, exists := byVersion[version]
+		if !exists {
+			m = &migration{version: version, name: parts[1]}
+			byVersion[version] = m
+		}
+		if m.name != parts[1] {
+			return nil, InvalidMigrationFileError{fileName}
+		}
+
+		if suffix == upSuffix {
+			m.up = string(content)
+		} else {
+			m.down = string(content)
+		}
+	}
+
+	migrations := make([]migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if len(m.up) == 0 || len(m.down) == 0 {
+			return nil, MissingMigrationFileError{m.version}
+		}
+		migrations = append(migrations, *m)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].version < migrations[j].version
+	})
+
+	return migrations, nil
+}
@@ -0,0 +1,29 @@
+package db
+
+import (
+	"testing"
+
+	assertions "github.com/stretchr/testify/assert"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	assert := assertions.New(t)
+
+	migrations, err := loadMigrations()
+	assert.NoError(err)
+	assert.NotEmpty(migrations)
+
+	t.Run("Migrations are sorted by unique versions", func(t *testing.T) {
+		for i := 1; i < len(migrations); i++ {
+			assert.Less(migrations[i-1].version, migrations[i].version)
+		}
+	})
+
+	t.Run("Every migration can be applied and reverted", func(t *testing.T) {
+		for _, m := range migrations {
+			assert.NotEmpty(m.name)
+			assert.NotEmpty(m.up)
+			assert.NotEmpty(m.down)
+		}
+	})
+}
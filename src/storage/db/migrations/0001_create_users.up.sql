@@ -0,0 +1,3 @@
+CREATE TABLE IF NOT EXISTS users(
+        username VARCHAR (14) NOT NULL PRIMARY KEY,
+        joined_at TIMESTAMPTZ DEFAULT NOW());
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS posts(
+        post_id VARCHAR (36) PRIMARY KEY,
+        username VARCHAR (14) NOT NULL REFERENCES users (username),
+        content VARCHAR (777) NULL,
+        reposted_id VARCHAR (36) NULL,
+        created_at TIMESTAMPTZ DEFAULT NOW(),
+        FOREIGN KEY (reposted_id) REFERENCES posts (post_id));
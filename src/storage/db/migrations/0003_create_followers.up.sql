@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS followers(
+        username VARCHAR (14) NOT NULL,
+        followed_by VARCHAR (14) NOT NULL,
+        FOREIGN KEY (username) REFERENCES users (username),
+        FOREIGN KEY (followed_by) REFERENCES users (username),
+        PRIMARY KEY(username, followed_by));
@@ -0,0 +1,2 @@
+ALTER TABLE posts
+        ADD COLUMN IF NOT EXISTS reply_id VARCHAR (36) NULL REFERENCES posts (post_id);
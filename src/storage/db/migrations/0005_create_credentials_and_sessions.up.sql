@@ -0,0 +1,10 @@
+CREATE TABLE credentials(
+        username VARCHAR (14) NOT NULL PRIMARY KEY REFERENCES users (username),
+        password_hash VARCHAR (255) NOT NULL,
+        updated_at TIMESTAMPTZ DEFAULT NOW());
+
+CREATE TABLE sessions(
+        token_hash VARCHAR (64) NOT NULL PRIMARY KEY,
+        username VARCHAR (14) NOT NULL REFERENCES users (username),
+        created_at TIMESTAMPTZ DEFAULT NOW(),
+        expires_at TIMESTAMPTZ NOT NULL);
@@ -0,0 +1,5 @@
+ALTER TABLE posts
+        ADD COLUMN IF NOT EXISTS search_vector tsvector
+        GENERATED ALWAYS AS (to_tsvector('english', COALESCE(content, ''))) STORED;
+CREATE INDEX IF NOT EXISTS posts_search_vector_idx
+        ON posts USING GIN (search_vector);
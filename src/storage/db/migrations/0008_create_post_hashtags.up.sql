@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS post_hashtags(
+        post_id VARCHAR (36) NOT NULL REFERENCES posts (post_id),
+        hashtag VARCHAR (50) NOT NULL,
+        created_at TIMESTAMPTZ NOT NULL,
+        PRIMARY KEY (post_id, hashtag));
+CREATE INDEX IF NOT EXISTS post_hashtags_hashtag_idx
+        ON post_hashtags (hashtag, created_at DESC, post_id DESC);
+CREATE INDEX IF NOT EXISTS post_hashtags_created_at_idx
+        ON post_hashtags (created_at);
+INSERT INTO post_hashtags (post_id, hashtag, created_at)
+        SELECT DISTINCT p.post_id, LOWER(m[1]), p.created_at
+        FROM posts p, regexp_matches(p.content, '(?:^|[^[:alnum:]_])#([[:alnum:]_]+)', 'g') m
+        WHERE p.content IS NOT NULL
+        AND char_length(m[1]) <= 50
+        ON CONFLICT DO NOTHING;
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS post_mentions(
+        post_id VARCHAR (36) NOT NULL REFERENCES posts (post_id),
+        username VARCHAR (14) NOT NULL REFERENCES users (username),
+        created_at TIMESTAMPTZ NOT NULL,
+        PRIMARY KEY (post_id, username));
+CREATE INDEX IF NOT EXISTS post_mentions_username_idx
+        ON post_mentions (username, created_at DESC, post_id DESC);
+
+-- mentions written before are indexed, but not notified
+INSERT INTO post_mentions (post_id, username, created_at)
+        SELECT DISTINCT p.post_id, u.username, p.created_at
+        FROM posts p, regexp_matches(p.content, '(?:^|[^a-zA-Z0-9_])@([a-zA-Z0-9]+)', 'g') m, users u
+        WHERE p.content IS NOT NULL
+        AND u.username = m[1]
+        ON CONFLICT DO NOTHING;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS notifications(
+        notification_id BIGSERIAL PRIMARY KEY,
+        username VARCHAR (14) NOT NULL REFERENCES users (username),
+        kind VARCHAR (16) NOT NULL,
+        actor VARCHAR (14) NOT NULL REFERENCES users (username),
+        post_id VARCHAR (36) NULL REFERENCES posts (post_id),
+        created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+        read_at TIMESTAMPTZ NULL);
+CREATE INDEX IF NOT EXISTS notifications_username_idx
+        ON notifications (username, notification_id DESC);
+CREATE INDEX IF NOT EXISTS notifications_unread_idx
+        ON notifications (username, notification_id DESC)
+        WHERE read_at IS NULL;
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS post_likes(
+        post_id VARCHAR (36) NOT NULL REFERENCES posts (post_id),
+        username VARCHAR (14) NOT NULL REFERENCES users (username),
+        created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+        PRIMARY KEY (post_id, username));
+CREATE INDEX IF NOT EXISTS post_likes_post_id_idx
+        ON post_likes (post_id, created_at DESC);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS followers_followed_by_followed_at_idx;
+DROP INDEX IF EXISTS followers_username_followed_at_idx;
+ALTER TABLE followers
+        DROP COLUMN followed_at;
@@ -0,0 +1,15 @@
+ALTER TABLE followers
+        ADD COLUMN IF NOT EXISTS followed_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+-- follows notified since notifications exist keep the date they were notified
+UPDATE followers f
+        SET followed_at = n.created_at
+        FROM (SELECT username, actor, MAX(created_at) AS created_at
+              FROM notifications
+              WHERE kind = 'follow'
+              GROUP BY username, actor) n
+        WHERE n.username = f.username
+        AND n.actor = f.followed_by;
+CREATE INDEX IF NOT EXISTS followers_username_followed_at_idx
+        ON followers (username, followed_at DESC, followed_by DESC);
+CREATE INDEX IF NOT EXISTS followers_followed_by_followed_at_idx
+        ON followers (followed_by, followed_at DESC, username DESC);
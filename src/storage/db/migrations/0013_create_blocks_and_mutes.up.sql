@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS blocks(
+        username VARCHAR (14) NOT NULL REFERENCES users (username),
+        blocked VARCHAR (14) NOT NULL REFERENCES users (username),
+        created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+        PRIMARY KEY (username, blocked));
+CREATE INDEX IF NOT EXISTS blocks_blocked_idx
+        ON blocks (blocked);
+CREATE TABLE IF NOT EXISTS mutes(
+        username VARCHAR (14) NOT NULL REFERENCES users (username),
+        muted VARCHAR (14) NOT NULL REFERENCES users (username),
+        created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+        PRIMARY KEY (username, muted));
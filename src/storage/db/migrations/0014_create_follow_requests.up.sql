@@ -0,0 +1,9 @@
+ALTER TABLE users
+        ADD COLUMN IF NOT EXISTS private BOOLEAN NOT NULL DEFAULT false;
+CREATE TABLE IF NOT EXISTS follow_requests(
+        username VARCHAR (14) NOT NULL REFERENCES users (username),
+        requested_by VARCHAR (14) NOT NULL REFERENCES users (username),
+        created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+        PRIMARY KEY (username, requested_by));
+CREATE INDEX IF NOT EXISTS follow_requests_username_created_at_idx
+        ON follow_requests (username, created_at DESC, requested_by DESC);
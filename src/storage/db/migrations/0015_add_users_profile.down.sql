@@ -0,0 +1,6 @@
+ALTER TABLE users
+        DROP COLUMN avatar_url,
+        DROP COLUMN website,
+        DROP COLUMN location,
+        DROP COLUMN bio,
+        DROP COLUMN display_name;
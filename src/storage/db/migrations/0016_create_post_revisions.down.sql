@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS post_revisions;
+ALTER TABLE posts
+        DROP COLUMN edited_at;
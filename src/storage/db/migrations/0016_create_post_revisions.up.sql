@@ -0,0 +1,10 @@
+ALTER TABLE posts
+        ADD COLUMN IF NOT EXISTS edited_at TIMESTAMPTZ NULL;
+CREATE TABLE IF NOT EXISTS post_revisions(
+        revision_id BIGSERIAL PRIMARY KEY,
+        post_id VARCHAR (36) NOT NULL REFERENCES posts (post_id),
+        content VARCHAR (777) NOT NULL,
+        created_at TIMESTAMPTZ NOT NULL,
+        replaced_at TIMESTAMPTZ NOT NULL DEFAULT NOW());
+CREATE INDEX IF NOT EXISTS post_revisions_post_id_idx
+        ON post_revisions (post_id, revision_id);
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS bookmarks(
+        username VARCHAR (14) NOT NULL REFERENCES users (username),
+        post_id VARCHAR (36) NOT NULL REFERENCES posts (post_id),
+        created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+        PRIMARY KEY (username, post_id));
+CREATE INDEX IF NOT EXISTS bookmarks_username_created_at_idx
+        ON bookmarks (username, created_at DESC, post_id DESC);
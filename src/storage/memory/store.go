@@ -0,0 +1,164 @@
+package memory
+
+import (
+	"sync"
+	"time"
+)
+
+type Post struct {
+	ID         string
+	Username   string
+	Content    string
+	RepostedId string
+	ReplyId    string
+	CreatedAt  time.Time
+	// When Content was last edited, zero if it never was
+	EditedAt time.Time
+	Deleted  bool
+	// Distinct hashtags of Content, lowercased and without the leading #
+	Hashtags []string
+	// Distinct registered usernames mentioned by Content
+	Mentions []string
+}
+
+type Notification struct {
+	ID        int64
+	Username  string
+	Kind      string
+	Actor     string
+	PostId    string
+	CreatedAt time.Time
+	Read      bool
+}
+
+// Revision is a prior version of the content of an edited post
+type Revision struct {
+	Content    string
+	CreatedAt  time.Time
+	ReplacedAt time.Time
+}
+
+// Profile holds the fields users fill in about themselves
+type Profile struct {
+	DisplayName string
+	Bio         string
+	Location    string
+	Website     string
+	AvatarURL   string
+}
+
+type Session struct {
+	Username  string
+	ExpiresAt time.Time
+}
+
+// Store keeps the whole state of the in-memory backends.
+// Backends sharing a Store must hold its lock while accessing any field
+type Store struct {
+	sync.RWMutex
+	// Registered users and the date they joined
+	Users map[string]time.Time
+	// Users whose posts are only seen by their followers
+	PrivateUsers map[string]struct{}
+	// Profiles of the users who filled in any of its fields
+	Profiles map[string]Profile
+	// Posts sorted by creation date, from the oldest to the newest
+	Posts []Post
+	// An index of Posts by post id
+	PostsIndex map[string]int
+	// Followers of each user and the date they started following
+	Followers map[string]map[string]time.Time
+	// Pending requests to follow each private user and the date they were made
+	FollowRequests map[string]map[string]time.Time
+	// Password hashes by username
+	Credentials map[string]string
+	// Sessions by token hash
+	Sessions map[string]Session
+	// Notifications sorted by id, from the oldest to the newest
+	Notifications []Notification
+	// Prior versions of the content of each edited post, from the oldest to the newest
+	Revisions map[string][]Revision
+	// Users who liked each post and the date they liked it
+	Likes map[string]map[string]time.Time
+	// Posts bookmarked by each user and the date they were bookmarked
+	Bookmarks map[string]map[string]time.Time
+	// Users blocked by each user and the date they were blocked
+	Blocks map[string]map[string]time.Time
+	// Users muted by each user and the date they were muted
+	Mutes map[string]map[string]time.Time
+}
+
+func NewStore() *Store {
+	return &Store{
+		Users:          make(map[string]time.Time),
+		PrivateUsers:   make(map[string]struct{}),
+		Profiles:       make(map[string]Profile),
+		Posts:          make([]Post, 0),
+		PostsIndex:     make(map[string]int),
+		Followers:      make(map[string]map[string]time.Time),
+		FollowRequests: make(map[string]map[string]time.Time),
+		Credentials:    make(map[string]string),
+		Sessions:       make(map[string]Session),
+		Notifications:  make([]Notification, 0),
+		Revisions:      make(map[string][]Revision),
+		Likes:          make(map[string]map[string]time.Time),
+		Bookmarks:      make(map[string]map[string]time.Time),
+		Blocks:         make(map[string]map[string]time.Time),
+		Mutes:          make(map[string]map[string]time.Time),
+	}
+}
+
+// AddPost appends a post to the store
+func (s *Store) AddPost(post Post) {
+	s.PostsIndex[post.ID] = len(s.Posts)
+	s.Posts = append(s.Posts, post)
+}
+
+// AddNotification appends a notification to the store, assigning its id
+func (s *Store) AddNotification(notification Notification) {
+	notification.ID = int64(len(s.Notifications) + 1)
+	s.Notifications = append(s.Notifications, notification)
+}
+
+// GetPost returns a post by its id
+func (s *Store) GetPost(postId string) (Post, bool) {
+	index, exists := s.PostsIndex[postId]
+	if !exists {
+		return Post{}, false
+	}
+	return s.Posts[index], true
+}
+
+// GetLivePost returns a post by its id, unless it was deleted
+func (s *Store) GetLivePost(postId string) (Post, bool) {
+	post, exists := s.GetPost(postId)
+	if !exists || post.Deleted {
+		return Post{}, false
+	}
+	return post, true
+}
+
+// IsFollowing checks if username is followed by follower
+func (s *Store) IsFollowing(username, follower string) bool {
+	_, exists := s.Followers[username][follower]
+	return exists
+}
+
+// IsBlocking checks if username blocked blocked
+func (s *Store) IsBlocking(username, blocked string) bool {
+	_, exists := s.Blocks[username][blocked]
+	return exists
+}
+
+// IsMuting checks if username muted muted
+func (s *Store) IsMuting(username, muted string) bool {
+	_, exists := s.Mutes[username][muted]
+	return exists
+}
+
+// CanSee checks if viewer can see the posts of author, i.e., author
+// is not private, or viewer is either author or one of their followers
+func (s *Store) CanSee(viewer, author string) bool {
+	_, private := s.PrivateUsers[author]
+	return !private || viewer == author || s.IsFollowing(author, viewer)
+}
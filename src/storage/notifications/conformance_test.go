@@ -0,0 +1,15 @@
+package notifications_test
+
+import (
+	"testing"
+
+	"posterr/src/test/conformance"
+)
+
+func TestMemoryConformance(t *testing.T) {
+	conformance.RunNotifications(t, conformance.MemoryFactory)
+}
+
+func TestPostgresConformance(t *testing.T) {
+	conformance.RunNotifications(t, conformance.PostgresFactory)
+}
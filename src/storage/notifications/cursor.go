@@ -0,0 +1,60 @@
+package notifications
+
+import (
+	"encoding/base64"
+	"strconv"
+
+	"posterr/src/types"
+)
+
+/*
+	Cursors are encoded as:  base64url({notification_id})
+	Notifications are sorted by id from the newest to the oldest,
+	so the next page starts right after the last notification of the previous one.
+*/
+
+const notificationsPageLimit = 20
+
+// encodeCursor returns the cursor pointing right after notification
+func encodeCursor(notification types.PosterrNotification) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(notification.ID, 10)))
+}
+
+// decodeCursor parses a cursor returned by encodeCursor
+func decodeCursor(encoded string) (int64, error) {
+	value, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return 0, InvalidCursorError{encoded}
+	}
+
+	notificationId, err := strconv.ParseInt(string(value), 10, 64)
+	if err != nil || notificationId <= 0 {
+		return 0, InvalidCursorError{encoded}
+	}
+
+	return notificationId, nil
+}
+
+// pageArgs returns the offset and notification_id arguments of a paginated query.
+// The offset is ignored when a cursor is given
+func pageArgs(page types.Page) ([]interface{}, error) {
+	if len(page.Cursor) == 0 {
+		return []interface{}{page.Offset, nil}, nil
+	}
+
+	notificationId, err := decodeCursor(page.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	return []interface{}{0, notificationId}, nil
+}
+
+// newPage returns a page of notifications, which has a next cursor only if it is full
+func newPage(notifications []types.PosterrNotification, unread int) types.PosterrNotificationPage {
+	page := types.PosterrNotificationPage{Notifications: notifications, Unread: unread}
+	if len(notifications) > 0 && len(notifications) == notificationsPageLimit {
+		page.NextCursor = encodeCursor(notifications[len(notifications)-1])
+	}
+	return page
+}
@@ -0,0 +1,42 @@
+package notifications
+
+import (
+	"fmt"
+	"strconv"
+)
+
+type NotificationDoesNotExistError struct {
+	notificationId int64
+}
+
+func (e NotificationDoesNotExistError) Error() string {
+	return fmt.Sprintf("notification id %d is not registered", e.notificationId)
+}
+
+func (e NotificationDoesNotExistError) Code() string {
+	return "notification_not_found"
+}
+
+func (e NotificationDoesNotExistError) Details() map[string]string {
+	return map[string]string{
+		"notification_id": strconv.FormatInt(e.notificationId, 10),
+	}
+}
+
+type InvalidCursorError struct {
+	cursor string
+}
+
+func (e InvalidCursorError) Error() string {
+	return fmt.Sprintf("invalid cursor %s", e.cursor)
+}
+
+func (e InvalidCursorError) Code() string {
+	return "invalid_cursor"
+}
+
+func (e InvalidCursorError) Details() map[string]string {
+	return map[string]string{
+		"cursor": e.cursor,
+	}
+}
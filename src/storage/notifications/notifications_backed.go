@@ -0,0 +1,79 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+
+	"posterr/src/types"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type notificationsBacked struct {
+	pool *pgxpool.Pool
+}
+
+func NewNotificationsBacked(pool *pgxpool.Pool) *notificationsBacked {
+	return &notificationsBacked{
+		pool: pool,
+	}
+}
+
+// ListNotifications returns a list of notifications of a given username, from the newest
+// to the oldest, along with how many are unread. Each call returns 20 notifications at most.
+func (nb *notificationsBacked) ListNotifications(ctx context.Context, username string, unreadOnly bool, page types.Page) (types.PosterrNotificationPage, error) {
+	args, err := pageArgs(page)
+	if err != nil {
+		return types.PosterrNotificationPage{}, err
+	}
+
+	rows, err := nb.pool.Query(ctx, selectNotifications, append([]interface{}{username, unreadOnly}, args...)...)
+	if err != nil {
+		return types.PosterrNotificationPage{}, fmt.Errorf("could not perform selectNotifications query: %w", err)
+	}
+	defer rows.Close()
+
+	notifications := make([]types.PosterrNotification, 0)
+	for rows.Next() {
+		notification := types.PosterrNotification{}
+		if err = rows.Scan(&notification.ID, &notification.Kind, &notification.Actor, &notification.PostId,
+			&notification.CreatedAt, &notification.Read); err != nil {
+			return types.PosterrNotificationPage{}, fmt.Errorf("could not scan selectNotifications rows: %w", err)
+		}
+
+		notifications = append(notifications, notification)
+	}
+
+	var unread int
+	row := nb.pool.QueryRow(ctx, countUnreadNotifications, username)
+	if err = row.Scan(&unread); err != nil {
+		return types.PosterrNotificationPage{}, fmt.Errorf("could not scan countUnreadNotifications rows: %w", err)
+	}
+
+	return newPage(notifications, unread), nil
+}
+
+// MarkNotificationRead marks a notification of a given username as read.
+// Notifications which were already read are kept as they are.
+func (nb *notificationsBacked) MarkNotificationRead(ctx context.Context, username string, notificationId int64) error {
+	tag, err := nb.pool.Exec(ctx, markNotificationRead, notificationId, username)
+	if err != nil {
+		return fmt.Errorf("could not perform markNotificationRead query: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return NotificationDoesNotExistError{notificationId}
+	}
+
+	return nil
+}
+
+// MarkAllNotificationsRead marks every notification of a given username as read.
+func (nb *notificationsBacked) MarkAllNotificationsRead(ctx context.Context, username string) error {
+	_, err := nb.pool.Exec(ctx, markAllNotificationsRead, username)
+	if err != nil {
+		return fmt.Errorf("could not perform markAllNotificationsRead query: %w", err)
+	}
+
+	return nil
+}
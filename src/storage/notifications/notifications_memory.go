@@ -0,0 +1,114 @@
+package notifications
+
+import (
+	"context"
+
+	"posterr/src/storage/memory"
+	"posterr/src/types"
+)
+
+type notificationsMemory struct {
+	store *memory.Store
+}
+
+func NewNotificationsMemory(store *memory.Store) *notificationsMemory {
+	return &notificationsMemory{
+		store: store,
+	}
+}
+
+// ListNotifications returns a list of notifications of a given username, from the newest
+// to the oldest, along with how many are unread. Each call returns 20 notifications at most.
+func (nm *notificationsMemory) ListNotifications(ctx context.Context, username string, unreadOnly bool, page types.Page) (types.PosterrNotificationPage, error) {
+	offset := page.Offset
+	var before int64
+	if len(page.Cursor) > 0 {
+		notificationId, err := decodeCursor(page.Cursor)
+		if err != nil {
+			return types.PosterrNotificationPage{}, err
+		}
+
+		offset, before = 0, notificationId
+	}
+
+	nm.store.RLock()
+	defer nm.store.RUnlock()
+
+	var unread int
+	notifications := make([]types.PosterrNotification, 0)
+	for i := len(nm.store.Notifications) - 1; i >= 0; i-- {
+		notification := nm.store.Notifications[i]
+		if !nm.isVisible(notification, username) {
+			continue
+		}
+
+		if !notification.Read {
+			unread++
+		}
+
+		if (unreadOnly && notification.Read) || (before > 0 && notification.ID >= before) ||
+			len(notifications) == notificationsPageLimit {
+			continue
+		}
+
+		if offset > 0 {
+			offset--
+			continue
+		}
+
+		notifications = append(notifications, toPosterrNotification(notification))
+	}
+
+	return newPage(notifications, unread), nil
+}
+
+// MarkNotificationRead marks a notification of a given username as read.
+// Notifications which were already read are kept as they are.
+func (nm *notificationsMemory) MarkNotificationRead(ctx context.Context, username string, notificationId int64) error {
+	nm.store.Lock()
+	defer nm.store.Unlock()
+
+	index := int(notificationId) - 1
+	if index < 0 || index >= len(nm.store.Notifications) || nm.store.Notifications[index].Username != username {
+		return NotificationDoesNotExistError{notificationId}
+	}
+
+	nm.store.Notifications[index].Read = true
+
+	return nil
+}
+
+// MarkAllNotificationsRead marks every notification of a given username as read.
+func (nm *notificationsMemory) MarkAllNotificationsRead(ctx context.Context, username string) error {
+	nm.store.Lock()
+	defer nm.store.Unlock()
+
+	for i := range nm.store.Notifications {
+		if nm.store.Notifications[i].Username == username {
+			nm.store.Notifications[i].Read = true
+		}
+	}
+
+	return nil
+}
+
+// isVisible checks if a notification belongs to username, and its post, if any, was not deleted
+func (nm *notificationsMemory) isVisible(notification memory.Notification, username string) bool {
+	if notification.Username != username {
+		return false
+	}
+
+	post, exists := nm.store.GetPost(notification.PostId)
+	return !exists || !post.Deleted
+}
+
+func toPosterrNotification(notification memory.Notification) types.PosterrNotification {
+	return types.PosterrNotification{
+		ID:        notification.ID,
+		Kind:      notification.Kind,
+		Actor:     notification.Actor,
+		PostId:    notification.PostId,
+		CreatedAt: notification.CreatedAt,
+		Read:      notification.Read,
+	}
+}
@@ -0,0 +1,33 @@
+package notifications
+
+const (
+	// notifications of deleted posts are hidden. $2 selects only the unread ones,
+	// $3 is the offset and $4 the notification_id of the cursor, or NULL
+	selectNotifications = `SELECT n.notification_id, n.kind, n.actor, COALESCE(n.post_id, ''), n.created_at, n.read_at IS NOT NULL
+                 FROM notifications n
+                 LEFT JOIN posts p ON p.post_id = n.post_id
+                 WHERE n.username = $1
+                 AND p.deleted_at IS NULL
+                 AND (NOT $2 OR n.read_at IS NULL)
+                 AND ($4::bigint IS NULL OR n.notification_id < $4)
+                 ORDER BY n.notification_id DESC
+                 LIMIT 20
+                 OFFSET $3`
+
+	countUnreadNotifications = `SELECT COUNT(*) as unread
+                 FROM notifications n
+                 LEFT JOIN posts p ON p.post_id = n.post_id
+                 WHERE n.username = $1
+                 AND p.deleted_at IS NULL
+                 AND n.read_at IS NULL`
+
+	markNotificationRead = `UPDATE notifications
+                 SET read_at = COALESCE(read_at, NOW())
+                 WHERE notification_id = $1
+                 AND username = $2`
+
+	markAllNotificationsRead = `UPDATE notifications
+                 SET read_at = NOW()
+                 WHERE username = $1
+                 AND read_at IS NULL`
+)
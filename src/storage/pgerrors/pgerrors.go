@@ -0,0 +1,80 @@
+package pgerrors
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgconn"
+)
+
+// SQLSTATE codes, as listed in https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	StringDataRightTruncation = "22001"
+	ForeignKeyViolation       = "23503"
+	UniqueViolation           = "23505"
+	DuplicateDatabase         = "42P04"
+)
+
+// Rule maps the Postgres errors with a given code, and optionally
+// a given constraint, to an error of a storage backend
+type Rule struct {
+	Code string
+	// An empty Constraint matches every constraint
+	Constraint string
+	Err        error
+}
+
+func (r Rule) matches(pgErr *pgconn.PgError) bool {
+	return r.Code == pgErr.Code && (len(r.Constraint) == 0 || r.Constraint == pgErr.ConstraintName)
+}
+
+// translatedError is an error of a storage backend caused by a Postgres error.
+// errors.Is and errors.As find both the storage error and its cause
+type translatedError struct {
+	err   error
+	cause error
+}
+
+func (e translatedError) Error() string {
+	return fmt.Sprintf("%s: %s", e.err, e.cause)
+}
+
+func (e translatedError) Unwrap() error {
+	return e.err
+}
+
+func (e translatedError) Is(target error) bool {
+	return errors.Is(e.cause, target)
+}
+
+func (e translatedError) As(target interface{}) bool {
+	return errors.As(e.cause, target)
+}
+
+// Wrap returns err as caused by cause
+func Wrap(err, cause error) error {
+	return translatedError{err: err, cause: cause}
+}
+
+// Translate returns the error of the first rule matching the Postgres error
+// wrapped by err, caused by err. Otherwise, err is returned as is
+func Translate(err error, rules ...Rule) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+
+	for _, rule := range rules {
+		if rule.matches(pgErr) {
+			return Wrap(rule.Err, err)
+		}
+	}
+
+	return err
+}
+
+// HasCode checks if err wraps a Postgres error with a given code
+func HasCode(err error, code string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == code
+}
@@ -0,0 +1,62 @@
+package pgerrors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	assertions "github.com/stretchr/testify/assert"
+)
+
+type notFoundError struct{}
+
+func (e notFoundError) Error() string {
+	return "not found"
+}
+
+type tooLongError struct{}
+
+func (e tooLongError) Error() string {
+	return "too long"
+}
+
+func TestTranslate(t *testing.T) {
+	assert := assertions.New(t)
+
+	rules := []Rule{
+		{Code: StringDataRightTruncation, Err: tooLongError{}},
+		{Code: ForeignKeyViolation, Constraint: "posts_reposted_id_fkey", Err: notFoundError{}},
+	}
+
+	t.Run("Should translate an error matching a rule", func(t *testing.T) {
+		cause := fmt.Errorf("could not insert: %w", &pgconn.PgError{Code: StringDataRightTruncation})
+		err := Translate(cause, rules...)
+		assert.ErrorAs(err, &tooLongError{})
+		assert.ErrorIs(err, cause)
+
+		var pgErr *pgconn.PgError
+		assert.ErrorAs(err, &pgErr)
+		assert.Equal(StringDataRightTruncation, pgErr.Code)
+	})
+
+	t.Run("Should match the constraint of a rule", func(t *testing.T) {
+		err := Translate(&pgconn.PgError{Code: ForeignKeyViolation, ConstraintName: "posts_reposted_id_fkey"}, rules...)
+		assert.ErrorAs(err, &notFoundError{})
+
+		err = Translate(&pgconn.PgError{Code: ForeignKeyViolation, ConstraintName: "posts_username_fkey"}, rules...)
+		assert.False(errors.As(err, &notFoundError{}))
+	})
+
+	t.Run("Should return errors not matching any rule as is", func(t *testing.T) {
+		cause := fmt.Errorf("connection refused")
+		assert.Equal(cause, Translate(cause, rules...))
+	})
+
+	t.Run("Should check the code of a wrapped error", func(t *testing.T) {
+		err := fmt.Errorf("could not create: %w", &pgconn.PgError{Code: DuplicateDatabase})
+		assert.True(HasCode(err, DuplicateDatabase))
+		assert.False(HasCode(err, UniqueViolation))
+		assert.False(HasCode(fmt.Errorf("connection refused"), DuplicateDatabase))
+	})
+}
@@ -0,0 +1,20 @@
+package posterr
+
+import (
+	"time"
+
+	"posterr/src/types"
+)
+
+const bookmarkPageLimit = 10
+
+// newBookmarkPage returns a page of bookmarked posts, which has a next cursor only if it is full.
+// Bookmarks are sorted by when each post was bookmarked, so the cursor points right after
+// the bookmark of the last post, which was made at lastBookmarkedAt
+func newBookmarkPage(posts []types.PosterrContent, lastBookmarkedAt time.Time) types.PosterrPage {
+	page := types.PosterrPage{Posts: posts}
+	if len(posts) > 0 && len(posts) == bookmarkPageLimit {
+		page.NextCursor = encodeKeysetCursor(lastBookmarkedAt, posts[len(posts)-1].ID)
+	}
+	return page
+}
@@ -0,0 +1,15 @@
+package posterr_test
+
+import (
+	"testing"
+
+	"posterr/src/test/conformance"
+)
+
+func TestMemoryConformance(t *testing.T) {
+	conformance.RunPosterr(t, conformance.MemoryFactory)
+}
+
+func TestPostgresConformance(t *testing.T) {
+	conformance.RunPosterr(t, conformance.PostgresFactory)
+}
@@ -0,0 +1,144 @@
+package posterr
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+
+	"posterr/src/types"
+)
+
+/*
+	Cursors are encoded as:  base64url({created_at}|{post_id})
+	Posts are sorted by (created_at, post_id) from the newest to the oldest,
+	so the next page starts right after the last post of the previous one.
+
+	Posts sorted by relevance have no such key, so their cursors are
+	encoded as:  base64url(offset:{offset})
+*/
+
+const (
+	cursorSeparator    = "|"
+	offsetCursorPrefix = "offset:"
+)
+
+type cursor struct {
+	createdAt time.Time
+	postId    string
+	// Only set by cursors of posts sorted by relevance
+	offset int
+}
+
+// encodeCursor returns the cursor pointing right after post
+func encodeCursor(post types.PosterrContent) string {
+	return encodeKeysetCursor(post.CreatedAt, post.ID)
+}
+
+// encodeKeysetCursor returns the cursor pointing right after the post
+// with postId sorted by createdAt, e.g., the date it was bookmarked
+func encodeKeysetCursor(createdAt time.Time, postId string) string {
+	value := createdAt.Format(time.RFC3339Nano) + cursorSeparator + postId
+	return base64.RawURLEncoding.EncodeToString([]byte(value))
+}
+
+// decodeCursor parses a cursor returned by encodeCursor
+func decodeCursor(encoded string) (cursor, error) {
+	value, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return cursor{}, InvalidCursorError{encoded}
+	}
+
+	if strings.HasPrefix(string(value), offsetCursorPrefix) {
+		offset, err := strconv.Atoi(strings.TrimPrefix(string(value), offsetCursorPrefix))
+		if err != nil || offset < 0 {
+			return cursor{}, InvalidCursorError{encoded}
+		}
+		return cursor{offset: offset}, nil
+	}
+
+	parts := strings.SplitN(string(value), cursorSeparator, 2)
+	if len(parts) != 2 || len(parts[1]) == 0 {
+		return cursor{}, InvalidCursorError{encoded}
+	}
+
+	createdAt, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return cursor{}, InvalidCursorError{encoded}
+	}
+
+	return cursor{createdAt: createdAt, postId: parts[1]}, nil
+}
+
+// decodeKeysetCursor parses a cursor of posts sorted from the newest to the oldest
+func decodeKeysetCursor(encoded string) (cursor, error) {
+	c, err := decodeCursor(encoded)
+	if err != nil {
+		return cursor{}, err
+	}
+
+	if c.createdAt.IsZero() {
+		return cursor{}, InvalidCursorError{encoded}
+	}
+	return c, nil
+}
+
+// isAfter checks if a post comes after the cursor, i.e., it is older
+func (c cursor) isAfter(createdAt time.Time, postId string) bool {
+	return createdAt.Before(c.createdAt) || (createdAt.Equal(c.createdAt) && postId < c.postId)
+}
+
+// newPage returns a page of posts, which has a next cursor only if it is full
+func newPage(posts []types.PosterrContent, limit int) types.PosterrPage {
+	page := types.PosterrPage{Posts: posts}
+	if len(posts) > 0 && len(posts) == limit {
+		page.NextCursor = encodeCursor(posts[len(posts)-1])
+	}
+	return page
+}
+
+// encodeOffsetCursor returns the cursor pointing to the post at offset
+func encodeOffsetCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(offsetCursorPrefix + strconv.Itoa(offset)))
+}
+
+// pageOffset returns the offset of a page of posts sorted by relevance
+func pageOffset(page types.Page) (int, error) {
+	if len(page.Cursor) == 0 {
+		return page.Offset, nil
+	}
+
+	c, err := decodeCursor(page.Cursor)
+	if err != nil {
+		return 0, err
+	}
+
+	if !c.createdAt.IsZero() {
+		return 0, InvalidCursorError{page.Cursor}
+	}
+	return c.offset, nil
+}
+
+// newOffsetPage returns a page of posts sorted by relevance, which has a next cursor only if it is full
+func newOffsetPage(posts []types.PosterrContent, limit, offset int) types.PosterrPage {
+	page := types.PosterrPage{Posts: posts}
+	if len(posts) > 0 && len(posts) == limit {
+		page.NextCursor = encodeOffsetCursor(offset + len(posts))
+	}
+	return page
+}
+
+// pageArgs returns the offset, created_at and post_id arguments of a paginated query.
+// The offset is ignored when a cursor is given
+func pageArgs(page types.Page) ([]interface{}, error) {
+	if len(page.Cursor) == 0 {
+		return []interface{}{page.Offset, nil, nil}, nil
+	}
+
+	c, err := decodeKeysetCursor(page.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	return []interface{}{0, c.createdAt, c.postId}, nil
+}
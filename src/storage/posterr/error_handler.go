@@ -1,18 +1,41 @@
 package posterr
 
 import (
-	"strings"
+	"errors"
+
+	"posterr/src/storage/pgerrors"
+
+	"github.com/jackc/pgx/v4"
 )
 
-func getErrorFromString(err error, username, repostedId string) error {
-	if strings.Contains(err.Error(), valueTooLongErrorCode) {
-		return PostExceededMaximumCharsError{}
-	} else if strings.Contains(err.Error(), foreignKeyViolationErrorCode) {
-		if strings.Contains(err.Error(), "posts_username_fkey") {
-			return UserDoesNotExistError{username}
-		} else if strings.Contains(err.Error(), "posts_reposted_id_fkey") {
-			return PostIdDoesNotExistError{repostedId}
-		}
+// translateError returns the storage error a Postgres error stands for
+func translateError(err error, username, referencedId string) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return pgerrors.Wrap(PostIdDoesNotExistError{referencedId}, err)
 	}
-	return err
+
+	return pgerrors.Translate(err,
+		pgerrors.Rule{Code: pgerrors.StringDataRightTruncation, Err: PostExceededMaximumCharsError{}},
+		pgerrors.Rule{Code: pgerrors.ForeignKeyViolation, Constraint: "posts_username_fkey", Err: UserDoesNotExistError{username}},
+		pgerrors.Rule{Code: pgerrors.ForeignKeyViolation, Constraint: "posts_reposted_id_fkey", Err: PostIdDoesNotExistError{referencedId}},
+		pgerrors.Rule{Code: pgerrors.ForeignKeyViolation, Constraint: "posts_reply_id_fkey", Err: PostIdDoesNotExistError{referencedId}},
+	)
+}
+
+// translateLikeError returns the storage error a Postgres error of post_likes stands for
+func translateLikeError(err error, username, postId string) error {
+	return pgerrors.Translate(err,
+		pgerrors.Rule{Code: pgerrors.UniqueViolation, Constraint: "post_likes_pkey", Err: AlreadyLikedError{username, postId}},
+		pgerrors.Rule{Code: pgerrors.ForeignKeyViolation, Constraint: "post_likes_username_fkey", Err: UserDoesNotExistError{username}},
+		pgerrors.Rule{Code: pgerrors.ForeignKeyViolation, Constraint: "post_likes_post_id_fkey", Err: PostIdDoesNotExistError{postId}},
+	)
+}
+
+// translateBookmarkError returns the storage error a Postgres error of bookmarks stands for
+func translateBookmarkError(err error, username, postId string) error {
+	return pgerrors.Translate(err,
+		pgerrors.Rule{Code: pgerrors.UniqueViolation, Constraint: "bookmarks_pkey", Err: AlreadyBookmarkedError{username, postId}},
+		pgerrors.Rule{Code: pgerrors.ForeignKeyViolation, Constraint: "bookmarks_username_fkey", Err: UserDoesNotExistError{username}},
+		pgerrors.Rule{Code: pgerrors.ForeignKeyViolation, Constraint: "bookmarks_post_id_fkey", Err: PostIdDoesNotExistError{postId}},
+	)
 }
@@ -1,10 +1,8 @@
 package posterr
 
-import "fmt"
-
-const (
-	valueTooLongErrorCode        = "SQLSTATE 22001"
-	foreignKeyViolationErrorCode = "SQLSTATE 23503"
+import (
+	"fmt"
+	"time"
 )
 
 type UserDoesNotExistError struct {
@@ -15,6 +13,16 @@ func (e UserDoesNotExistError) Error() string {
 	return fmt.Sprintf("username %s is not registered", e.username)
 }
 
+func (e UserDoesNotExistError) Code() string {
+	return "user_not_found"
+}
+
+func (e UserDoesNotExistError) Details() map[string]string {
+	return map[string]string{
+		"username": e.username,
+	}
+}
+
 type PostIdDoesNotExistError struct {
 	postId string
 }
@@ -23,20 +31,290 @@ func (e PostIdDoesNotExistError) Error() string {
 	return fmt.Sprintf("post id %s is not registered", e.postId)
 }
 
+func (e PostIdDoesNotExistError) Code() string {
+	return "post_not_found"
+}
+
+func (e PostIdDoesNotExistError) Details() map[string]string {
+	return map[string]string{
+		"post_id": e.postId,
+	}
+}
+
+type NotPostAuthorError struct {
+	username string
+	postId   string
+}
+
+func (e NotPostAuthorError) Error() string {
+	return fmt.Sprintf("username %s is not the author of post id %s", e.username, e.postId)
+}
+
+func (e NotPostAuthorError) Code() string {
+	return "not_post_author"
+}
+
+func (e NotPostAuthorError) Details() map[string]string {
+	return map[string]string{
+		"username": e.username,
+		"post_id":  e.postId,
+	}
+}
+
 type ExceededMaximumDailyPostsError struct{}
 
 func (e ExceededMaximumDailyPostsError) Error() string {
 	return "exceeded maximum daily posts"
 }
 
+func (e ExceededMaximumDailyPostsError) Code() string {
+	return "daily_posts_exceeded"
+}
+
 type PostExceededMaximumCharsError struct{}
 
 func (e PostExceededMaximumCharsError) Error() string {
 	return "post exceeded maximum allowed chars"
 }
 
+func (e PostExceededMaximumCharsError) Code() string {
+	return "post_too_long"
+}
+
 type InvalidToggleError struct{}
 
 func (e InvalidToggleError) Error() string {
 	return "invalid toggle selected"
 }
+
+func (e InvalidToggleError) Code() string {
+	return "invalid_toggle"
+}
+
+type InvalidOrderError struct {
+	order string
+}
+
+func (e InvalidOrderError) Error() string {
+	return fmt.Sprintf("invalid order %s: order must be either asc or desc", e.order)
+}
+
+func (e InvalidOrderError) Code() string {
+	return "invalid_order"
+}
+
+func (e InvalidOrderError) Details() map[string]string {
+	return map[string]string{
+		"order": e.order,
+	}
+}
+
+type InvalidSortError struct {
+	sort string
+}
+
+func (e InvalidSortError) Error() string {
+	return fmt.Sprintf("invalid sort %s: sort must be either relevance or recent", e.sort)
+}
+
+func (e InvalidSortError) Code() string {
+	return "invalid_sort"
+}
+
+func (e InvalidSortError) Details() map[string]string {
+	return map[string]string{
+		"sort": e.sort,
+	}
+}
+
+type InvalidCursorError struct {
+	cursor string
+}
+
+func (e InvalidCursorError) Error() string {
+	return fmt.Sprintf("invalid cursor %s", e.cursor)
+}
+
+func (e InvalidCursorError) Code() string {
+	return "invalid_cursor"
+}
+
+func (e InvalidCursorError) Details() map[string]string {
+	return map[string]string{
+		"cursor": e.cursor,
+	}
+}
+
+type InvalidHashtagError struct {
+	hashtag string
+}
+
+func (e InvalidHashtagError) Error() string {
+	return fmt.Sprintf("invalid hashtag %s: hashtags must have up to 50 letters, digits or underscores", e.hashtag)
+}
+
+func (e InvalidHashtagError) Code() string {
+	return "invalid_hashtag"
+}
+
+func (e InvalidHashtagError) Details() map[string]string {
+	return map[string]string{
+		"hashtag": e.hashtag,
+	}
+}
+
+type InvalidTrendingWindowError struct {
+	window time.Duration
+}
+
+func (e InvalidTrendingWindowError) Error() string {
+	return fmt.Sprintf("invalid window %s: window must be positive and up to 7 days", e.window)
+}
+
+func (e InvalidTrendingWindowError) Code() string {
+	return "invalid_window"
+}
+
+func (e InvalidTrendingWindowError) Details() map[string]string {
+	return map[string]string{
+		"window": e.window.String(),
+	}
+}
+
+type AlreadyLikedError struct {
+	username string
+	postId   string
+}
+
+func (e AlreadyLikedError) Error() string {
+	return fmt.Sprintf("username %s already likes post id %s", e.username, e.postId)
+}
+
+func (e AlreadyLikedError) Code() string {
+	return "already_liked"
+}
+
+func (e AlreadyLikedError) Details() map[string]string {
+	return map[string]string{
+		"username": e.username,
+		"post_id":  e.postId,
+	}
+}
+
+type NotLikedError struct {
+	username string
+	postId   string
+}
+
+func (e NotLikedError) Error() string {
+	return fmt.Sprintf("username %s does not like post id %s", e.username, e.postId)
+}
+
+func (e NotLikedError) Code() string {
+	return "not_liked"
+}
+
+func (e NotLikedError) Details() map[string]string {
+	return map[string]string{
+		"username": e.username,
+		"post_id":  e.postId,
+	}
+}
+
+type BlockedByAuthorError struct {
+	username string
+	postId   string
+}
+
+func (e BlockedByAuthorError) Error() string {
+	return fmt.Sprintf("username %s was blocked by the author of post id %s", e.username, e.postId)
+}
+
+func (e BlockedByAuthorError) Code() string {
+	return "blocked"
+}
+
+func (e BlockedByAuthorError) Details() map[string]string {
+	return map[string]string{
+		"username": e.username,
+		"post_id":  e.postId,
+	}
+}
+
+type RepostNotEditableError struct {
+	postId string
+}
+
+func (e RepostNotEditableError) Error() string {
+	return fmt.Sprintf("post id %s is a repost, which has no content to edit", e.postId)
+}
+
+func (e RepostNotEditableError) Code() string {
+	return "repost_not_editable"
+}
+
+func (e RepostNotEditableError) Details() map[string]string {
+	return map[string]string{
+		"post_id": e.postId,
+	}
+}
+
+type EditWindowExpiredError struct {
+	postId string
+	window time.Duration
+}
+
+func (e EditWindowExpiredError) Error() string {
+	return fmt.Sprintf("post id %s can only be edited within %s of its creation", e.postId, e.window)
+}
+
+func (e EditWindowExpiredError) Code() string {
+	return "edit_window_expired"
+}
+
+func (e EditWindowExpiredError) Details() map[string]string {
+	return map[string]string{
+		"post_id": e.postId,
+		"window":  e.window.String(),
+	}
+}
+
+type AlreadyBookmarkedError struct {
+	username string
+	postId   string
+}
+
+func (e AlreadyBookmarkedError) Error() string {
+	return fmt.Sprintf("username %s already bookmarked post id %s", e.username, e.postId)
+}
+
+func (e AlreadyBookmarkedError) Code() string {
+	return "already_bookmarked"
+}
+
+func (e AlreadyBookmarkedError) Details() map[string]string {
+	return map[string]string{
+		"username": e.username,
+		"post_id":  e.postId,
+	}
+}
+
+type NotBookmarkedError struct {
+	username string
+	postId   string
+}
+
+func (e NotBookmarkedError) Error() string {
+	return fmt.Sprintf("username %s did not bookmark post id %s", e.username, e.postId)
+}
+
+func (e NotBookmarkedError) Code() string {
+	return "not_bookmarked"
+}
+
+func (e NotBookmarkedError) Details() map[string]string {
+	return map[string]string{
+		"username": e.username,
+		"post_id":  e.postId,
+	}
+}
@@ -0,0 +1,73 @@
+package posterr
+
+import (
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	maxHashtagChars       = 50
+	hashtagPageLimit      = 10
+	defaultTrendingWindow = 24 * time.Hour
+	maxTrendingWindow     = 7 * 24 * time.Hour
+	defaultTrendingLimit  = 10
+	maxTrendingLimit      = 50
+)
+
+var (
+	// a hashtag is not preceded by a word character, so "a#b" has none.
+	// The backfill of migration 0008 follows the same rule
+	hashtagPattern = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_])#([\p{L}\p{N}_]+)`)
+	validHashtag   = regexp.MustCompile(`^[\p{L}\p{N}_]+$`)
+)
+
+// extractHashtags returns the distinct hashtags of a post content, lowercased
+// and without the leading #. Hashtags longer than maxHashtagChars are ignored
+func extractHashtags(content string) []string {
+	hashtags := make([]string, 0)
+	seen := make(map[string]struct{})
+	for _, match := range hashtagPattern.FindAllStringSubmatch(content, -1) {
+		hashtag := strings.ToLower(match[1])
+		if utf8.RuneCountInString(hashtag) > maxHashtagChars {
+			continue
+		}
+
+		if _, exists := seen[hashtag]; !exists {
+			seen[hashtag] = struct{}{}
+			hashtags = append(hashtags, hashtag)
+		}
+	}
+
+	return hashtags
+}
+
+// normalizeHashtag returns a hashtag as stored, accepting it with or without the leading #
+func normalizeHashtag(hashtag string) (string, error) {
+	normalized := strings.ToLower(strings.TrimPrefix(hashtag, "#"))
+	if !validHashtag.MatchString(normalized) || utf8.RuneCountInString(normalized) > maxHashtagChars {
+		return "", InvalidHashtagError{hashtag}
+	}
+
+	return normalized, nil
+}
+
+// trendingParams applies the defaults of a trending hashtags request and validates it
+func trendingParams(window time.Duration, limit int) (time.Duration, int, error) {
+	if window == 0 {
+		window = defaultTrendingWindow
+	}
+
+	if window < 0 || window > maxTrendingWindow {
+		return 0, 0, InvalidTrendingWindowError{window}
+	}
+
+	if limit <= 0 {
+		limit = defaultTrendingLimit
+	} else if limit > maxTrendingLimit {
+		limit = maxTrendingLimit
+	}
+
+	return window, limit, nil
+}
@@ -0,0 +1,17 @@
+package posterr
+
+import "posterr/src/types"
+
+// likeTargets returns the posts and their embedded reposted posts by post id, whose likes
+// are set once the posts are read. Deleted posts have no likes, since they can't be liked
+func likeTargets(posts []types.PosterrContent) map[string][]*types.PosterrContent {
+	targets := make(map[string][]*types.PosterrContent)
+	for i := range posts {
+		for post := &posts[i]; post != nil; post = post.Reposted {
+			if !post.Deleted {
+				targets[post.ID] = append(targets[post.ID], post)
+			}
+		}
+	}
+	return targets
+}
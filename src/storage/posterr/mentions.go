@@ -0,0 +1,28 @@
+package posterr
+
+import (
+	"regexp"
+
+	"posterr/src/types"
+)
+
+const mentionPageLimit = 10
+
+// a mention is not preceded by a word character, so "a@b" has none.
+// The backfill of migration 0009 follows the same rule
+var mentionPattern = regexp.MustCompile(`(?:^|[^` + types.UsernameCharset + `_])@([` + types.UsernameCharset + `]+)`)
+
+// extractMentions returns the distinct usernames mentioned by a post content, without the leading @.
+// Mentioned usernames are not validated, so the ones which are not registered must be ignored
+func extractMentions(content string) []string {
+	mentions := make([]string, 0)
+	seen := make(map[string]struct{})
+	for _, match := range mentionPattern.FindAllStringSubmatch(content, -1) {
+		if _, exists := seen[match[1]]; !exists {
+			seen[match[1]] = struct{}{}
+			mentions = append(mentions, match[1])
+		}
+	}
+
+	return mentions
+}
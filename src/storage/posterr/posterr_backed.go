@@ -3,136 +3,187 @@ package posterr
 import (
 	"context"
 	"fmt"
+	"time"
 
-	storagedb "posterr/src/storage/db"
 	"posterr/src/types"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
 )
 
 const (
 	maxDailyPosts      = 5
 	defaultSearchLimit = 10
+	maxSearchLimit     = 50
+	// DefaultEditWindow is how long after their creation posts can be edited by default
+	DefaultEditWindow = 15 * time.Minute
 )
 
 type posterrBacked struct {
-	db storagedb.ConnectDB
+	pool *pgxpool.Pool
+	// How long after their creation posts can be edited
+	editWindow time.Duration
 }
 
-func NewPosterrBacked(db storagedb.ConnectDB) *posterrBacked {
+func NewPosterrBacked(pool *pgxpool.Pool, editWindow time.Duration) *posterrBacked {
 	return &posterrBacked{
-		db: db,
+		pool:       pool,
+		editWindow: editWindow,
 	}
 }
 
 // ListHomePageContent returns a list of posts:
 // - If the toggle is All, returns a list of posts from the whole database;
 // - If the toggle is Following, returns a list of posts only from the users a given username follows.
-// Each call returns 10 posts at most.
-func (pb *posterrBacked) ListHomePageContent(username string, offset int, toggle bool) ([]types.PosterrContent, error) {
-	conn, err := pb.db.Connect()
+// Posts of the users username muted or blocked, or of the private users
+// they don't follow, are not listed. Each call returns 10 posts at most.
+func (pb *posterrBacked) ListHomePageContent(ctx context.Context, username string, page types.Page, toggle bool) (types.PosterrPage, error) {
+	args, err := pageArgs(page)
 	if err != nil {
-		return nil, fmt.Errorf("could not connect to database: %w", err)
+		return types.PosterrPage{}, err
 	}
-	defer conn.Close()
 
 	var rows pgx.Rows
 	switch toggle {
 	case types.All:
-		rows, err = conn.Query(context.Background(), selectAllPosts, offset)
+		rows, err = pb.pool.Query(ctx, selectAllPosts, append([]interface{}{username}, args...)...)
 		if err != nil {
-			return nil, fmt.Errorf("could not perform selectAllPosts query: %w", err)
+			return types.PosterrPage{}, fmt.Errorf("could not perform selectAllPosts query: %w", err)
 		}
 	case types.Following:
-		rows, err = conn.Query(context.Background(), selectFollowingPosts, username, offset)
+		rows, err = pb.pool.Query(ctx, selectFollowingPosts, append([]interface{}{username}, args...)...)
 		if err != nil {
-			return nil, fmt.Errorf("could not perform selectFollowingPosts query: %w", err)
+			return types.PosterrPage{}, fmt.Errorf("could not perform selectFollowingPosts query: %w", err)
 		}
 	default:
-		return nil, InvalidToggleError{}
+		return types.PosterrPage{}, InvalidToggleError{}
 	}
+	defer rows.Close()
 
 	posts := make([]types.PosterrContent, 0)
 	for rows.Next() {
-		postContent := types.PosterrContent{}
-		if err = rows.Scan(&postContent.ID, &postContent.Username, &postContent.Content, &postContent.RepostedId, &postContent.CreatedAt); err != nil {
-			return nil, fmt.Errorf("could not scan selectPosts rows: %w", err)
+		postContent, err := scanHydratedPost(rows)
+		if err != nil {
+			return types.PosterrPage{}, fmt.Errorf("could not scan selectPosts rows: %w", err)
 		}
 
 		posts = append(posts, postContent)
 	}
 
-	return posts, nil
+	if err = pb.hideReposted(ctx, posts); err != nil {
+		return types.PosterrPage{}, err
+	}
+
+	if err = pb.setLikes(ctx, posts); err != nil {
+		return types.PosterrPage{}, err
+	}
+
+	return newPage(posts, homePageLimit), nil
 }
 
-// ListProfileContent returns a lists of posts for a given username.
+// ListProfileContent returns a lists of posts for a given username. Posts of private users
+// are only listed to themselves and their followers, as the user of ctx.
 // Each call returns 5 posts at most.
-func (pb *posterrBacked) ListProfileContent(username string, offset int) ([]types.PosterrContent, error) {
-	conn, err := pb.db.Connect()
+func (pb *posterrBacked) ListProfileContent(ctx context.Context, username string, page types.Page) (types.PosterrPage, error) {
+	args, err := pageArgs(page)
 	if err != nil {
-		return nil, fmt.Errorf("could not connect to database: %w", err)
+		return types.PosterrPage{}, err
 	}
-	defer conn.Close()
 
-	rows, err := conn.Query(context.Background(), selectProfilePosts, username, offset)
+	viewer, _ := types.UserFromContext(ctx)
+	rows, err := pb.pool.Query(ctx, selectProfilePosts, append(append([]interface{}{username}, args...), viewer)...)
 	if err != nil {
-		return nil, fmt.Errorf("could not perform selectProfilePosts query: %w", err)
+		return types.PosterrPage{}, fmt.Errorf("could not perform selectProfilePosts query: %w", err)
 	}
+	defer rows.Close()
 
 	posts := make([]types.PosterrContent, 0)
 	for rows.Next() {
-		postContent := types.PosterrContent{}
-		if err = rows.Scan(&postContent.ID, &postContent.Username, &postContent.Content, &postContent.RepostedId, &postContent.CreatedAt); err != nil {
-			return nil, fmt.Errorf("could not scan selectProfilePosts rows: %w", err)
+		postContent, err := scanHydratedPost(rows)
+		if err != nil {
+			return types.PosterrPage{}, fmt.Errorf("could not scan selectProfilePosts rows: %w", err)
 		}
 
 		posts = append(posts, postContent)
 	}
 
-	return posts, nil
+	if err = pb.hideReposted(ctx, posts); err != nil {
+		return types.PosterrPage{}, err
+	}
+
+	if err = pb.setLikes(ctx, posts); err != nil {
+		return types.PosterrPage{}, err
+	}
+
+	return newPage(posts, profilePageLimit), nil
 }
 
-// SearchContent returns a lists of posts matching a substring criteria.
-// The number of returned posts can be customized by the limit parameter.
-func (pb *posterrBacked) SearchContent(text string, limit, offset int) ([]types.PosterrContent, error) {
-	conn, err := pb.db.Connect()
+// GetContent returns a post by its id, unless it was deleted
+// or its author is a private user the user of ctx doesn't follow.
+func (pb *posterrBacked) GetContent(ctx context.Context, postId string) (types.PosterrContent, error) {
+	viewer, _ := types.UserFromContext(ctx)
+	row := pb.pool.QueryRow(ctx, selectPost, postId, viewer)
+	post, err := scanHydratedPost(row)
 	if err != nil {
-		return nil, fmt.Errorf("could not connect to database: %w", err)
+		err = fmt.Errorf("could not scan selectPost rows: %w", err)
+		return types.PosterrContent{}, translateError(err, "", postId)
 	}
-	defer conn.Close()
 
-	if limit == 0 {
-		limit = defaultSearchLimit
+	posts := []types.PosterrContent{post}
+	if err = pb.hideReposted(ctx, posts); err != nil {
+		return types.PosterrContent{}, err
 	}
 
-	rows, err := conn.Query(context.Background(), searchPosts, text, limit, offset)
-	if err != nil {
-		return nil, fmt.Errorf("could not perform searchPosts query: %w", err)
+	if err = pb.setLikes(ctx, posts); err != nil {
+		return types.PosterrContent{}, err
 	}
 
-	posts := make([]types.PosterrContent, 0)
-	for rows.Next() {
-		postContent := types.PosterrContent{}
-		if err = rows.Scan(&postContent.ID, &postContent.Username, &postContent.Content, &postContent.RepostedId, &postContent.CreatedAt); err != nil {
-			return nil, fmt.Errorf("could not scan searchPosts rows: %w", err)
+	return posts[0], nil
+}
+
+// SearchContent returns a list of posts matching a search query,
+// sorted either by relevance or from the newest to the oldest.
+// Posts of the users the user of ctx muted or blocked, or of the private users
+// they don't follow, are not matched.
+// The number of returned posts can be customized by the limit parameter, up to 50.
+func (pb *posterrBacked) SearchContent(ctx context.Context, query types.SearchQuery, limit int, page types.Page) (types.PosterrPage, error) {
+	limit = searchLimit(limit)
+
+	viewer, _ := types.UserFromContext(ctx)
+	switch query.Sort {
+	case types.Relevance:
+		offset, err := pageOffset(page)
+		if err != nil {
+			return types.PosterrPage{}, err
 		}
 
-		posts = append(posts, postContent)
-	}
+		posts, err := pb.searchPosts(ctx, searchPostsByRelevance, append(searchArgs(query, viewer), limit, offset)...)
+		if err != nil {
+			return types.PosterrPage{}, err
+		}
 
-	return posts, nil
-}
+		return newOffsetPage(posts, limit, offset), nil
+	case types.Recent:
+		args, err := pageArgs(page)
+		if err != nil {
+			return types.PosterrPage{}, err
+		}
 
-// WriteContent creates a post for a given username and returns the postId.
-func (pb *posterrBacked) WriteContent(username, postContent string) (string, error) {
-	conn, err := pb.db.Connect()
-	if err != nil {
-		return "", fmt.Errorf("could not connect to database: %w", err)
+		posts, err := pb.searchPosts(ctx, searchPostsByRecency, append(append(searchArgs(query, viewer), limit), args...)...)
+		if err != nil {
+			return types.PosterrPage{}, err
+		}
+
+		return newPage(posts, limit), nil
+	default:
+		return types.PosterrPage{}, InvalidSortError{query.Sort}
 	}
-	defer conn.Close()
+}
 
-	dailyPosts, err := pb.countDailyPosts(username)
+// WriteContent creates a post for a given username and returns the postId.
+func (pb *posterrBacked) WriteContent(ctx context.Context, username, postContent string) (string, error) {
+	dailyPosts, err := pb.countDailyPosts(ctx, username)
 	if err != nil {
 		return "", fmt.Errorf("could not count daily posts: %w", err)
 	}
@@ -142,25 +193,19 @@ func (pb *posterrBacked) WriteContent(username, postContent string) (string, err
 		return "", ExceededMaximumDailyPostsError{}
 	}
 
-	_, err = conn.Exec(context.Background(), "INSERT INTO posts (post_id, username, content) VALUES ($1, $2, $3)",
+	err = pb.insertPost(ctx, newPost{id: postId, content: postContent}, "INSERT INTO posts (post_id, username, content) VALUES ($1, $2, $3)",
 		postId, username, postContent)
 	if err != nil {
-		err = fmt.Errorf("could not insert into posts: %w", err)
-		return "", getErrorFromString(err, username, "")
+		return "", translateError(err, username, "")
 	}
 
 	return postId, nil
 }
 
 // WriteRepostContent creates a repost for a given username and returns the postId.
-func (pb *posterrBacked) WriteRepostContent(username, repostedId string) (string, error) {
-	conn, err := pb.db.Connect()
-	if err != nil {
-		return "", fmt.Errorf("could not connect to database: %w", err)
-	}
-	defer conn.Close()
-
-	dailyPosts, err := pb.countDailyPosts(username)
+// Posts of the private users username doesn't follow can't be reposted.
+func (pb *posterrBacked) WriteRepostContent(ctx context.Context, username, repostedId string) (string, error) {
+	dailyPosts, err := pb.countDailyPosts(ctx, username)
 	if err != nil {
 		return "", fmt.Errorf("could not count daily posts: %w", err)
 	}
@@ -170,25 +215,66 @@ func (pb *posterrBacked) WriteRepostContent(username, repostedId string) (string
 		return "", ExceededMaximumDailyPostsError{}
 	}
 
-	_, err = conn.Exec(context.Background(), "INSERT INTO posts (post_id, username, reposted_id) VALUES ($1, $2, $3)",
-		postId, username, repostedId)
+	if err = pb.checkLivePost(ctx, repostedId); err != nil {
+		return "", err
+	}
+
+	if err = pb.checkVisiblePost(ctx, username, repostedId); err != nil {
+		return "", err
+	}
+
+	if err = pb.checkAuthorBlocks(ctx, username, repostedId); err != nil {
+		return "", err
+	}
+
+	err = pb.insertPost(ctx, newPost{id: postId, kind: types.RepostNotification, referencedId: repostedId},
+		"INSERT INTO posts (post_id, username, reposted_id) VALUES ($1, $2, $3)", postId, username, repostedId)
 	if err != nil {
-		err = fmt.Errorf("could not insert into posts: %w", err)
-		return "", getErrorFromString(err, username, repostedId)
+		return "", translateError(err, username, repostedId)
 	}
 
 	return postId, nil
 }
 
 // WriteQuoteRepostContent creates a quote repost for a given username and returns the postId.
-func (pb *posterrBacked) WriteQuoteRepostContent(username, postContent, repostedId string) (string, error) {
-	conn, err := pb.db.Connect()
+// Posts of the private users username doesn't follow can't be quote reposted.
+func (pb *posterrBacked) WriteQuoteRepostContent(ctx context.Context, username, postContent, repostedId string) (string, error) {
+	dailyPosts, err := pb.countDailyPosts(ctx, username)
+	if err != nil {
+		return "", fmt.Errorf("could not count daily posts: %w", err)
+	}
+
+	postId := uuid.New().String()
+	if dailyPosts >= maxDailyPosts {
+		return "", ExceededMaximumDailyPostsError{}
+	}
+
+	if err = pb.checkLivePost(ctx, repostedId); err != nil {
+		return "", err
+	}
+
+	if err = pb.checkVisiblePost(ctx, username, repostedId); err != nil {
+		return "", err
+	}
+
+	if err = pb.checkAuthorBlocks(ctx, username, repostedId); err != nil {
+		return "", err
+	}
+
+	err = pb.insertPost(ctx, newPost{id: postId, content: postContent, kind: types.QuoteNotification, referencedId: repostedId},
+		"INSERT INTO posts (post_id, username, content, reposted_id) VALUES ($1, $2, $3, $4)",
+		postId, username, postContent, repostedId)
 	if err != nil {
-		return "", fmt.Errorf("could not connect to database: %w", err)
+		return "", translateError(err, username, repostedId)
 	}
-	defer conn.Close()
 
-	dailyPosts, err := pb.countDailyPosts(username)
+	return postId, nil
+}
+
+// WriteReplyContent creates a reply to replyId for a given username and returns the postId.
+// Posts of the private users username doesn't follow, or of the users who blocked them, can't be replied.
+func (pb *posterrBacked) WriteReplyContent(ctx context.Context, username, postContent, replyId string) (string, error) {
+	dailyPosts, err := pb.countDailyPosts(ctx, username)
 	if err != nil {
 		return "", fmt.Errorf("could not count daily posts: %w", err)
 	}
@@ -198,28 +284,561 @@ func (pb *posterrBacked) WriteQuoteRepostContent(username, postContent, reposted
 		return "", ExceededMaximumDailyPostsError{}
 	}
 
-	_, err = conn.Exec(context.Background(), "INSERT INTO posts (post_id, username, content, reposted_id) VALUES ($1, $2, $3, $4)",
-		postId, username, postContent, repostedId)
+	if err = pb.checkLivePost(ctx, replyId); err != nil {
+		return "", err
+	}
+
+	if err = pb.checkVisiblePost(ctx, username, replyId); err != nil {
+		return "", err
+	}
+
+	if err = pb.checkAuthorBlocks(ctx, username, replyId); err != nil {
+		return "", err
+	}
+
+	err = pb.insertPost(ctx, newPost{id: postId, content: postContent, kind: types.ReplyNotification, referencedId: replyId},
+		"INSERT INTO posts (post_id, username, content, reply_id) VALUES ($1, $2, $3, $4)",
+		postId, username, postContent, replyId)
 	if err != nil {
-		err = fmt.Errorf("could not insert into posts: %w", err)
-		return "", getErrorFromString(err, username, repostedId)
+		return "", translateError(err, username, replyId)
 	}
 
 	return postId, nil
 }
 
-// countDailyPosts returns how many posts where made in a single day.
-func (pb *posterrBacked) countDailyPosts(username string) (int, error) {
-	conn, err := pb.db.Connect()
+// ListReplies returns the whole reply tree of a given postId.
+// Replies sharing the same parent are sorted by creation date
+// according to order, which is either Ascending or Descending.
+// Replies of the users the user of ctx muted or blocked, or of the private users they don't follow,
+// are not listed, nor are the replies to them.
+func (pb *posterrBacked) ListReplies(ctx context.Context, postId, order string) ([]types.PosterrReply, error) {
+	var query string
+	switch order {
+	case types.Ascending:
+		query = selectRepliesAscending
+	case types.Descending:
+		query = selectRepliesDescending
+	default:
+		return nil, InvalidOrderError{order}
+	}
+
+	viewer, _ := types.UserFromContext(ctx)
+	if err := pb.checkVisiblePost(ctx, viewer, postId); err != nil {
+		return nil, err
+	}
+
+	rows, err := pb.pool.Query(ctx, query, postId, viewer)
+	if err != nil {
+		return nil, fmt.Errorf("could not perform selectReplies query: %w", err)
+	}
+	defer rows.Close()
+
+	replies := make([]types.PosterrContent, 0)
+	for rows.Next() {
+		reply := types.PosterrContent{}
+		if err = rows.Scan(&reply.ID, &reply.Username, &reply.Content, &reply.RepostedId, &reply.ReplyId, &reply.CreatedAt, &reply.EditedAt, &reply.Deleted); err != nil {
+			return nil, fmt.Errorf("could not scan selectReplies rows: %w", err)
+		}
+
+		replies = append(replies, reply)
+	}
+
+	if err = pb.setLikes(ctx, replies); err != nil {
+		return nil, err
+	}
+
+	return buildReplyTree(postId, replies), nil
+}
+
+// DeleteContent deletes a post of a given username. The post is kept as a tombstone,
+// so reposts and replies of it are kept and the daily posts count is unaffected.
+func (pb *posterrBacked) DeleteContent(ctx context.Context, username, postId string) error {
+	var author string
+	row := pb.pool.QueryRow(ctx, selectPostAuthor, postId)
+	if err := row.Scan(&author); err != nil {
+		err = fmt.Errorf("could not scan selectPostAuthor rows: %w", err)
+		return translateError(err, username, postId)
+	}
+
+	if author != username {
+		return NotPostAuthorError{username, postId}
+	}
+
+	// revisions are deleted along with the content they precede
+	return pb.pool.BeginFunc(ctx, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, deletePost, postId, username)
+		if err != nil {
+			return fmt.Errorf("could not perform deletePost query: %w", err)
+		}
+
+		// the post was deleted concurrently
+		if tag.RowsAffected() == 0 {
+			return PostIdDoesNotExistError{postId}
+		}
+
+		if _, err = tx.Exec(ctx, deletePostRevisions, postId); err != nil {
+			return fmt.Errorf("could not delete from post_revisions: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// EditContent replaces the content of a post of a given username, keeping the replaced content
+// as a revision. Only posts with content can be edited, within the edit window since their creation.
+// Hashtags and mentions follow the new content, and newly mentioned users are notified.
+func (pb *posterrBacked) EditContent(ctx context.Context, username, postId, postContent string) error {
+	return pb.pool.BeginFunc(ctx, func(tx pgx.Tx) error {
+		var author string
+		var content *string
+		var writtenAt, createdAt time.Time
+		row := tx.QueryRow(ctx, selectEditablePost, postId)
+		if err := row.Scan(&author, &content, &writtenAt, &createdAt); err != nil {
+			err = fmt.Errorf("could not scan selectEditablePost rows: %w", err)
+			return translateError(err, username, postId)
+		}
+
+		if author != username {
+			return NotPostAuthorError{username, postId}
+		}
+
+		if content == nil {
+			return RepostNotEditableError{postId}
+		}
+
+		if time.Since(createdAt) > pb.editWindow {
+			return EditWindowExpiredError{postId, pb.editWindow}
+		}
+
+		if _, err := tx.Exec(ctx, insertPostRevision, postId, *content, writtenAt); err != nil {
+			return fmt.Errorf("could not insert into post_revisions: %w", err)
+		}
+
+		if _, err := tx.Exec(ctx, editPost, postId, postContent); err != nil {
+			err = fmt.Errorf("could not perform editPost query: %w", err)
+			return translateError(err, username, postId)
+		}
+
+		if _, err := tx.Exec(ctx, deletePostHashtags, postId); err != nil {
+			return fmt.Errorf("could not delete from post_hashtags: %w", err)
+		}
+
+		if hashtags := extractHashtags(postContent); len(hashtags) > 0 {
+			if _, err := tx.Exec(ctx, insertPostHashtags, postId, hashtags); err != nil {
+				return fmt.Errorf("could not insert into post_hashtags: %w", err)
+			}
+		}
+
+		if _, err := tx.Exec(ctx, deletePostMentions, postId); err != nil {
+			return fmt.Errorf("could not delete from post_mentions: %w", err)
+		}
+
+		if mentions := extractMentions(postContent); len(mentions) > 0 {
+			if _, err := tx.Exec(ctx, insertPostMentions, postId, mentions); err != nil {
+				return fmt.Errorf("could not insert into post_mentions: %w", err)
+			}
+
+			if _, err := tx.Exec(ctx, insertMentionNotifications, postId); err != nil {
+				return fmt.Errorf("could not insert into notifications: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// ListContentRevisions returns the prior versions of the content of a post,
+// from the original to the latest replaced one, unless the post was deleted
+// or its author is a private user the user of ctx doesn't follow.
+func (pb *posterrBacked) ListContentRevisions(ctx context.Context, postId string) ([]types.PosterrRevision, error) {
+	if err := pb.checkLivePost(ctx, postId); err != nil {
+		return nil, err
+	}
+
+	viewer, _ := types.UserFromContext(ctx)
+	if err := pb.checkVisiblePost(ctx, viewer, postId); err != nil {
+		return nil, err
+	}
+
+	rows, err := pb.pool.Query(ctx, selectPostRevisions, postId)
+	if err != nil {
+		return nil, fmt.Errorf("could not perform selectPostRevisions query: %w", err)
+	}
+	defer rows.Close()
+
+	revisions := make([]types.PosterrRevision, 0)
+	for rows.Next() {
+		var revision types.PosterrRevision
+		if err = rows.Scan(&revision.Content, &revision.CreatedAt, &revision.ReplacedAt); err != nil {
+			return nil, fmt.Errorf("could not scan selectPostRevisions rows: %w", err)
+		}
+
+		revisions = append(revisions, revision)
+	}
+
+	return revisions, nil
+}
+
+// ListHashtagContent returns a list of posts using a given hashtag, with or without the leading #.
+// Posts of the users the user of ctx muted or blocked, or of the private users they don't follow,
+// are not listed. Each call returns 10 posts at most.
+func (pb *posterrBacked) ListHashtagContent(ctx context.Context, hashtag string, page types.Page) (types.PosterrPage, error) {
+	hashtag, err := normalizeHashtag(hashtag)
+	if err != nil {
+		return types.PosterrPage{}, err
+	}
+
+	args, err := pageArgs(page)
+	if err != nil {
+		return types.PosterrPage{}, err
+	}
+
+	viewer, _ := types.UserFromContext(ctx)
+	rows, err := pb.pool.Query(ctx, selectHashtagPosts, append(append([]interface{}{hashtag}, args...), viewer)...)
+	if err != nil {
+		return types.PosterrPage{}, fmt.Errorf("could not perform selectHashtagPosts query: %w", err)
+	}
+	defer rows.Close()
+
+	posts := make([]types.PosterrContent, 0)
+	for rows.Next() {
+		postContent, err := scanHydratedPost(rows)
+		if err != nil {
+			return types.PosterrPage{}, fmt.Errorf("could not scan selectHashtagPosts rows: %w", err)
+		}
+
+		posts = append(posts, postContent)
+	}
+
+	if err = pb.hideReposted(ctx, posts); err != nil {
+		return types.PosterrPage{}, err
+	}
+
+	if err = pb.setLikes(ctx, posts); err != nil {
+		return types.PosterrPage{}, err
+	}
+
+	return newPage(posts, hashtagPageLimit), nil
+}
+
+// ListMentionContent returns a list of posts mentioning a given username.
+// Posts of the users the user of ctx muted or blocked, or of the private users they don't follow,
+// are not listed. Each call returns 10 posts at most.
+func (pb *posterrBacked) ListMentionContent(ctx context.Context, username string, page types.Page) (types.PosterrPage, error) {
+	args, err := pageArgs(page)
+	if err != nil {
+		return types.PosterrPage{}, err
+	}
+
+	viewer, _ := types.UserFromContext(ctx)
+	rows, err := pb.pool.Query(ctx, selectMentionPosts, append(append([]interface{}{username}, args...), viewer)...)
+	if err != nil {
+		return types.PosterrPage{}, fmt.Errorf("could not perform selectMentionPosts query: %w", err)
+	}
+	defer rows.Close()
+
+	posts := make([]types.PosterrContent, 0)
+	for rows.Next() {
+		postContent, err := scanHydratedPost(rows)
+		if err != nil {
+			return types.PosterrPage{}, fmt.Errorf("could not scan selectMentionPosts rows: %w", err)
+		}
+
+		posts = append(posts, postContent)
+	}
+
+	if err = pb.hideReposted(ctx, posts); err != nil {
+		return types.PosterrPage{}, err
+	}
+
+	if err = pb.setLikes(ctx, posts); err != nil {
+		return types.PosterrPage{}, err
+	}
+
+	return newPage(posts, mentionPageLimit), nil
+}
+
+// ListTrendingHashtags returns the hashtags used by most posts within the last window,
+// 24 hours by default. Deleted posts are not counted.
+// The number of returned hashtags can be customized by the limit parameter.
+func (pb *posterrBacked) ListTrendingHashtags(ctx context.Context, window time.Duration, limit int) ([]types.PosterrHashtag, error) {
+	window, limit, err := trendingParams(window, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := pb.pool.Query(ctx, selectTrendingHashtags, time.Now().Add(-window), limit)
+	if err != nil {
+		return nil, fmt.Errorf("could not perform selectTrendingHashtags query: %w", err)
+	}
+	defer rows.Close()
+
+	hashtags := make([]types.PosterrHashtag, 0)
+	for rows.Next() {
+		hashtag := types.PosterrHashtag{}
+		if err = rows.Scan(&hashtag.Hashtag, &hashtag.Count); err != nil {
+			return nil, fmt.Errorf("could not scan selectTrendingHashtags rows: %w", err)
+		}
+
+		hashtags = append(hashtags, hashtag)
+	}
+
+	return hashtags, nil
+}
+
+// LikePost adds a like of username to a post, which doesn't count towards the daily posts.
+// The author of the post is notified the first time a user likes it.
+// Posts of the private users username doesn't follow, or of the users who blocked them, can't be liked.
+func (pb *posterrBacked) LikePost(ctx context.Context, username, postId string) error {
+	if err := pb.checkLivePost(ctx, postId); err != nil {
+		return err
+	}
+
+	if err := pb.checkVisiblePost(ctx, username, postId); err != nil {
+		return err
+	}
+
+	if err := pb.checkAuthorBlocks(ctx, username, postId); err != nil {
+		return err
+	}
+
+	err := pb.pool.BeginFunc(ctx, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, "INSERT INTO post_likes (post_id, username) VALUES ($1, $2)", postId, username); err != nil {
+			return fmt.Errorf("could not insert into post_likes: %w", err)
+		}
+
+		if _, err := tx.Exec(ctx, insertLikeNotification, postId, username, types.LikeNotification); err != nil {
+			return fmt.Errorf("could not insert into notifications: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return translateLikeError(err, username, postId)
+	}
+
+	return nil
+}
+
+// UnlikePost removes a like of username from a post.
+func (pb *posterrBacked) UnlikePost(ctx context.Context, username, postId string) error {
+	tag, err := pb.pool.Exec(ctx, "DELETE FROM post_likes WHERE post_id = $1 AND username = $2", postId, username)
+	if err != nil {
+		return fmt.Errorf("could not delete from post_likes: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return NotLikedError{username, postId}
+	}
+
+	return nil
+}
+
+// ListPostLikers returns the users who liked a post, from the latest to the earliest like,
+// unless its author is a private user the user of ctx doesn't follow.
+func (pb *posterrBacked) ListPostLikers(ctx context.Context, postId string) ([]types.PosterrUser, error) {
+	if err := pb.checkLivePost(ctx, postId); err != nil {
+		return nil, err
+	}
+
+	viewer, _ := types.UserFromContext(ctx)
+	if err := pb.checkVisiblePost(ctx, viewer, postId); err != nil {
+		return nil, err
+	}
+
+	rows, err := pb.pool.Query(ctx, selectPostLikers, postId)
+	if err != nil {
+		return nil, fmt.Errorf("could not perform selectPostLikers query: %w", err)
+	}
+	defer rows.Close()
+
+	likers := make([]types.PosterrUser, 0)
+	for rows.Next() {
+		var liker types.PosterrUser
+		if err = rows.Scan(&liker.Username); err != nil {
+			return nil, fmt.Errorf("could not scan selectPostLikers rows: %w", err)
+		}
+
+		likers = append(likers, liker)
+	}
+
+	return likers, nil
+}
+
+// BookmarkPost saves a post for username to read later. Deleted posts can't be bookmarked,
+// nor posts of the private users username doesn't follow or of the users who blocked them.
+func (pb *posterrBacked) BookmarkPost(ctx context.Context, username, postId string) error {
+	if err := pb.checkLivePost(ctx, postId); err != nil {
+		return err
+	}
+
+	if err := pb.checkVisiblePost(ctx, username, postId); err != nil {
+		return err
+	}
+
+	if err := pb.checkAuthorBlocks(ctx, username, postId); err != nil {
+		return err
+	}
+
+	_, err := pb.pool.Exec(ctx, "INSERT INTO bookmarks (username, post_id) VALUES ($1, $2)", username, postId)
+	if err != nil {
+		err = fmt.Errorf("could not insert into bookmarks: %w", err)
+		return translateBookmarkError(err, username, postId)
+	}
+
+	return nil
+}
+
+// UnbookmarkPost removes a post from the bookmarks of username
+func (pb *posterrBacked) UnbookmarkPost(ctx context.Context, username, postId string) error {
+	tag, err := pb.pool.Exec(ctx, "DELETE FROM bookmarks WHERE username = $1 AND post_id = $2", username, postId)
 	if err != nil {
-		return 0, fmt.Errorf("could not connect to database: %w", err)
+		return fmt.Errorf("could not delete from bookmarks: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return NotBookmarkedError{username, postId}
 	}
-	defer conn.Close()
 
-	rows, err := conn.Query(context.Background(), countDailyPosts, username)
+	return nil
+}
+
+// ListBookmarkedContent returns the posts bookmarked by username, from the latest to the earliest bookmark.
+// Deleted posts and posts of the private users username doesn't follow are not listed.
+// Each call returns 10 posts at most.
+func (pb *posterrBacked) ListBookmarkedContent(ctx context.Context, username string, page types.Page) (types.PosterrPage, error) {
+	args, err := pageArgs(page)
+	if err != nil {
+		return types.PosterrPage{}, err
+	}
+
+	rows, err := pb.pool.Query(ctx, selectBookmarkedPosts, append([]interface{}{username}, args...)...)
+	if err != nil {
+		return types.PosterrPage{}, fmt.Errorf("could not perform selectBookmarkedPosts query: %w", err)
+	}
+	defer rows.Close()
+
+	posts := make([]types.PosterrContent, 0)
+	var bookmarkedAt time.Time
+	for rows.Next() {
+		postContent, err := scanHydratedPost(rows, &bookmarkedAt)
+		if err != nil {
+			return types.PosterrPage{}, fmt.Errorf("could not scan selectBookmarkedPosts rows: %w", err)
+		}
+
+		posts = append(posts, postContent)
+	}
+
+	if err = pb.hideReposted(ctx, posts); err != nil {
+		return types.PosterrPage{}, err
+	}
+
+	if err = pb.setLikes(ctx, posts); err != nil {
+		return types.PosterrPage{}, err
+	}
+
+	return newBookmarkPage(posts, bookmarkedAt), nil
+}
+
+// newPost describes a post being inserted
+type newPost struct {
+	id      string
+	content string
+	// The kind of the notification sent to the author of referencedId, if any
+	kind         string
+	referencedId string
+}
+
+// insertPost runs the insert of a post along with the inserts of its hashtags, mentions
+// and the notifications of the referenced and mentioned users, within a transaction.
+// Users mentioned by a post which was already notified of it are not notified again.
+func (pb *posterrBacked) insertPost(ctx context.Context, post newPost, insert string, args ...interface{}) error {
+	return pb.pool.BeginFunc(ctx, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, insert, args...); err != nil {
+			return fmt.Errorf("could not insert into posts: %w", err)
+		}
+
+		if len(post.referencedId) > 0 {
+			if _, err := tx.Exec(ctx, insertReferenceNotification, post.id, post.kind, post.referencedId); err != nil {
+				return fmt.Errorf("could not insert into notifications: %w", err)
+			}
+		}
+
+		if hashtags := extractHashtags(post.content); len(hashtags) > 0 {
+			if _, err := tx.Exec(ctx, insertPostHashtags, post.id, hashtags); err != nil {
+				return fmt.Errorf("could not insert into post_hashtags: %w", err)
+			}
+		}
+
+		if mentions := extractMentions(post.content); len(mentions) > 0 {
+			if _, err := tx.Exec(ctx, insertPostMentions, post.id, mentions); err != nil {
+				return fmt.Errorf("could not insert into post_mentions: %w", err)
+			}
+
+			if _, err := tx.Exec(ctx, insertMentionNotifications, post.id); err != nil {
+				return fmt.Errorf("could not insert into notifications: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// checkLivePost ensures that postId exists and was not deleted,
+// since deleted posts can no longer be reposted or replied.
+func (pb *posterrBacked) checkLivePost(ctx context.Context, postId string) error {
+	var countRows int
+	row := pb.pool.QueryRow(ctx, countLivePostId, postId)
+	if err := row.Scan(&countRows); err != nil {
+		return fmt.Errorf("could not scan countLivePostId rows: %w", err)
+	}
+
+	if countRows == 0 {
+		return PostIdDoesNotExistError{postId}
+	}
+
+	return nil
+}
+
+// checkAuthorBlocks ensures that the author of postId did not block username,
+// since users can't repost or quote repost the posts of users who blocked them.
+func (pb *posterrBacked) checkAuthorBlocks(ctx context.Context, username, postId string) error {
+	var countRows int
+	row := pb.pool.QueryRow(ctx, countAuthorBlocks, postId, username)
+	if err := row.Scan(&countRows); err != nil {
+		return fmt.Errorf("could not scan countAuthorBlocks rows: %w", err)
+	}
+
+	if countRows > 0 {
+		return BlockedByAuthorError{username, postId}
+	}
+
+	return nil
+}
+
+// checkVisiblePost ensures that postId exists, deleted or not, and that viewer can see it,
+// since the posts of private users are only seen by themselves and their followers.
+func (pb *posterrBacked) checkVisiblePost(ctx context.Context, viewer, postId string) error {
+	var countRows int
+	row := pb.pool.QueryRow(ctx, countVisiblePostId, postId, viewer)
+	if err := row.Scan(&countRows); err != nil {
+		return fmt.Errorf("could not scan countVisiblePostId rows: %w", err)
+	}
+
+	if countRows == 0 {
+		return PostIdDoesNotExistError{postId}
+	}
+
+	return nil
+}
+
+// countDailyPosts returns how many posts where made in a single day.
+func (pb *posterrBacked) countDailyPosts(ctx context.Context, username string) (int, error) {
+	rows, err := pb.pool.Query(ctx, countDailyPosts, username)
 	if err != nil {
 		return 0, fmt.Errorf("could not perform countDailyPosts query: %w", err)
 	}
+	defer rows.Close()
 
 	var dailyPosts int
 	for rows.Next() {
@@ -230,3 +849,101 @@ func (pb *posterrBacked) countDailyPosts(username string) (int, error) {
 
 	return dailyPosts, nil
 }
+
+func (pb *posterrBacked) searchPosts(ctx context.Context, query string, args ...interface{}) ([]types.PosterrContent, error) {
+	rows, err := pb.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("could not perform searchPosts query: %w", err)
+	}
+	defer rows.Close()
+
+	posts := make([]types.PosterrContent, 0)
+	for rows.Next() {
+		postContent, err := scanHydratedPost(rows)
+		if err != nil {
+			return nil, fmt.Errorf("could not scan searchPosts rows: %w", err)
+		}
+
+		posts = append(posts, postContent)
+	}
+
+	if err = pb.hideReposted(ctx, posts); err != nil {
+		return nil, err
+	}
+
+	if err = pb.setLikes(ctx, posts); err != nil {
+		return nil, err
+	}
+
+	return posts, nil
+}
+
+// hideReposted replaces the embedded reposted posts of the private users the user of ctx
+// doesn't follow by tombstones, since reposts are seen by more users than the reposted posts.
+func (pb *posterrBacked) hideReposted(ctx context.Context, posts []types.PosterrContent) error {
+	targets := repostedTargets(posts)
+	if len(targets) == 0 {
+		return nil
+	}
+
+	authors := make([]string, 0, len(targets))
+	for author := range targets {
+		authors = append(authors, author)
+	}
+
+	viewer, _ := types.UserFromContext(ctx)
+	rows, err := pb.pool.Query(ctx, selectHiddenUsers, authors, viewer)
+	if err != nil {
+		return fmt.Errorf("could not perform selectHiddenUsers query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var author string
+		if err = rows.Scan(&author); err != nil {
+			return fmt.Errorf("could not scan selectHiddenUsers rows: %w", err)
+		}
+
+		for _, post := range targets[author] {
+			*post = *tombstone(post.ID)
+		}
+	}
+
+	return rows.Err()
+}
+
+// setLikes sets the likes of posts and of their embedded reposted posts,
+// flagging the ones liked by the user of ctx, if any.
+func (pb *posterrBacked) setLikes(ctx context.Context, posts []types.PosterrContent) error {
+	targets := likeTargets(posts)
+	if len(targets) == 0 {
+		return nil
+	}
+
+	postIds := make([]string, 0, len(targets))
+	for postId := range targets {
+		postIds = append(postIds, postId)
+	}
+
+	viewer, _ := types.UserFromContext(ctx)
+	rows, err := pb.pool.Query(ctx, selectPostLikes, postIds, viewer)
+	if err != nil {
+		return fmt.Errorf("could not perform selectPostLikes query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var postId string
+		var likes int
+		var likedByMe bool
+		if err = rows.Scan(&postId, &likes, &likedByMe); err != nil {
+			return fmt.Errorf("could not scan selectPostLikes rows: %w", err)
+		}
+
+		for _, post := range targets[postId] {
+			post.Likes, post.LikedByMe = likes, likedByMe
+		}
+	}
+
+	return rows.Err()
+}
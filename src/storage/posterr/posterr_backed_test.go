@@ -1,12 +1,14 @@
 package posterr
 
 import (
+	"context"
 	"testing"
 
 	storagedb "posterr/src/storage/db"
 	storageusers "posterr/src/storage/users"
 	testdb "posterr/src/test/db"
 	testrand "posterr/src/test/rand"
+	"posterr/src/types"
 
 	assertions "github.com/stretchr/testify/assert"
 )
@@ -14,150 +16,257 @@ import (
 const maxContentSize = 777
 
 func TestWritePost(t *testing.T) {
+	ctx := context.Background()
 	assert := assertions.New(t)
 	rs := testrand.NewPseudoRandomString()
 	dbName := testdb.GenerateDBName()
 
-	db := storagedb.NewDatabase(dbName)
+	db := storagedb.NewDatabase(dbName, storagedb.DefaultPoolConfig())
 	err := db.InitializeDB()
 	defer testdb.DropDatabase(dbName)
-	assert.NoError(err)
+	if err != nil {
+		t.Fatalf("could not initialize database: %s", err)
+	}
+
+	pool, err := db.Connect()
+	defer db.Close()
+	if err != nil {
+		t.Fatalf("could not connect to database: %s", err)
+	}
 
-	posts := NewPosterrBacked(db)
-	users := storageusers.NewUserBacked(db)
+	posts := NewPosterrBacked(pool, DefaultEditWindow)
+	users := storageusers.NewUserBacked(pool)
 
 	username := rs.GenerateUnique(14)
-	err = users.CreateUser(username)
+	err = users.CreateUser(ctx, username)
 	assert.NoError(err)
 
 	t.Run("Should post if content is just right", func(t *testing.T) {
 		content := rs.GenerateAny(maxContentSize)
-		_, err = posts.WriteContent(username, content)
+		_, err = posts.WriteContent(ctx, username, content)
 		assert.NoError(err)
 	})
 
 	t.Run("Should not post if content is too long", func(t *testing.T) {
 		content := rs.GenerateAny(maxContentSize + 1)
-		_, err = posts.WriteContent(username, content)
-		assert.Equal(PostExceededMaximumCharsError{}, err)
+		_, err = posts.WriteContent(ctx, username, content)
+		assert.ErrorIs(err, PostExceededMaximumCharsError{})
 	})
 }
 
 func TestTooManyPostsInASingleDay(t *testing.T) {
+	ctx := context.Background()
 	assert := assertions.New(t)
 	rs := testrand.NewPseudoRandomString()
 	dbName := testdb.GenerateDBName()
 
-	db := storagedb.NewDatabase(dbName)
+	db := storagedb.NewDatabase(dbName, storagedb.DefaultPoolConfig())
 	err := db.InitializeDB()
 	defer testdb.DropDatabase(dbName)
-	assert.NoError(err)
+	if err != nil {
+		t.Fatalf("could not initialize database: %s", err)
+	}
 
-	posts := NewPosterrBacked(db)
-	users := storageusers.NewUserBacked(db)
+	pool, err := db.Connect()
+	defer db.Close()
+	if err != nil {
+		t.Fatalf("could not connect to database: %s", err)
+	}
+
+	posts := NewPosterrBacked(pool, DefaultEditWindow)
+	users := storageusers.NewUserBacked(pool)
 
 	username := rs.GenerateUnique(14)
-	err = users.CreateUser(username)
+	err = users.CreateUser(ctx, username)
 	assert.NoError(err)
 
 	noPosts := 5
 	for i := 0; i < noPosts; i++ {
 		content := rs.GenerateAny(maxContentSize)
-		_, err = posts.WriteContent(username, content)
+		_, err = posts.WriteContent(ctx, username, content)
 		assert.NoError(err)
 	}
 
 	content := rs.GenerateAny(maxContentSize)
-	_, err = posts.WriteContent(username, content)
-	assert.Equal(ExceededMaximumDailyPostsError{}, err)
+	_, err = posts.WriteContent(ctx, username, content)
+	assert.ErrorIs(err, ExceededMaximumDailyPostsError{})
 }
 
 func TestRepost(t *testing.T) {
+	ctx := context.Background()
 	assert := assertions.New(t)
 	rs := testrand.NewPseudoRandomString()
 	dbName := testdb.GenerateDBName()
 
-	db := storagedb.NewDatabase(dbName)
+	db := storagedb.NewDatabase(dbName, storagedb.DefaultPoolConfig())
 	err := db.InitializeDB()
 	defer testdb.DropDatabase(dbName)
-	assert.NoError(err)
+	if err != nil {
+		t.Fatalf("could not initialize database: %s", err)
+	}
 
-	posts := NewPosterrBacked(db)
-	users := storageusers.NewUserBacked(db)
+	pool, err := db.Connect()
+	defer db.Close()
+	if err != nil {
+		t.Fatalf("could not connect to database: %s", err)
+	}
+
+	posts := NewPosterrBacked(pool, DefaultEditWindow)
+	users := storageusers.NewUserBacked(pool)
 
 	username := rs.GenerateUnique(14)
-	err = users.CreateUser(username)
+	err = users.CreateUser(ctx, username)
 	assert.NoError(err)
 
 	t.Run("Should repost an existing post", func(t *testing.T) {
 		content := rs.GenerateAny(maxContentSize)
-		postId, err := posts.WriteContent(username, content)
+		postId, err := posts.WriteContent(ctx, username, content)
 		assert.NoError(err)
 
-		_, err = posts.WriteRepostContent(username, postId)
+		_, err = posts.WriteRepostContent(ctx, username, postId)
 		assert.NoError(err)
 	})
 
 	t.Run("Should not repost an non existing post", func(t *testing.T) {
 		content := rs.GenerateAny(maxContentSize)
-		_, err := posts.WriteContent(username, content)
+		_, err := posts.WriteContent(ctx, username, content)
 		assert.NoError(err)
 
-		_, err = posts.WriteRepostContent(username, "somePostId")
-		assert.Equal(PostIdDoesNotExistError{"somePostId"}, err)
+		_, err = posts.WriteRepostContent(ctx, username, "somePostId")
+		assert.ErrorIs(err, PostIdDoesNotExistError{"somePostId"})
 	})
 
 	t.Run("Should not repost if username does not existing", func(t *testing.T) {
 		content := rs.GenerateAny(maxContentSize)
-		postId, err := posts.WriteContent(username, content)
+		postId, err := posts.WriteContent(ctx, username, content)
 		assert.NoError(err)
 
-		_, err = posts.WriteRepostContent("notauser", postId)
-		assert.Equal(UserDoesNotExistError{"notauser"}, err)
+		_, err = posts.WriteRepostContent(ctx, "notauser", postId)
+		assert.ErrorIs(err, UserDoesNotExistError{"notauser"})
 	})
 }
 
 func TestQuotedRepost(t *testing.T) {
+	ctx := context.Background()
 	assert := assertions.New(t)
 	rs := testrand.NewPseudoRandomString()
 	dbName := testdb.GenerateDBName()
 
-	db := storagedb.NewDatabase(dbName)
+	db := storagedb.NewDatabase(dbName, storagedb.DefaultPoolConfig())
 	err := db.InitializeDB()
 	defer testdb.DropDatabase(dbName)
-	assert.NoError(err)
+	if err != nil {
+		t.Fatalf("could not initialize database: %s", err)
+	}
+
+	pool, err := db.Connect()
+	defer db.Close()
+	if err != nil {
+		t.Fatalf("could not connect to database: %s", err)
+	}
 
-	posts := NewPosterrBacked(db)
-	users := storageusers.NewUserBacked(db)
+	posts := NewPosterrBacked(pool, DefaultEditWindow)
+	users := storageusers.NewUserBacked(pool)
 
 	username := rs.GenerateUnique(14)
-	err = users.CreateUser(username)
+	err = users.CreateUser(ctx, username)
 	assert.NoError(err)
 
 	t.Run("Should quote repost an existing post", func(t *testing.T) {
 		content := rs.GenerateAny(maxContentSize)
-		postId, err := posts.WriteContent(username, content)
+		postId, err := posts.WriteContent(ctx, username, content)
 		assert.NoError(err)
 
-		_, err = posts.WriteQuoteRepostContent(username, "check this out", postId)
+		_, err = posts.WriteQuoteRepostContent(ctx, username, "check this out", postId)
 		assert.NoError(err)
 	})
 
 	t.Run("Should not quote repost an non existing post", func(t *testing.T) {
 		content := rs.GenerateAny(maxContentSize)
-		_, err := posts.WriteContent(username, content)
+		_, err := posts.WriteContent(ctx, username, content)
 		assert.NoError(err)
 
-		_, err = posts.WriteQuoteRepostContent(username, "check this out", "somePostId")
-		assert.Equal(PostIdDoesNotExistError{"somePostId"}, err)
+		_, err = posts.WriteQuoteRepostContent(ctx, username, "check this out", "somePostId")
+		assert.ErrorIs(err, PostIdDoesNotExistError{"somePostId"})
 	})
 
 	t.Run("Should not quote repost if username does not existing", func(t *testing.T) {
 		content := rs.GenerateAny(maxContentSize)
-		postId, err := posts.WriteContent(username, content)
+		postId, err := posts.WriteContent(ctx, username, content)
 		assert.NoError(err)
 
-		_, err = posts.WriteQuoteRepostContent("notauser", "check this out", postId)
-		assert.Equal(UserDoesNotExistError{"notauser"}, err)
+		_, err = posts.WriteQuoteRepostContent(ctx, "notauser", "check this out", postId)
+		assert.ErrorIs(err, UserDoesNotExistError{"notauser"})
+	})
+}
+
+func TestReply(t *testing.T) {
+	ctx := context.Background()
+	assert := assertions.New(t)
+	rs := testrand.NewPseudoRandomString()
+	dbName := testdb.GenerateDBName()
+
+	db := storagedb.NewDatabase(dbName, storagedb.DefaultPoolConfig())
+	err := db.InitializeDB()
+	defer testdb.DropDatabase(dbName)
+	if err != nil {
+		t.Fatalf("could not initialize database: %s", err)
+	}
+
+	pool, err := db.Connect()
+	defer db.Close()
+	if err != nil {
+		t.Fatalf("could not connect to database: %s", err)
+	}
+
+	posts := NewPosterrBacked(pool, DefaultEditWindow)
+	users := storageusers.NewUserBacked(pool)
+
+	username := rs.GenerateUnique(14)
+	err = users.CreateUser(ctx, username)
+	assert.NoError(err)
+
+	t.Run("Should reply to replies and list the reply tree", func(t *testing.T) {
+		postId, err := posts.WriteContent(ctx, username, "what do you think?")
+		assert.NoError(err)
+
+		replyId, err := posts.WriteReplyContent(ctx, username, "first reply", postId)
+		assert.NoError(err)
+
+		nestedReplyId, err := posts.WriteReplyContent(ctx, username, "nested reply", replyId)
+		assert.NoError(err)
+
+		secondReplyId, err := posts.WriteReplyContent(ctx, username, "second reply", postId)
+		assert.NoError(err)
+
+		replies, err := posts.ListReplies(ctx, postId, types.Ascending)
+		assert.NoError(err)
+		assert.Len(replies, 2)
+		assert.Equal(replyId, replies[0].ID)
+		assert.Equal(secondReplyId, replies[1].ID)
+		assert.Len(replies[0].Replies, 1)
+		assert.Equal(nestedReplyId, replies[0].Replies[0].ID)
+		assert.Empty(replies[1].Replies)
+
+		replies, err = posts.ListReplies(ctx, postId, types.Descending)
+		assert.NoError(err)
+		assert.Len(replies, 2)
+		assert.Equal(secondReplyId, replies[0].ID)
+		assert.Equal(replyId, replies[1].ID)
+	})
+
+	t.Run("Should not reply to a non existing post", func(t *testing.T) {
+		_, err = posts.WriteReplyContent(ctx, username, "hello?", "somePostId")
+		assert.ErrorIs(err, PostIdDoesNotExistError{"somePostId"})
+	})
+
+	t.Run("Should not list replies of a non existing post", func(t *testing.T) {
+		_, err = posts.ListReplies(ctx, "somePostId", types.Ascending)
+		assert.ErrorIs(err, PostIdDoesNotExistError{"somePostId"})
+	})
+
+	t.Run("Should not list replies in an invalid order", func(t *testing.T) {
+		_, err = posts.ListReplies(ctx, "somePostId", "sideways")
+		assert.ErrorIs(err, InvalidOrderError{"sideways"})
 	})
 }
@@ -0,0 +1,895 @@
+package posterr
+
+import (
+	"context"
+	"sort"
+	"time"
+	"unicode/utf8"
+
+	"posterr/src/storage/memory"
+	"posterr/src/types"
+
+	"github.com/google/uuid"
+)
+
+const (
+	homePageLimit    = 10
+	profilePageLimit = 5
+	maxContentChars  = 777
+)
+
+type posterrMemory struct {
+	store *memory.Store
+	// How long after their creation posts can be edited
+	editWindow time.Duration
+}
+
+func NewPosterrMemory(store *memory.Store, editWindow time.Duration) *posterrMemory {
+	return &posterrMemory{
+		store:      store,
+		editWindow: editWindow,
+	}
+}
+
+// ListHomePageContent returns a list of posts:
+// - If the toggle is All, returns a list of posts from the whole store;
+// - If the toggle is Following, returns a list of posts only from the users a given username follows.
+// Posts of the users username muted or blocked, or of the private users
+// they don't follow, are not listed. Each call returns 10 posts at most.
+func (pm *posterrMemory) ListHomePageContent(ctx context.Context, username string, page types.Page, toggle bool) (types.PosterrPage, error) {
+	pm.store.RLock()
+	defer pm.store.RUnlock()
+
+	switch toggle {
+	case types.All:
+		return pm.selectPosts(ctx, page, homePageLimit, func(post memory.Post) bool {
+			return !post.Deleted && !pm.isHidden(username, post.Username) && pm.store.CanSee(username, post.Username)
+		})
+	case types.Following:
+		return pm.selectPosts(ctx, page, homePageLimit, func(post memory.Post) bool {
+			return !post.Deleted && pm.store.IsFollowing(post.Username, username) && !pm.isHidden(username, post.Username)
+		})
+	default:
+		return types.PosterrPage{}, InvalidToggleError{}
+	}
+}
+
+// ListProfileContent returns a lists of posts for a given username. Posts of private users
+// are only listed to themselves and their followers, as the user of ctx.
+// Each call returns 5 posts at most.
+func (pm *posterrMemory) ListProfileContent(ctx context.Context, username string, page types.Page) (types.PosterrPage, error) {
+	pm.store.RLock()
+	defer pm.store.RUnlock()
+
+	viewer, _ := types.UserFromContext(ctx)
+	return pm.selectPosts(ctx, page, profilePageLimit, func(post memory.Post) bool {
+		return !post.Deleted && post.Username == username && pm.store.CanSee(viewer, username)
+	})
+}
+
+// GetContent returns a post by its id, unless it was deleted
+// or its author is a private user the user of ctx doesn't follow.
+func (pm *posterrMemory) GetContent(ctx context.Context, postId string) (types.PosterrContent, error) {
+	pm.store.RLock()
+	defer pm.store.RUnlock()
+
+	viewer, _ := types.UserFromContext(ctx)
+	post, exists := pm.store.GetLivePost(postId)
+	if !exists || !pm.store.CanSee(viewer, post.Username) {
+		return types.PosterrContent{}, PostIdDoesNotExistError{postId}
+	}
+
+	posts := []types.PosterrContent{pm.toHydratedContent(viewer, post)}
+	pm.setLikes(ctx, posts)
+
+	return posts[0], nil
+}
+
+// SearchContent returns a list of posts matching a search query,
+// sorted either by relevance or from the newest to the oldest.
+// Posts of the users the user of ctx muted or blocked, or of the private users
+// they don't follow, are not matched.
+// The number of returned posts can be customized by the limit parameter, up to 50.
+func (pm *posterrMemory) SearchContent(ctx context.Context, query types.SearchQuery, limit int, page types.Page) (types.PosterrPage, error) {
+	pm.store.RLock()
+	defer pm.store.RUnlock()
+
+	limit = searchLimit(limit)
+
+	viewer, _ := types.UserFromContext(ctx)
+	switch query.Sort {
+	case types.Relevance:
+		return pm.searchPostsByRelevance(ctx, query, viewer, limit, page)
+	case types.Recent:
+		return pm.selectPosts(ctx, page, limit, func(post memory.Post) bool {
+			_, matches := pm.matchPost(query, viewer, post)
+			return matches
+		})
+	default:
+		return types.PosterrPage{}, InvalidSortError{query.Sort}
+	}
+}
+
+// WriteContent creates a post for a given username and returns the postId.
+func (pm *posterrMemory) WriteContent(ctx context.Context, username, postContent string) (string, error) {
+	return pm.writePost(memory.Post{
+		Username: username,
+		Content:  postContent,
+	})
+}
+
+// WriteRepostContent creates a repost for a given username and returns the postId.
+// Posts of the private users username doesn't follow can't be reposted.
+func (pm *posterrMemory) WriteRepostContent(ctx context.Context, username, repostedId string) (string, error) {
+	return pm.writePost(memory.Post{
+		Username:   username,
+		RepostedId: repostedId,
+	})
+}
+
+// WriteQuoteRepostContent creates a quote repost for a given username and returns the postId.
+// Posts of the private users username doesn't follow can't be quote reposted.
+func (pm *posterrMemory) WriteQuoteRepostContent(ctx context.Context, username, postContent, repostedId string) (string, error) {
+	return pm.writePost(memory.Post{
+		Username:   username,
+		Content:    postContent,
+		RepostedId: repostedId,
+	})
+}
+
+// WriteReplyContent creates a reply to replyId for a given username and returns the postId.
+// Posts of the private users username doesn't follow, or of the users who blocked them, can't be replied.
+func (pm *posterrMemory) WriteReplyContent(ctx context.Context, username, postContent, replyId string) (string, error) {
+	return pm.writePost(memory.Post{
+		Username: username,
+		Content:  postContent,
+		ReplyId:  replyId,
+	})
+}
+
+// ListReplies returns the whole reply tree of a given postId.
+// Replies sharing the same parent are sorted by creation date
+// according to order, which is either Ascending or Descending.
+// Replies of the users the user of ctx muted or blocked, or of the private users they don't follow,
+// are not listed, nor are the replies to them.
+func (pm *posterrMemory) ListReplies(ctx context.Context, postId, order string) ([]types.PosterrReply, error) {
+	if order != types.Ascending && order != types.Descending {
+		return nil, InvalidOrderError{order}
+	}
+
+	pm.store.RLock()
+	defer pm.store.RUnlock()
+
+	viewer, _ := types.UserFromContext(ctx)
+	if post, exists := pm.store.GetPost(postId); !exists || !pm.store.CanSee(viewer, post.Username) {
+		return nil, PostIdDoesNotExistError{postId}
+	}
+
+	// collects every visible descendant of postId, which are always newer than their parents
+	descendants := map[string]struct{}{postId: {}}
+	replies := make([]types.PosterrContent, 0)
+	for _, post := range pm.store.Posts {
+		_, isDescendant := descendants[post.ReplyId]
+		if isDescendant && !pm.isHidden(viewer, post.Username) && pm.store.CanSee(viewer, post.Username) {
+			descendants[post.ID] = struct{}{}
+			replies = append(replies, toPosterrContent(post))
+		}
+	}
+
+	if order == types.Descending {
+		for i, j := 0, len(replies)-1; i < j; i, j = i+1, j-1 {
+			replies[i], replies[j] = replies[j], replies[i]
+		}
+	}
+	pm.setLikes(ctx, replies)
+
+	return buildReplyTree(postId, replies), nil
+}
+
+// DeleteContent deletes a post of a given username. The post is kept as a tombstone,
+// so reposts and replies of it are kept and the daily posts count is unaffected.
+func (pm *posterrMemory) DeleteContent(ctx context.Context, username, postId string) error {
+	pm.store.Lock()
+	defer pm.store.Unlock()
+
+	post, exists := pm.store.GetLivePost(postId)
+	if !exists {
+		return PostIdDoesNotExistError{postId}
+	}
+
+	if post.Username != username {
+		return NotPostAuthorError{username, postId}
+	}
+
+	index := pm.store.PostsIndex[postId]
+	pm.store.Posts[index].Content = ""
+	pm.store.Posts[index].Deleted = true
+	// revisions are deleted along with the content they precede
+	delete(pm.store.Revisions, postId)
+
+	return nil
+}
+
+// EditContent replaces the content of a post of a given username, keeping the replaced content
+// as a revision. Only posts with content can be edited, within the edit window since their creation.
+// Hashtags and mentions follow the new content, and newly mentioned users are notified.
+func (pm *posterrMemory) EditContent(ctx context.Context, username, postId, postContent string) error {
+	pm.store.Lock()
+	defer pm.store.Unlock()
+
+	post, exists := pm.store.GetLivePost(postId)
+	if !exists {
+		return PostIdDoesNotExistError{postId}
+	}
+
+	if post.Username != username {
+		return NotPostAuthorError{username, postId}
+	}
+
+	if len(post.Content) == 0 {
+		return RepostNotEditableError{postId}
+	}
+
+	if time.Since(post.CreatedAt) > pm.editWindow {
+		return EditWindowExpiredError{postId, pm.editWindow}
+	}
+
+	if utf8.RuneCountInString(postContent) > maxContentChars {
+		return PostExceededMaximumCharsError{}
+	}
+
+	writtenAt := post.CreatedAt
+	if !post.EditedAt.IsZero() {
+		writtenAt = post.EditedAt
+	}
+	editedAt := time.Now()
+	pm.store.Revisions[postId] = append(pm.store.Revisions[postId], memory.Revision{
+		Content:    post.Content,
+		CreatedAt:  writtenAt,
+		ReplacedAt: editedAt,
+	})
+
+	post.Content = postContent
+	post.EditedAt = editedAt
+	post.Hashtags = extractHashtags(postContent)
+	post.Mentions = pm.registeredMentions(postContent)
+	pm.store.Posts[pm.store.PostsIndex[postId]] = post
+	pm.notifyNewMentions(post)
+
+	return nil
+}
+
+// ListContentRevisions returns the prior versions of the content of a post,
+// from the original to the latest replaced one, unless the post was deleted
+// or its author is a private user the user of ctx doesn't follow.
+func (pm *posterrMemory) ListContentRevisions(ctx context.Context, postId string) ([]types.PosterrRevision, error) {
+	pm.store.RLock()
+	defer pm.store.RUnlock()
+
+	viewer, _ := types.UserFromContext(ctx)
+	post, exists := pm.store.GetLivePost(postId)
+	if !exists || !pm.store.CanSee(viewer, post.Username) {
+		return nil, PostIdDoesNotExistError{postId}
+	}
+
+	revisions := make([]types.PosterrRevision, 0, len(pm.store.Revisions[postId]))
+	for _, revision := range pm.store.Revisions[postId] {
+		revisions = append(revisions, types.PosterrRevision{
+			Content:    revision.Content,
+			CreatedAt:  revision.CreatedAt,
+			ReplacedAt: revision.ReplacedAt,
+		})
+	}
+
+	return revisions, nil
+}
+
+// ListHashtagContent returns a list of posts using a given hashtag, with or without the leading #.
+// Posts of the users the user of ctx muted or blocked, or of the private users they don't follow,
+// are not listed. Each call returns 10 posts at most.
+func (pm *posterrMemory) ListHashtagContent(ctx context.Context, hashtag string, page types.Page) (types.PosterrPage, error) {
+	hashtag, err := normalizeHashtag(hashtag)
+	if err != nil {
+		return types.PosterrPage{}, err
+	}
+
+	pm.store.RLock()
+	defer pm.store.RUnlock()
+
+	viewer, _ := types.UserFromContext(ctx)
+	return pm.selectPosts(ctx, page, hashtagPageLimit, func(post memory.Post) bool {
+		if post.Deleted || pm.isHidden(viewer, post.Username) || !pm.store.CanSee(viewer, post.Username) {
+			return false
+		}
+
+		for _, postHashtag := range post.Hashtags {
+			if postHashtag == hashtag {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// ListMentionContent returns a list of posts mentioning a given username.
+// Posts of the users the user of ctx muted or blocked, or of the private users they don't follow,
+// are not listed. Each call returns 10 posts at most.
+func (pm *posterrMemory) ListMentionContent(ctx context.Context, username string, page types.Page) (types.PosterrPage, error) {
+	pm.store.RLock()
+	defer pm.store.RUnlock()
+
+	viewer, _ := types.UserFromContext(ctx)
+	return pm.selectPosts(ctx, page, mentionPageLimit, func(post memory.Post) bool {
+		if post.Deleted || pm.isHidden(viewer, post.Username) || !pm.store.CanSee(viewer, post.Username) {
+			return false
+		}
+
+		for _, mention := range post.Mentions {
+			if mention == username {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// ListTrendingHashtags returns the hashtags used by most posts within the last window,
+// 24 hours by default. Deleted posts are not counted.
+// The number of returned hashtags can be customized by the limit parameter.
+func (pm *posterrMemory) ListTrendingHashtags(ctx context.Context, window time.Duration, limit int) ([]types.PosterrHashtag, error) {
+	window, limit, err := trendingParams(window, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	pm.store.RLock()
+	defer pm.store.RUnlock()
+
+	since := time.Now().Add(-window)
+	hashtags := make([]types.PosterrHashtag, 0)
+	indexes := make(map[string]int)
+	lastUsed := make(map[string]time.Time)
+	for i := len(pm.store.Posts) - 1; i >= 0 && !pm.store.Posts[i].CreatedAt.Before(since); i-- {
+		post := pm.store.Posts[i]
+		if post.Deleted {
+			continue
+		}
+
+		for _, hashtag := range post.Hashtags {
+			index, exists := indexes[hashtag]
+			if !exists {
+				// posts are visited from the newest to the oldest
+				index = len(hashtags)
+				indexes[hashtag] = index
+				lastUsed[hashtag] = post.CreatedAt
+				hashtags = append(hashtags, types.PosterrHashtag{Hashtag: hashtag})
+			}
+			hashtags[index].Count++
+		}
+	}
+
+	// ties are broken by the most recently used hashtag
+	sort.Slice(hashtags, func(i, j int) bool {
+		if hashtags[i].Count != hashtags[j].Count {
+			return hashtags[i].Count > hashtags[j].Count
+		}
+		if !lastUsed[hashtags[i].Hashtag].Equal(lastUsed[hashtags[j].Hashtag]) {
+			return lastUsed[hashtags[i].Hashtag].After(lastUsed[hashtags[j].Hashtag])
+		}
+		return hashtags[i].Hashtag < hashtags[j].Hashtag
+	})
+
+	if len(hashtags) > limit {
+		hashtags = hashtags[:limit]
+	}
+	return hashtags, nil
+}
+
+// LikePost adds a like of username to a post, which doesn't count towards the daily posts.
+// The author of the post is notified the first time a user likes it.
+// Posts of the private users username doesn't follow, or of the users who blocked them, can't be liked.
+func (pm *posterrMemory) LikePost(ctx context.Context, username, postId string) error {
+	pm.store.Lock()
+	defer pm.store.Unlock()
+
+	post, exists := pm.store.GetLivePost(postId)
+	if !exists || !pm.store.CanSee(username, post.Username) {
+		return PostIdDoesNotExistError{postId}
+	}
+
+	if pm.store.IsBlocking(post.Username, username) {
+		return BlockedByAuthorError{username, postId}
+	}
+
+	if _, exists := pm.store.Users[username]; !exists {
+		return UserDoesNotExistError{username}
+	}
+
+	if _, liked := pm.store.Likes[postId][username]; liked {
+		return AlreadyLikedError{username, postId}
+	}
+
+	if pm.store.Likes[postId] == nil {
+		pm.store.Likes[postId] = make(map[string]time.Time)
+	}
+	pm.store.Likes[postId][username] = time.Now()
+
+	if post.Username == username {
+		return nil
+	}
+
+	for _, notification := range pm.store.Notifications {
+		if notification.PostId == postId && notification.Actor == username && notification.Kind == types.LikeNotification {
+			return nil
+		}
+	}
+
+	pm.store.AddNotification(memory.Notification{
+		Username:  post.Username,
+		Kind:      types.LikeNotification,
+		Actor:     username,
+		PostId:    postId,
+		CreatedAt: pm.store.Likes[postId][username],
+	})
+
+	return nil
+}
+
+// UnlikePost removes a like of username from a post.
+func (pm *posterrMemory) UnlikePost(ctx context.Context, username, postId string) error {
+	pm.store.Lock()
+	defer pm.store.Unlock()
+
+	if _, liked := pm.store.Likes[postId][username]; !liked {
+		return NotLikedError{username, postId}
+	}
+
+	delete(pm.store.Likes[postId], username)
+
+	return nil
+}
+
+// ListPostLikers returns the users who liked a post, from the latest to the earliest like,
+// unless its author is a private user the user of ctx doesn't follow.
+func (pm *posterrMemory) ListPostLikers(ctx context.Context, postId string) ([]types.PosterrUser, error) {
+	pm.store.RLock()
+	defer pm.store.RUnlock()
+
+	viewer, _ := types.UserFromContext(ctx)
+	post, exists := pm.store.GetLivePost(postId)
+	if !exists || !pm.store.CanSee(viewer, post.Username) {
+		return nil, PostIdDoesNotExistError{postId}
+	}
+
+	likes := pm.store.Likes[postId]
+	likers := make([]types.PosterrUser, 0, len(likes))
+	for username := range likes {
+		likers = append(likers, types.PosterrUser{Username: username})
+	}
+
+	sort.Slice(likers, func(i, j int) bool {
+		likedAt, otherLikedAt := likes[likers[i].Username], likes[likers[j].Username]
+		if !likedAt.Equal(otherLikedAt) {
+			return likedAt.After(otherLikedAt)
+		}
+		return likers[i].Username < likers[j].Username
+	})
+
+	return likers, nil
+}
+
+// BookmarkPost saves a post for username to read later. Deleted posts can't be bookmarked,
+// nor posts of the private users username doesn't follow or of the users who blocked them.
+func (pm *posterrMemory) BookmarkPost(ctx context.Context, username, postId string) error {
+	pm.store.Lock()
+	defer pm.store.Unlock()
+
+	post, exists := pm.store.GetLivePost(postId)
+	if !exists || !pm.store.CanSee(username, post.Username) {
+		return PostIdDoesNotExistError{postId}
+	}
+
+	if pm.store.IsBlocking(post.Username, username) {
+		return BlockedByAuthorError{username, postId}
+	}
+
+	if _, exists := pm.store.Users[username]; !exists {
+		return UserDoesNotExistError{username}
+	}
+
+	if _, bookmarked := pm.store.Bookmarks[username][postId]; bookmarked {
+		return AlreadyBookmarkedError{username, postId}
+	}
+
+	if pm.store.Bookmarks[username] == nil {
+		pm.store.Bookmarks[username] = make(map[string]time.Time)
+	}
+	pm.store.Bookmarks[username][postId] = time.Now()
+
+	return nil
+}
+
+// UnbookmarkPost removes a post from the bookmarks of username
+func (pm *posterrMemory) UnbookmarkPost(ctx context.Context, username, postId string) error {
+	pm.store.Lock()
+	defer pm.store.Unlock()
+
+	if _, bookmarked := pm.store.Bookmarks[username][postId]; !bookmarked {
+		return NotBookmarkedError{username, postId}
+	}
+
+	delete(pm.store.Bookmarks[username], postId)
+	return nil
+}
+
+// ListBookmarkedContent returns the posts bookmarked by username, from the latest to the earliest bookmark.
+// Deleted posts and posts of the private users username doesn't follow are not listed.
+// Each call returns 10 posts at most.
+func (pm *posterrMemory) ListBookmarkedContent(ctx context.Context, username string, page types.Page) (types.PosterrPage, error) {
+	offset := page.Offset
+	after := func(bookmarkedAt time.Time, postId string) bool { return true }
+	if len(page.Cursor) > 0 {
+		c, err := decodeKeysetCursor(page.Cursor)
+		if err != nil {
+			return types.PosterrPage{}, err
+		}
+
+		offset = 0
+		after = c.isAfter
+	}
+
+	pm.store.RLock()
+	defer pm.store.RUnlock()
+
+	type bookmark struct {
+		post memory.Post
+		at   time.Time
+	}
+	bookmarks := make([]bookmark, 0, len(pm.store.Bookmarks[username]))
+	for postId, bookmarkedAt := range pm.store.Bookmarks[username] {
+		post, exists := pm.store.GetLivePost(postId)
+		if exists && pm.store.CanSee(username, post.Username) && after(bookmarkedAt, postId) {
+			bookmarks = append(bookmarks, bookmark{post, bookmarkedAt})
+		}
+	}
+	sort.Slice(bookmarks, func(i, j int) bool {
+		if !bookmarks[i].at.Equal(bookmarks[j].at) {
+			return bookmarks[i].at.After(bookmarks[j].at)
+		}
+		return bookmarks[i].post.ID > bookmarks[j].post.ID
+	})
+
+	if offset > len(bookmarks) {
+		offset = len(bookmarks)
+	}
+	bookmarks = bookmarks[offset:]
+	if len(bookmarks) > bookmarkPageLimit {
+		bookmarks = bookmarks[:bookmarkPageLimit]
+	}
+
+	posts := make([]types.PosterrContent, 0, len(bookmarks))
+	var bookmarkedAt time.Time
+	for _, bookmark := range bookmarks {
+		posts = append(posts, pm.toHydratedContent(username, bookmark.post))
+		bookmarkedAt = bookmark.at
+	}
+	pm.setLikes(ctx, posts)
+
+	return newBookmarkPage(posts, bookmarkedAt), nil
+}
+
+// writePost validates and stores a post, the same way
+// the constraints of the posts table do, and returns the postId.
+func (pm *posterrMemory) writePost(post memory.Post) (string, error) {
+	pm.store.Lock()
+	defer pm.store.Unlock()
+
+	if pm.countDailyPosts(post.Username) >= maxDailyPosts {
+		return "", ExceededMaximumDailyPostsError{}
+	}
+
+	if utf8.RuneCountInString(post.Content) > maxContentChars {
+		return "", PostExceededMaximumCharsError{}
+	}
+
+	if _, exists := pm.store.Users[post.Username]; !exists {
+		return "", UserDoesNotExistError{post.Username}
+	}
+
+	reposted, exists := pm.store.GetLivePost(post.RepostedId)
+	if len(post.RepostedId) > 0 && !exists {
+		return "", PostIdDoesNotExistError{post.RepostedId}
+	}
+
+	if exists && !pm.store.CanSee(post.Username, reposted.Username) {
+		return "", PostIdDoesNotExistError{post.RepostedId}
+	}
+
+	if exists && pm.store.IsBlocking(reposted.Username, post.Username) {
+		return "", BlockedByAuthorError{post.Username, post.RepostedId}
+	}
+
+	replied, exists := pm.store.GetLivePost(post.ReplyId)
+	if len(post.ReplyId) > 0 && !exists {
+		return "", PostIdDoesNotExistError{post.ReplyId}
+	}
+
+	if exists && !pm.store.CanSee(post.Username, replied.Username) {
+		return "", PostIdDoesNotExistError{post.ReplyId}
+	}
+
+	if exists && pm.store.IsBlocking(replied.Username, post.Username) {
+		return "", BlockedByAuthorError{post.Username, post.ReplyId}
+	}
+
+	post.ID = uuid.New().String()
+	post.CreatedAt = time.Now()
+	post.Hashtags = extractHashtags(post.Content)
+	post.Mentions = pm.registeredMentions(post.Content)
+	pm.store.AddPost(post)
+	pm.notifyPost(post)
+
+	return post.ID, nil
+}
+
+// notifyPost notifies the author of the post referenced by post, which is reposted, quoted
+// or replied by it, and the users it mentions. Users are not notified of their own posts,
+// nor twice of the same post.
+func (pm *posterrMemory) notifyPost(post memory.Post) {
+	notified := map[string]struct{}{post.Username: {}}
+	notify := func(username, kind string) {
+		if _, exists := notified[username]; exists {
+			return
+		}
+
+		notified[username] = struct{}{}
+		pm.store.AddNotification(memory.Notification{
+			Username:  username,
+			Kind:      kind,
+			Actor:     post.Username,
+			PostId:    post.ID,
+			CreatedAt: post.CreatedAt,
+		})
+	}
+
+	kind, referencedId := types.ReplyNotification, post.ReplyId
+	if len(post.RepostedId) > 0 {
+		kind, referencedId = types.QuoteNotification, post.RepostedId
+		if len(post.Content) == 0 {
+			kind = types.RepostNotification
+		}
+	}
+
+	if referenced, exists := pm.store.GetPost(referencedId); exists {
+		notify(referenced.Username, kind)
+	}
+
+	for _, mention := range post.Mentions {
+		notify(mention, types.MentionNotification)
+	}
+}
+
+// notifyNewMentions notifies the users mentioned by an edited post,
+// unless they are its author or were already notified of it.
+func (pm *posterrMemory) notifyNewMentions(post memory.Post) {
+	notified := map[string]struct{}{post.Username: {}}
+	for _, notification := range pm.store.Notifications {
+		if notification.PostId == post.ID {
+			notified[notification.Username] = struct{}{}
+		}
+	}
+
+	for _, mention := range post.Mentions {
+		if _, exists := notified[mention]; exists {
+			continue
+		}
+
+		pm.store.AddNotification(memory.Notification{
+			Username:  mention,
+			Kind:      types.MentionNotification,
+			Actor:     post.Username,
+			PostId:    post.ID,
+			CreatedAt: post.CreatedAt,
+		})
+	}
+}
+
+// registeredMentions returns the distinct registered usernames mentioned by content
+func (pm *posterrMemory) registeredMentions(content string) []string {
+	var mentions []string
+	for _, mention := range extractMentions(content) {
+		if _, exists := pm.store.Users[mention]; exists {
+			mentions = append(mentions, mention)
+		}
+	}
+	return mentions
+}
+
+// isHidden checks if the posts of author are hidden from viewer, who muted or blocked author
+func (pm *posterrMemory) isHidden(viewer, author string) bool {
+	return pm.store.IsMuting(viewer, author) || pm.store.IsBlocking(viewer, author)
+}
+
+// countDailyPosts returns how many posts where made in a single day.
+func (pm *posterrMemory) countDailyPosts(username string) int {
+	year, month, day := time.Now().Date()
+
+	var dailyPosts int
+	for i := len(pm.store.Posts) - 1; i >= 0; i-- {
+		post := pm.store.Posts[i]
+		postYear, postMonth, postDay := post.CreatedAt.Date()
+		if postYear != year || postMonth != month || postDay != day {
+			break
+		}
+
+		if post.Username == username {
+			dailyPosts++
+		}
+	}
+
+	return dailyPosts
+}
+
+// selectPosts returns a page of the posts matching filter from the newest
+// to the oldest, starting after the page cursor or skipping the page offset
+func (pm *posterrMemory) selectPosts(ctx context.Context, page types.Page, limit int, filter func(post memory.Post) bool) (types.PosterrPage, error) {
+	offset := page.Offset
+	after := func(post memory.Post) bool { return true }
+	if len(page.Cursor) > 0 {
+		c, err := decodeKeysetCursor(page.Cursor)
+		if err != nil {
+			return types.PosterrPage{}, err
+		}
+
+		offset = 0
+		after = func(post memory.Post) bool { return c.isAfter(post.CreatedAt, post.ID) }
+	}
+
+	viewer, _ := types.UserFromContext(ctx)
+	posts := make([]types.PosterrContent, 0)
+	for i := len(pm.store.Posts) - 1; i >= 0 && len(posts) < limit; i-- {
+		post := pm.store.Posts[i]
+		if !filter(post) || !after(post) {
+			continue
+		}
+
+		if offset > 0 {
+			offset--
+			continue
+		}
+
+		posts = append(posts, pm.toHydratedContent(viewer, post))
+	}
+	pm.setLikes(ctx, posts)
+
+	return newPage(posts, limit), nil
+}
+
+// searchPostsByRelevance returns a page of the posts matching query from the most
+// to the least relevant, skipping the offset of the page or of its cursor
+func (pm *posterrMemory) searchPostsByRelevance(ctx context.Context, query types.SearchQuery, viewer string, limit int, page types.Page) (types.PosterrPage, error) {
+	offset, err := pageOffset(page)
+	if err != nil {
+		return types.PosterrPage{}, err
+	}
+
+	type rankedPost struct {
+		post memory.Post
+		rank int
+	}
+
+	matches := make([]rankedPost, 0)
+	for i := len(pm.store.Posts) - 1; i >= 0; i-- {
+		post := pm.store.Posts[i]
+		if rank, ok := pm.matchPost(query, viewer, post); ok {
+			matches = append(matches, rankedPost{post, rank})
+		}
+	}
+
+	// ties are kept from the newest to the oldest
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].rank > matches[j].rank
+	})
+
+	posts := make([]types.PosterrContent, 0)
+	for i := offset; i < len(matches) && len(posts) < limit; i++ {
+		posts = append(posts, pm.toHydratedContent(viewer, matches[i].post))
+	}
+	pm.setLikes(ctx, posts)
+
+	return newOffsetPage(posts, limit, offset), nil
+}
+
+// matchPost checks if a post matches query, either by its content or by the content
+// of the post it reposts, and returns its rank. Matches in the reposted post are worth half.
+// Posts of the users viewer muted, blocked or can't see are not matched, nor by the posts reposting them
+func (pm *posterrMemory) matchPost(query types.SearchQuery, viewer string, post memory.Post) (int, bool) {
+	if post.Deleted || pm.isHidden(viewer, post.Username) || !pm.store.CanSee(viewer, post.Username) {
+		return 0, false
+	}
+
+	if len(query.From) > 0 && post.Username != query.From {
+		return 0, false
+	}
+
+	if (!query.Since.IsZero() && post.CreatedAt.Before(query.Since)) ||
+		(!query.Until.IsZero() && !post.CreatedAt.Before(query.Until)) {
+		return 0, false
+	}
+
+	var rank int
+	var matches bool
+	if len(post.Content) > 0 {
+		if ownRank, ok := matchContent(query, post.Content); ok {
+			rank, matches = 2*ownRank, true
+		}
+	}
+
+	reposted, exists := pm.store.GetLivePost(post.RepostedId)
+	if exists && hasSearchText(query) && len(reposted.Content) > 0 && pm.store.CanSee(viewer, reposted.Username) {
+		if repostedRank, ok := matchContent(query, reposted.Content); ok {
+			rank, matches = rank+repostedRank, true
+		}
+	}
+
+	return rank, matches
+}
+
+// toHydratedContent returns a post with its reposted posts embedded, up to maxRepostDepth.
+// Reposted posts of the private users viewer doesn't follow are embedded as tombstones
+func (pm *posterrMemory) toHydratedContent(viewer string, post memory.Post) types.PosterrContent {
+	content := toPosterrContent(post)
+	content.Reposted = pm.embedReposted(viewer, post.RepostedId, maxRepostDepth)
+	return content
+}
+
+func (pm *posterrMemory) embedReposted(viewer, repostedId string, depth int) *types.PosterrContent {
+	if len(repostedId) == 0 || depth == 0 {
+		return nil
+	}
+
+	reposted, _ := pm.store.GetPost(repostedId)
+	if reposted.Deleted || !pm.store.CanSee(viewer, reposted.Username) {
+		return tombstone(reposted.ID)
+	}
+
+	content := toPosterrContent(reposted)
+	content.Reposted = pm.embedReposted(viewer, reposted.RepostedId, depth-1)
+	return &content
+}
+
+// setLikes sets the likes of posts and of their embedded reposted posts,
+// flagging the ones liked by the user of ctx, if any.
+func (pm *posterrMemory) setLikes(ctx context.Context, posts []types.PosterrContent) {
+	viewer, _ := types.UserFromContext(ctx)
+	for postId, targets := range likeTargets(posts) {
+		likes := pm.store.Likes[postId]
+		_, likedByMe := likes[viewer]
+		for _, post := range targets {
+			post.Likes, post.LikedByMe = len(likes), likedByMe
+		}
+	}
+}
+
+func toPosterrContent(post memory.Post) types.PosterrContent {
+	return types.PosterrContent{
+		ID:         post.ID,
+		Username:   post.Username,
+		Content:    post.Content,
+		RepostedId: post.RepostedId,
+		ReplyId:    post.ReplyId,
+		CreatedAt:  post.CreatedAt,
+		EditedAt:   editedAt(post),
+		Deleted:    post.Deleted,
+	}
+}
+
+// editedAt returns when the content of post was last edited, nil if it never was
+func editedAt(post memory.Post) *time.Time {
+	if post.EditedAt.IsZero() {
+		return nil
+	}
+
+	editedAt := post.EditedAt
+	return &editedAt
+}
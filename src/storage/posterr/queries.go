@@ -1,38 +1,377 @@
 package posterr
 
 const (
-	selectAllPosts = `SELECT post_id, username, COALESCE(content, ''), COALESCE(reposted_id, ''), created_at
-                 FROM posts
-                 ORDER BY created_at DESC
+	// selectHydratedPosts embeds the reposted post and the post it reposts, if any,
+	// so that reposts of quote reposts can be rendered without extra queries
+	selectHydratedPosts = `SELECT ` + hydratedColumns + hydratedJoins
+
+	hydratedColumns = `p.post_id, p.username, COALESCE(p.content, ''), COALESCE(p.reposted_id, ''), COALESCE(p.reply_id, ''), p.created_at, p.edited_at,
+                     COALESCE(r1.post_id, ''), COALESCE(r1.username, ''), COALESCE(r1.content, ''), COALESCE(r1.reposted_id, ''), COALESCE(r1.reply_id, ''), r1.created_at, r1.edited_at, r1.deleted_at IS NOT NULL,
+                     COALESCE(r2.post_id, ''), COALESCE(r2.username, ''), COALESCE(r2.content, ''), COALESCE(r2.reposted_id, ''), COALESCE(r2.reply_id, ''), r2.created_at, r2.edited_at, r2.deleted_at IS NOT NULL`
+
+	hydratedJoins = `
+                 FROM posts p
+                 LEFT JOIN posts r1 ON r1.post_id = p.reposted_id
+                 LEFT JOIN posts r2 ON r2.post_id = r1.reposted_id`
+
+	// keyset pagination: $n is the created_at and $n+1 the post_id
+	// of the cursor, or both are NULL to start from the newest post
+	// the home page and searches of a viewer, $1 and $5 respectively,
+	// hide the posts of the users the viewer muted or blocked,
+	// as well as of the private users the viewer does not follow
+	selectAllPosts = selectHydratedPosts + `
+                 WHERE p.deleted_at IS NULL
+                 AND p.username NOT IN (
+                     SELECT blocked FROM blocks WHERE username = $1
+                     UNION
+                     SELECT muted FROM mutes WHERE username = $1)
+                 AND p.username NOT IN (
+                     SELECT u.username FROM users u
+                     WHERE u.private
+                     AND u.username <> $1
+                     AND NOT EXISTS (
+                         SELECT 1 FROM followers f
+                         WHERE f.username = u.username
+                         AND f.followed_by = $1))
+                 AND ($3::timestamptz IS NULL OR (p.created_at, p.post_id) < ($3, $4))
+                 ORDER BY p.created_at DESC, p.post_id DESC
                  LIMIT 10
-                 OFFSET $1`
+                 OFFSET $2`
 
-	selectFollowingPosts = `SELECT post_id, username, COALESCE(content, ''), COALESCE(reposted_id, ''), created_at
-                 FROM posts
-                 WHERE username IN (
+	selectFollowingPosts = selectHydratedPosts + `
+                 WHERE p.username IN (
                      SELECT username
                      FROM followers
                      WHERE followed_by = $1)
-                 ORDER BY created_at DESC
+                 AND p.username NOT IN (
+                     SELECT blocked FROM blocks WHERE username = $1
+                     UNION
+                     SELECT muted FROM mutes WHERE username = $1)
+                 AND p.deleted_at IS NULL
+                 AND ($3::timestamptz IS NULL OR (p.created_at, p.post_id) < ($3, $4))
+                 ORDER BY p.created_at DESC, p.post_id DESC
                  LIMIT 10
                  OFFSET $2`
 
-	selectProfilePosts = `SELECT post_id, username, COALESCE(content, ''), COALESCE(reposted_id, ''), created_at
-                 FROM posts
-                 WHERE username = $1
-                 ORDER BY created_at DESC
+	// private users are only seen by themselves and their followers, the viewer being $5
+	selectProfilePosts = selectHydratedPosts + `
+                 WHERE p.username = $1
+                 AND p.deleted_at IS NULL
+                 AND p.username NOT IN (
+                     SELECT u.username FROM users u
+                     WHERE u.private
+                     AND u.username <> $5
+                     AND NOT EXISTS (
+                         SELECT 1 FROM followers f
+                         WHERE f.username = u.username
+                         AND f.followed_by = $5))
+                 AND ($3::timestamptz IS NULL OR (p.created_at, p.post_id) < ($3, $4))
+                 ORDER BY p.created_at DESC, p.post_id DESC
                  LIMIT 5
                  OFFSET $2`
 
+	// bookmarks are sorted by when they were made, which is scanned after the hydrated columns.
+	// Posts of private users are hidden unless the owner of the bookmarks, $1, follows them
+	selectBookmarkedPosts = `SELECT ` + hydratedColumns + `, b.created_at` + hydratedJoins + `
+                 JOIN bookmarks b ON b.post_id = p.post_id
+                 WHERE b.username = $1
+                 AND p.deleted_at IS NULL
+                 AND p.username NOT IN (
+                     SELECT u.username FROM users u
+                     WHERE u.private
+                     AND u.username <> $1
+                     AND NOT EXISTS (
+                         SELECT 1 FROM followers f
+                         WHERE f.username = u.username
+                         AND f.followed_by = $1))
+                 AND ($3::timestamptz IS NULL OR (b.created_at, p.post_id) < ($3, $4))
+                 ORDER BY b.created_at DESC, p.post_id DESC
+                 LIMIT 10
+                 OFFSET $2`
+
+	// posts of private users are only seen by themselves and their followers, the viewer being $2
+	selectPost = selectHydratedPosts + `
+                 WHERE p.post_id = $1
+                 AND p.deleted_at IS NULL
+                 AND p.username NOT IN (
+                     SELECT u.username FROM users u
+                     WHERE u.private
+                     AND u.username <> $2
+                     AND NOT EXISTS (
+                         SELECT 1 FROM followers f
+                         WHERE f.username = u.username
+                         AND f.followed_by = $2))`
+
+	// as with the home page, the posts of the users the viewer, $5, muted or blocked are hidden,
+	// as well as of the private users the viewer does not follow
+	selectHashtagPosts = selectHydratedPosts + `
+                 JOIN post_hashtags h ON h.post_id = p.post_id
+                 WHERE h.hashtag = $1
+                 AND p.deleted_at IS NULL
+                 AND p.username NOT IN (
+                     SELECT blocked FROM blocks WHERE username = $5
+                     UNION
+                     SELECT muted FROM mutes WHERE username = $5)
+                 AND p.username NOT IN (
+                     SELECT u.username FROM users u
+                     WHERE u.private
+                     AND u.username <> $5
+                     AND NOT EXISTS (
+                         SELECT 1 FROM followers f
+                         WHERE f.username = u.username
+                         AND f.followed_by = $5))
+                 AND ($3::timestamptz IS NULL OR (p.created_at, p.post_id) < ($3, $4))
+                 ORDER BY p.created_at DESC, p.post_id DESC
+                 LIMIT 10
+                 OFFSET $2`
+
+	// the hashtags inherit the creation date of the post, so that
+	// trending hashtags can be computed without joining posts by date
+	insertPostHashtags = `INSERT INTO post_hashtags (post_id, hashtag, created_at)
+                 SELECT post_id, UNNEST($2::varchar[]), created_at
+                 FROM posts
+                 WHERE post_id = $1`
+
+	// ties are broken by the most recently used hashtag
+	selectTrendingHashtags = `SELECT h.hashtag, COUNT(*) AS uses
+                 FROM post_hashtags h
+                 JOIN posts p ON p.post_id = h.post_id
+                 WHERE h.created_at >= $1
+                 AND p.deleted_at IS NULL
+                 GROUP BY h.hashtag
+                 ORDER BY uses DESC, MAX(h.created_at) DESC, h.hashtag
+                 LIMIT $2`
+
+	// the viewer is $5
+	selectMentionPosts = selectHydratedPosts + `
+                 JOIN post_mentions m ON m.post_id = p.post_id
+                 WHERE m.username = $1
+                 AND p.deleted_at IS NULL
+                 AND p.username NOT IN (
+                     SELECT blocked FROM blocks WHERE username = $5
+                     UNION
+                     SELECT muted FROM mutes WHERE username = $5)
+                 AND p.username NOT IN (
+                     SELECT u.username FROM users u
+                     WHERE u.private
+                     AND u.username <> $5
+                     AND NOT EXISTS (
+                         SELECT 1 FROM followers f
+                         WHERE f.username = u.username
+                         AND f.followed_by = $5))
+                 AND ($3::timestamptz IS NULL OR (p.created_at, p.post_id) < ($3, $4))
+                 ORDER BY p.created_at DESC, p.post_id DESC
+                 LIMIT 10
+                 OFFSET $2`
+
+	// mentions of usernames which are not registered are ignored
+	insertPostMentions = `INSERT INTO post_mentions (post_id, username, created_at)
+                 SELECT p.post_id, u.username, p.created_at
+                 FROM posts p
+                 JOIN users u ON u.username = ANY($2::varchar[])
+                 WHERE p.post_id = $1`
+
+	// users are not notified of mentioning themselves, nor twice of the same post
+	insertMentionNotifications = `INSERT INTO notifications (username, kind, actor, post_id, created_at)
+                 SELECT m.username, 'mention', p.username, p.post_id, p.created_at
+                 FROM post_mentions m
+                 JOIN posts p ON p.post_id = m.post_id
+                 WHERE m.post_id = $1
+                 AND m.username <> p.username
+                 AND NOT EXISTS (
+                     SELECT 1
+                     FROM notifications n
+                     WHERE n.post_id = p.post_id
+                     AND n.username = m.username)`
+
+	// notifies the author of the post referenced by $3, which is reposted,
+	// quoted or replied by the post $1, unless both have the same author
+	insertReferenceNotification = `INSERT INTO notifications (username, kind, actor, post_id, created_at)
+                 SELECT r.username, $2::varchar, p.username, p.post_id, p.created_at
+                 FROM posts p
+                 JOIN posts r ON r.post_id = $3
+                 WHERE p.post_id = $1
+                 AND r.username <> p.username`
+
+	// $2 is the username whose likes are flagged
+	selectPostLikes = `SELECT post_id, COUNT(*), COALESCE(BOOL_OR(username = $2), false)
+                 FROM post_likes
+                 WHERE post_id = ANY($1::varchar[])
+                 GROUP BY post_id`
+
+	selectPostLikers = `SELECT username
+                 FROM post_likes
+                 WHERE post_id = $1
+                 ORDER BY created_at DESC, username`
+
+	// authors are not notified of liking their own posts,
+	// nor again when a post is liked after being unliked
+	insertLikeNotification = `INSERT INTO notifications (username, kind, actor, post_id)
+                 SELECT p.username, $3::varchar, $2, p.post_id
+                 FROM posts p
+                 WHERE p.post_id = $1
+                 AND p.username <> $2
+                 AND NOT EXISTS (
+                     SELECT 1
+                     FROM notifications n
+                     WHERE n.post_id = p.post_id
+                     AND n.actor = $2
+                     AND n.kind = $3)`
+
 	countDailyPosts = `SELECT COUNT(*) as daily_posts
                  FROM posts
                  WHERE username = $1
                  AND date_trunc('day', created_at) = date_trunc('day', NOW())`
 
-	searchPosts = `SELECT post_id, username, COALESCE(content, ''), COALESCE(reposted_id, ''), created_at
+	// $1 is a websearch_to_tsquery query, $2 a username, $3 and $4 the
+	// since and until dates, $5 the limit and $6 the offset of the page
+	searchPostsFilter = `
+                 WHERE p.deleted_at IS NULL
+                 AND ((p.content IS NOT NULL AND ($1 = '' OR p.search_vector @@ websearch_to_tsquery('english', $1)))
+                     OR ($1 <> '' AND r1.content IS NOT NULL AND r1.search_vector @@ websearch_to_tsquery('english', $1)
+                         AND r1.username NOT IN (
+                             SELECT u.username FROM users u
+                             WHERE u.private
+                             AND u.username <> $5
+                             AND NOT EXISTS (
+                                 SELECT 1 FROM followers f
+                                 WHERE f.username = u.username
+                                 AND f.followed_by = $5))))
+                 AND ($2 = '' OR p.username = $2)
+                 AND ($3::timestamptz IS NULL OR p.created_at >= $3)
+                 AND ($4::timestamptz IS NULL OR p.created_at < $4)
+                 AND p.username NOT IN (
+                     SELECT blocked FROM blocks WHERE username = $5
+                     UNION
+                     SELECT muted FROM mutes WHERE username = $5)
+                 AND p.username NOT IN (
+                     SELECT u.username FROM users u
+                     WHERE u.private
+                     AND u.username <> $5
+                     AND NOT EXISTS (
+                         SELECT 1 FROM followers f
+                         WHERE f.username = u.username
+                         AND f.followed_by = $5))`
+
+	searchPostsByRecency = selectHydratedPosts + searchPostsFilter + `
+                 AND ($8::timestamptz IS NULL OR (p.created_at, p.post_id) < ($8, $9))
+                 ORDER BY p.created_at DESC, p.post_id DESC
+                 LIMIT $6
+                 OFFSET $7`
+
+	// matches in the content of the reposted post are worth half
+	searchPostsByRelevance = selectHydratedPosts + searchPostsFilter + `
+                 ORDER BY ts_rank(p.search_vector, websearch_to_tsquery('english', $1))
+                     + ts_rank(COALESCE(r1.search_vector, ''::tsvector), websearch_to_tsquery('english', $1)) / 2 DESC,
+                     p.created_at DESC, p.post_id DESC
+                 LIMIT $6
+                 OFFSET $7`
+
+	// deleted posts are counted, unlike posts of the private users the viewer, $2, does not follow
+	countVisiblePostId = `SELECT COUNT(*) as post_exists
+                 FROM posts p
+                 WHERE p.post_id = $1
+                 AND p.username NOT IN (
+                     SELECT u.username FROM users u
+                     WHERE u.private
+                     AND u.username <> $2
+                     AND NOT EXISTS (
+                         SELECT 1 FROM followers f
+                         WHERE f.username = u.username
+                         AND f.followed_by = $2))`
+
+	// the private users among $1 the viewer, $2, does not follow, whose embedded posts are hidden
+	selectHiddenUsers = `SELECT u.username
+                 FROM users u
+                 WHERE u.username = ANY($1::varchar[])
+                 AND u.private
+                 AND u.username <> $2
+                 AND NOT EXISTS (
+                     SELECT 1 FROM followers f
+                     WHERE f.username = u.username
+                     AND f.followed_by = $2)`
+
+	countLivePostId = `SELECT COUNT(*) as post_exists
+                 FROM posts
+                 WHERE post_id = $1
+                 AND deleted_at IS NULL`
+
+	countAuthorBlocks = `SELECT COUNT(*) as blocks
+                 FROM blocks b
+                 JOIN posts p ON p.username = b.username
+                 WHERE p.post_id = $1
+                 AND b.blocked = $2`
+
+	selectPostAuthor = `SELECT username
                  FROM posts
-                 WHERE content IS NOT NULL AND content LIKE '%' || $1 || '%'
-                 ORDER BY created_at DESC
-                 LIMIT $2
-                 OFFSET $3`
+                 WHERE post_id = $1
+                 AND deleted_at IS NULL`
+
+	// content is cleared, but the row is kept so that reposts and
+	// replies still reference it and the daily posts count is unaffected
+	deletePost = `UPDATE posts
+                 SET content = NULL, deleted_at = NOW()
+                 WHERE post_id = $1
+                 AND username = $2
+                 AND deleted_at IS NULL`
+
+	// the post is locked, so that concurrent edits keep every revision
+	selectEditablePost = `SELECT username, content, COALESCE(edited_at, created_at), created_at
+                 FROM posts
+                 WHERE post_id = $1
+                 AND deleted_at IS NULL
+                 FOR UPDATE`
+
+	// the replaced content keeps the date it was written, either by creating or by editing the post
+	insertPostRevision = `INSERT INTO post_revisions (post_id, content, created_at)
+                 VALUES ($1, $2, $3)`
+
+	editPost = `UPDATE posts
+                 SET content = $2, edited_at = NOW()
+                 WHERE post_id = $1`
+
+	deletePostHashtags = `DELETE FROM post_hashtags
+                 WHERE post_id = $1`
+
+	deletePostMentions = `DELETE FROM post_mentions
+                 WHERE post_id = $1`
+
+	deletePostRevisions = `DELETE FROM post_revisions
+                 WHERE post_id = $1`
+
+	selectPostRevisions = `SELECT content, created_at, replaced_at
+                 FROM post_revisions
+                 WHERE post_id = $1
+                 ORDER BY revision_id`
+
+	// replies of the users the viewer, $2, muted or blocked, or of the private users
+	// they don't follow, are hidden, which leaves the replies to them out of the tree as well
+	selectReplies = `WITH RECURSIVE replies AS (
+                     SELECT post_id, username, content, reposted_id, reply_id, created_at, edited_at, deleted_at
+                     FROM posts
+                     WHERE reply_id = $1
+                     UNION ALL
+                     SELECT p.post_id, p.username, p.content, p.reposted_id, p.reply_id, p.created_at, p.edited_at, p.deleted_at
+                     FROM posts p
+                     INNER JOIN replies r ON p.reply_id = r.post_id)
+                 SELECT post_id, username, COALESCE(content, ''), COALESCE(reposted_id, ''), COALESCE(reply_id, ''), created_at, edited_at, deleted_at IS NOT NULL
+                 FROM replies
+                 WHERE username NOT IN (
+                     SELECT blocked FROM blocks WHERE username = $2
+                     UNION
+                     SELECT muted FROM mutes WHERE username = $2)
+                 AND username NOT IN (
+                     SELECT u.username FROM users u
+                     WHERE u.private
+                     AND u.username <> $2
+                     AND NOT EXISTS (
+                         SELECT 1 FROM followers f
+                         WHERE f.username = u.username
+                         AND f.followed_by = $2))`
+
+	selectRepliesAscending = selectReplies + `
+                 ORDER BY created_at ASC`
+
+	selectRepliesDescending = selectReplies + `
+                 ORDER BY created_at DESC`
 )
@@ -0,0 +1,27 @@
+package posterr
+
+import "posterr/src/types"
+
+// buildReplyTree nests a flat list of replies under their parents,
+// starting from rootId. The relative order of the given replies is
+// preserved among siblings.
+func buildReplyTree(rootId string, replies []types.PosterrContent) []types.PosterrReply {
+	children := make(map[string][]types.PosterrContent)
+	for _, reply := range replies {
+		children[reply.ReplyId] = append(children[reply.ReplyId], reply)
+	}
+
+	var build func(parentId string) []types.PosterrReply
+	build = func(parentId string) []types.PosterrReply {
+		tree := make([]types.PosterrReply, 0, len(children[parentId]))
+		for _, reply := range children[parentId] {
+			tree = append(tree, types.PosterrReply{
+				PosterrContent: reply,
+				Replies:        build(reply.ID),
+			})
+		}
+		return tree
+	}
+
+	return build(rootId)
+}
@@ -0,0 +1,90 @@
+package posterr
+
+import (
+	"time"
+
+	"posterr/src/types"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// maxRepostDepth is how many reposted posts are embedded into a post, e.g.,
+// a repost of a quote repost embeds both the quote repost and the original post
+const maxRepostDepth = 2
+
+// repostedColumns holds the columns of a post embedded by selectHydratedPosts
+type repostedColumns struct {
+	id         string
+	username   string
+	content    string
+	repostedId string
+	replyId    string
+	createdAt  *time.Time
+	editedAt   *time.Time
+	deleted    bool
+}
+
+// scanHydratedPost scans a row of selectHydratedPosts into a post with its reposted posts embedded.
+// The columns selected after the hydrated ones, if any, are scanned into extra
+func scanHydratedPost(row pgx.Row, extra ...interface{}) (types.PosterrContent, error) {
+	post := types.PosterrContent{}
+	reposted := [maxRepostDepth]repostedColumns{}
+	dest := []interface{}{&post.ID, &post.Username, &post.Content, &post.RepostedId, &post.ReplyId, &post.CreatedAt, &post.EditedAt,
+		&reposted[0].id, &reposted[0].username, &reposted[0].content, &reposted[0].repostedId, &reposted[0].replyId, &reposted[0].createdAt, &reposted[0].editedAt, &reposted[0].deleted,
+		&reposted[1].id, &reposted[1].username, &reposted[1].content, &reposted[1].repostedId, &reposted[1].replyId, &reposted[1].createdAt, &reposted[1].editedAt, &reposted[1].deleted}
+	err := row.Scan(append(dest, extra...)...)
+	if err != nil {
+		return types.PosterrContent{}, err
+	}
+
+	// embeds from the deepest reposted post up to the scanned one
+	var embedded *types.PosterrContent
+	for depth := maxRepostDepth - 1; depth >= 0; depth-- {
+		columns := reposted[depth]
+		if len(columns.id) == 0 {
+			continue
+		}
+
+		if columns.deleted {
+			embedded = tombstone(columns.id)
+			continue
+		}
+
+		embedded = &types.PosterrContent{
+			ID:         columns.id,
+			Username:   columns.username,
+			Content:    columns.content,
+			RepostedId: columns.repostedId,
+			ReplyId:    columns.replyId,
+			CreatedAt:  *columns.createdAt,
+			EditedAt:   columns.editedAt,
+			Reposted:   embedded,
+		}
+	}
+	post.Reposted = embedded
+
+	return post, nil
+}
+
+// repostedTargets returns the embedded reposted posts of posts by their author,
+// which are replaced by tombstones if the viewer can't see their author
+func repostedTargets(posts []types.PosterrContent) map[string][]*types.PosterrContent {
+	targets := make(map[string][]*types.PosterrContent)
+	for i := range posts {
+		for post := posts[i].Reposted; post != nil; post = post.Reposted {
+			if !post.Deleted {
+				targets[post.Username] = append(targets[post.Username], post)
+			}
+		}
+	}
+	return targets
+}
+
+// tombstone returns the embedded representation of a deleted or hidden post,
+// which only tells that the original post is no longer available
+func tombstone(postId string) *types.PosterrContent {
+	return &types.PosterrContent{
+		ID:      postId,
+		Deleted: true,
+	}
+}
@@ -0,0 +1,111 @@
+package posterr
+
+import (
+	"strings"
+	"time"
+	"unicode"
+
+	"posterr/src/types"
+)
+
+// webSearchText renders a query in the syntax of websearch_to_tsquery.
+// Terms are quoted as well, so that they are never read as operators
+func webSearchText(query types.SearchQuery) string {
+	quote := func(value string) string {
+		return `"` + strings.ReplaceAll(value, `"`, "") + `"`
+	}
+
+	parts := make([]string, 0, len(query.Terms)+len(query.Phrases)+len(query.Exclude))
+	for _, term := range query.Terms {
+		parts = append(parts, quote(term))
+	}
+	for _, phrase := range query.Phrases {
+		parts = append(parts, quote(phrase))
+	}
+	for _, excluded := range query.Exclude {
+		parts = append(parts, "-"+quote(excluded))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// searchLimit returns how many posts a search returns given limit,
+// which is capped to maxSearchLimit and defaults to defaultSearchLimit
+func searchLimit(limit int) int {
+	if limit <= 0 {
+		return defaultSearchLimit
+	} else if limit > maxSearchLimit {
+		return maxSearchLimit
+	}
+	return limit
+}
+
+// searchArgs returns the text, username, since and until arguments of a search query,
+// followed by the viewer whose muted and blocked users are not matched
+func searchArgs(query types.SearchQuery, viewer string) []interface{} {
+	optionalTime := func(t time.Time) interface{} {
+		if t.IsZero() {
+			return nil
+		}
+		return t
+	}
+
+	return []interface{}{webSearchText(query), query.From, optionalTime(query.Since), optionalTime(query.Until), viewer}
+}
+
+// searchWords splits a text into lowercase words, roughly as the Postgres parser does
+func searchWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// countPhrase returns how many times the words of phrase appear in sequence within words
+func countPhrase(words []string, phrase string) int {
+	phraseWords := searchWords(phrase)
+	if len(phraseWords) == 0 {
+		return 0
+	}
+
+	var count int
+	for i := 0; i+len(phraseWords) <= len(words); i++ {
+		matches := true
+		for j, word := range phraseWords {
+			if words[i+j] != word {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			count++
+		}
+	}
+
+	return count
+}
+
+// matchContent checks if content matches the text of a query, and returns how relevant it is
+func matchContent(query types.SearchQuery, content string) (int, bool) {
+	words := searchWords(content)
+	for _, excluded := range query.Exclude {
+		if countPhrase(words, excluded) > 0 {
+			return 0, false
+		}
+	}
+
+	var rank int
+	for _, phrase := range append(append([]string{}, query.Terms...), query.Phrases...) {
+		count := countPhrase(words, phrase)
+		if count == 0 {
+			return 0, false
+		}
+		rank += count
+	}
+
+	return rank, true
+}
+
+// hasSearchText checks if a query has terms, phrases or excluded terms
+func hasSearchText(query types.SearchQuery) bool {
+	return len(query.Terms) > 0 || len(query.Phrases) > 0 || len(query.Exclude) > 0
+}
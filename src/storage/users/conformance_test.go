@@ -0,0 +1,15 @@
+package users_test
+
+import (
+	"testing"
+
+	"posterr/src/test/conformance"
+)
+
+func TestMemoryConformance(t *testing.T) {
+	conformance.RunUsers(t, conformance.MemoryFactory)
+}
+
+func TestPostgresConformance(t *testing.T) {
+	conformance.RunUsers(t, conformance.PostgresFactory)
+}
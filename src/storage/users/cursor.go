@@ -0,0 +1,92 @@
+package users
+
+import (
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"posterr/src/types"
+)
+
+/*
+	Cursors are encoded as:  base64url({followed_at}|{username})
+	Follows are sorted by (followed_at, username) from the newest to the oldest,
+	so the next page starts right after the last user of the previous one.
+	Follow requests are sorted and encoded the same way, by the date they were made.
+*/
+
+const (
+	cursorSeparator = "|"
+	followPageLimit = 20
+)
+
+type cursor struct {
+	followedAt time.Time
+	username   string
+}
+
+// encodeCursor returns the cursor pointing right after the follow of username at followedAt
+func encodeCursor(followedAt time.Time, username string) string {
+	value := followedAt.Format(time.RFC3339Nano) + cursorSeparator + username
+	return base64.RawURLEncoding.EncodeToString([]byte(value))
+}
+
+// decodeCursor parses a cursor returned by encodeCursor
+func decodeCursor(encoded string) (cursor, error) {
+	value, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return cursor{}, InvalidCursorError{encoded}
+	}
+
+	parts := strings.SplitN(string(value), cursorSeparator, 2)
+	if len(parts) != 2 || len(parts[1]) == 0 {
+		return cursor{}, InvalidCursorError{encoded}
+	}
+
+	followedAt, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return cursor{}, InvalidCursorError{encoded}
+	}
+
+	return cursor{followedAt: followedAt, username: parts[1]}, nil
+}
+
+// isAfter checks if a follow comes after the cursor, i.e., it is older
+func (c cursor) isAfter(followedAt time.Time, username string) bool {
+	return followedAt.Before(c.followedAt) || (followedAt.Equal(c.followedAt) && username < c.username)
+}
+
+// pageArgs returns the offset, followed_at and username arguments of a paginated query.
+// The offset is ignored when a cursor is given
+func pageArgs(page types.Page) ([]interface{}, error) {
+	if len(page.Cursor) == 0 {
+		return []interface{}{page.Offset, nil, nil}, nil
+	}
+
+	c, err := decodeCursor(page.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	return []interface{}{0, c.followedAt, c.username}, nil
+}
+
+// newPage returns a page of follows, which has a next cursor only if it is full
+func newPage(follows []types.PosterrFollow) types.PosterrFollowPage {
+	page := types.PosterrFollowPage{Users: follows}
+	if len(follows) > 0 && len(follows) == followPageLimit {
+		last := follows[len(follows)-1]
+		page.NextCursor = encodeCursor(last.FollowedAt, last.Username)
+	}
+	return page
+}
+
+// newRequestPage returns a page of follow requests, which has a next cursor only if it is full
+func newRequestPage(requests []types.PosterrFollowRequest) types.PosterrFollowRequestPage {
+	page := types.PosterrFollowRequestPage{Requests: requests}
+	if len(requests) > 0 && len(requests) == followPageLimit {
+		last := requests[len(requests)-1]
+		page.NextCursor = encodeCursor(last.RequestedAt, last.Username)
+	}
+	return page
+}
@@ -1,16 +1,53 @@
 package users
 
 import (
-	"strings"
+	"errors"
+
+	"posterr/src/storage/pgerrors"
+
+	"github.com/jackc/pgx/v4"
 )
 
-func getErrorFromString(err error, username string) error {
-	if strings.Contains(err.Error(), valueTooLongErrorCode) {
-		return UsernameExceededMaximumCharsError{username}
-	} else if strings.Contains(err.Error(), duplicatedKeyErrorCode) {
-		return UserAlreadyExistsError{}
-	} else if strings.Contains(err.Error(), noRowsInResult) {
-		return UserDoesNotExistError{username}
+// translateError returns the storage error a Postgres error stands for
+func translateError(err error, username string) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return pgerrors.Wrap(UserDoesNotExistError{username}, err)
 	}
-	return err
+
+	return pgerrors.Translate(err,
+		pgerrors.Rule{Code: pgerrors.StringDataRightTruncation, Err: UsernameExceededMaximumCharsError{username}},
+		pgerrors.Rule{Code: pgerrors.UniqueViolation, Constraint: "users_pkey", Err: UserAlreadyExistsError{username}},
+	)
+}
+
+// translateFollowError returns the storage error a Postgres error
+// raised while username is followed or unfollowed by follower stands for
+func translateFollowError(err error, username, follower string) error {
+	return pgerrors.Translate(err,
+		pgerrors.Rule{Code: pgerrors.ForeignKeyViolation, Constraint: "followers_username_fkey", Err: UserDoesNotExistError{username}},
+		pgerrors.Rule{Code: pgerrors.ForeignKeyViolation, Constraint: "followers_followed_by_fkey", Err: UserDoesNotExistError{follower}},
+		pgerrors.Rule{Code: pgerrors.ForeignKeyViolation, Constraint: "follow_requests_requested_by_fkey", Err: UserDoesNotExistError{follower}},
+		pgerrors.Rule{Code: pgerrors.UniqueViolation, Constraint: "followers_pkey", Err: UserAlreadyFollowsError{username, follower}},
+		pgerrors.Rule{Code: pgerrors.UniqueViolation, Constraint: "follow_requests_pkey", Err: FollowRequestAlreadyExistsError{username, follower}},
+	)
+}
+
+// translateBlockError returns the storage error a Postgres error
+// raised while username is blocked by blocker stands for
+func translateBlockError(err error, username, blocker string) error {
+	return pgerrors.Translate(err,
+		pgerrors.Rule{Code: pgerrors.ForeignKeyViolation, Constraint: "blocks_blocked_fkey", Err: UserDoesNotExistError{username}},
+		pgerrors.Rule{Code: pgerrors.ForeignKeyViolation, Constraint: "blocks_username_fkey", Err: UserDoesNotExistError{blocker}},
+		pgerrors.Rule{Code: pgerrors.UniqueViolation, Constraint: "blocks_pkey", Err: UserAlreadyBlockedError{username, blocker}},
+	)
+}
+
+// translateMuteError returns the storage error a Postgres error
+// raised while username is muted by muter stands for
+func translateMuteError(err error, username, muter string) error {
+	return pgerrors.Translate(err,
+		pgerrors.Rule{Code: pgerrors.ForeignKeyViolation, Constraint: "mutes_muted_fkey", Err: UserDoesNotExistError{username}},
+		pgerrors.Rule{Code: pgerrors.ForeignKeyViolation, Constraint: "mutes_username_fkey", Err: UserDoesNotExistError{muter}},
+		pgerrors.Rule{Code: pgerrors.UniqueViolation, Constraint: "mutes_pkey", Err: UserAlreadyMutedError{username, muter}},
+	)
 }
@@ -1,11 +1,8 @@
 package users
 
-import "fmt"
-
-const (
-	valueTooLongErrorCode  = "SQLSTATE 22001"
-	duplicatedKeyErrorCode = "SQLSTATE 23505"
-	noRowsInResult         = "no rows in result set"
+import (
+	"fmt"
+	"strconv"
 )
 
 type InvalidUsernameError struct {
@@ -16,6 +13,16 @@ func (e InvalidUsernameError) Error() string {
 	return fmt.Sprintf("invalid username %s: username must consist of alphanumeric charactes only", e.username)
 }
 
+func (e InvalidUsernameError) Code() string {
+	return "invalid_username"
+}
+
+func (e InvalidUsernameError) Details() map[string]string {
+	return map[string]string{
+		"username": e.username,
+	}
+}
+
 type UsernameExceededMaximumCharsError struct {
 	username string
 }
@@ -24,6 +31,16 @@ func (e UsernameExceededMaximumCharsError) Error() string {
 	return fmt.Sprintf("username %s exceeded maximum allowed chars", e.username)
 }
 
+func (e UsernameExceededMaximumCharsError) Code() string {
+	return "username_too_long"
+}
+
+func (e UsernameExceededMaximumCharsError) Details() map[string]string {
+	return map[string]string{
+		"username": e.username,
+	}
+}
+
 type UserAlreadyExistsError struct {
 	username string
 }
@@ -32,6 +49,16 @@ func (e UserAlreadyExistsError) Error() string {
 	return fmt.Sprintf("username %s already exists", e.username)
 }
 
+func (e UserAlreadyExistsError) Code() string {
+	return "user_already_exists"
+}
+
+func (e UserAlreadyExistsError) Details() map[string]string {
+	return map[string]string{
+		"username": e.username,
+	}
+}
+
 type UserDoesNotExistError struct {
 	username string
 }
@@ -40,6 +67,16 @@ func (e UserDoesNotExistError) Error() string {
 	return fmt.Sprintf("username %s is not registered", e.username)
 }
 
+func (e UserDoesNotExistError) Code() string {
+	return "user_not_found"
+}
+
+func (e UserDoesNotExistError) Details() map[string]string {
+	return map[string]string{
+		"username": e.username,
+	}
+}
+
 type SelfFollowError struct {
 	user string
 }
@@ -48,6 +85,16 @@ func (e SelfFollowError) Error() string {
 	return fmt.Sprintf("%s cannot follow or unfollow itself", e.user)
 }
 
+func (e SelfFollowError) Code() string {
+	return "self_follow"
+}
+
+func (e SelfFollowError) Details() map[string]string {
+	return map[string]string{
+		"username": e.user,
+	}
+}
+
 type UserAlreadyFollowsError struct {
 	user     string
 	follower string
@@ -57,6 +104,17 @@ func (e UserAlreadyFollowsError) Error() string {
 	return fmt.Sprintf("%s already follows %s", e.follower, e.user)
 }
 
+func (e UserAlreadyFollowsError) Code() string {
+	return "already_following"
+}
+
+func (e UserAlreadyFollowsError) Details() map[string]string {
+	return map[string]string{
+		"username": e.user,
+		"follower": e.follower,
+	}
+}
+
 type UserDoesNotFollowError struct {
 	user     string
 	follower string
@@ -65,3 +123,249 @@ type UserDoesNotFollowError struct {
 func (e UserDoesNotFollowError) Error() string {
 	return fmt.Sprintf("%s does not follow %s", e.follower, e.user)
 }
+
+func (e UserDoesNotFollowError) Code() string {
+	return "not_following"
+}
+
+func (e UserDoesNotFollowError) Details() map[string]string {
+	return map[string]string{
+		"username": e.user,
+		"follower": e.follower,
+	}
+}
+
+type InvalidCursorError struct {
+	cursor string
+}
+
+func (e InvalidCursorError) Error() string {
+	return fmt.Sprintf("invalid cursor %s", e.cursor)
+}
+
+func (e InvalidCursorError) Code() string {
+	return "invalid_cursor"
+}
+
+func (e InvalidCursorError) Details() map[string]string {
+	return map[string]string{
+		"cursor": e.cursor,
+	}
+}
+
+type SelfBlockError struct {
+	user string
+}
+
+func (e SelfBlockError) Error() string {
+	return fmt.Sprintf("%s cannot block or unblock itself", e.user)
+}
+
+func (e SelfBlockError) Code() string {
+	return "self_block"
+}
+
+func (e SelfBlockError) Details() map[string]string {
+	return map[string]string{
+		"username": e.user,
+	}
+}
+
+type UserAlreadyBlockedError struct {
+	user    string
+	blocker string
+}
+
+func (e UserAlreadyBlockedError) Error() string {
+	return fmt.Sprintf("%s already blocked %s", e.blocker, e.user)
+}
+
+func (e UserAlreadyBlockedError) Code() string {
+	return "already_blocked"
+}
+
+func (e UserAlreadyBlockedError) Details() map[string]string {
+	return map[string]string{
+		"username": e.user,
+		"blocker":  e.blocker,
+	}
+}
+
+type UserNotBlockedError struct {
+	user    string
+	blocker string
+}
+
+func (e UserNotBlockedError) Error() string {
+	return fmt.Sprintf("%s did not block %s", e.blocker, e.user)
+}
+
+func (e UserNotBlockedError) Code() string {
+	return "not_blocked"
+}
+
+func (e UserNotBlockedError) Details() map[string]string {
+	return map[string]string{
+		"username": e.user,
+		"blocker":  e.blocker,
+	}
+}
+
+type SelfMuteError struct {
+	user string
+}
+
+func (e SelfMuteError) Error() string {
+	return fmt.Sprintf("%s cannot mute or unmute itself", e.user)
+}
+
+func (e SelfMuteError) Code() string {
+	return "self_mute"
+}
+
+func (e SelfMuteError) Details() map[string]string {
+	return map[string]string{
+		"username": e.user,
+	}
+}
+
+type UserAlreadyMutedError struct {
+	user  string
+	muter string
+}
+
+func (e UserAlreadyMutedError) Error() string {
+	return fmt.Sprintf("%s already muted %s", e.muter, e.user)
+}
+
+func (e UserAlreadyMutedError) Code() string {
+	return "already_muted"
+}
+
+func (e UserAlreadyMutedError) Details() map[string]string {
+	return map[string]string{
+		"username": e.user,
+		"muter":    e.muter,
+	}
+}
+
+type UserNotMutedError struct {
+	user  string
+	muter string
+}
+
+func (e UserNotMutedError) Error() string {
+	return fmt.Sprintf("%s did not mute %s", e.muter, e.user)
+}
+
+func (e UserNotMutedError) Code() string {
+	return "not_muted"
+}
+
+func (e UserNotMutedError) Details() map[string]string {
+	return map[string]string{
+		"username": e.user,
+		"muter":    e.muter,
+	}
+}
+
+// UserBlockedError is returned when follower can't follow username
+// because either of them blocked the other
+type UserBlockedError struct {
+	user     string
+	follower string
+}
+
+func (e UserBlockedError) Error() string {
+	return fmt.Sprintf("%s cannot follow %s: one of them blocked the other", e.follower, e.user)
+}
+
+func (e UserBlockedError) Code() string {
+	return "blocked"
+}
+
+func (e UserBlockedError) Details() map[string]string {
+	return map[string]string{
+		"username": e.user,
+		"follower": e.follower,
+	}
+}
+
+type FollowRequestAlreadyExistsError struct {
+	user      string
+	requester string
+}
+
+func (e FollowRequestAlreadyExistsError) Error() string {
+	return fmt.Sprintf("%s already requested to follow %s", e.requester, e.user)
+}
+
+func (e FollowRequestAlreadyExistsError) Code() string {
+	return "already_requested"
+}
+
+func (e FollowRequestAlreadyExistsError) Details() map[string]string {
+	return map[string]string{
+		"username":  e.user,
+		"requester": e.requester,
+	}
+}
+
+type FollowRequestDoesNotExistError struct {
+	user      string
+	requester string
+}
+
+func (e FollowRequestDoesNotExistError) Error() string {
+	return fmt.Sprintf("%s did not request to follow %s", e.requester, e.user)
+}
+
+func (e FollowRequestDoesNotExistError) Code() string {
+	return "follow_request_not_found"
+}
+
+func (e FollowRequestDoesNotExistError) Details() map[string]string {
+	return map[string]string{
+		"username":  e.user,
+		"requester": e.requester,
+	}
+}
+
+type ProfileFieldTooLongError struct {
+	field    string
+	maxChars int
+}
+
+func (e ProfileFieldTooLongError) Error() string {
+	return fmt.Sprintf("%s exceeded the maximum of %d chars", e.field, e.maxChars)
+}
+
+func (e ProfileFieldTooLongError) Code() string {
+	return "profile_field_too_long"
+}
+
+func (e ProfileFieldTooLongError) Details() map[string]string {
+	return map[string]string{
+		"field":     e.field,
+		"max_chars": strconv.Itoa(e.maxChars),
+	}
+}
+
+type InvalidProfileFieldError struct {
+	field  string
+	reason string
+}
+
+func (e InvalidProfileFieldError) Error() string {
+	return fmt.Sprintf("invalid %s: %s", e.field, e.reason)
+}
+
+func (e InvalidProfileFieldError) Code() string {
+	return "invalid_profile_field"
+}
+
+func (e InvalidProfileFieldError) Details() map[string]string {
+	return map[string]string{
+		"field": e.field,
+	}
+}
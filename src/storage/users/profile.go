@@ -0,0 +1,79 @@
+package users
+
+import (
+	"net/url"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"posterr/src/types"
+)
+
+// Maximum chars of each profile field, also enforced by the users table
+const (
+	maxDisplayNameChars = 50
+	maxBioChars         = 160
+	maxLocationChars    = 30
+	maxWebsiteChars     = 100
+	maxAvatarURLChars   = 200
+)
+
+// profileField is a field of types.PosterrProfileUpdate along with how it is validated
+type profileField struct {
+	name     string
+	value    *string
+	maxChars int
+	// Whether the field may span multiple lines
+	multiline bool
+	// Whether the field must be an http or https URL
+	isURL bool
+}
+
+func profileFields(update types.PosterrProfileUpdate) []profileField {
+	return []profileField{
+		{name: "display_name", value: update.DisplayName, maxChars: maxDisplayNameChars},
+		{name: "bio", value: update.Bio, maxChars: maxBioChars, multiline: true},
+		{name: "location", value: update.Location, maxChars: maxLocationChars},
+		{name: "website", value: update.Website, maxChars: maxWebsiteChars, isURL: true},
+		{name: "avatar_url", value: update.AvatarURL, maxChars: maxAvatarURLChars, isURL: true},
+	}
+}
+
+// validateProfileUpdate checks every field set by update. Empty fields are always valid
+func validateProfileUpdate(update types.PosterrProfileUpdate) error {
+	for _, field := range profileFields(update) {
+		if field.value == nil || *field.value == "" {
+			continue
+		}
+		value := *field.value
+
+		if utf8.RuneCountInString(value) > field.maxChars {
+			return ProfileFieldTooLongError{field.name, field.maxChars}
+		}
+
+		if strings.IndexFunc(value, func(r rune) bool {
+			return unicode.IsControl(r) && !(field.multiline && r == '\n')
+		}) >= 0 {
+			if field.multiline {
+				return InvalidProfileFieldError{field.name, "must not contain control characters other than line breaks"}
+			}
+			return InvalidProfileFieldError{field.name, "must be a single line without control characters"}
+		}
+
+		if field.isURL && !isWebURL(value) {
+			return InvalidProfileFieldError{field.name, "must be an absolute http or https URL"}
+		}
+	}
+
+	return nil
+}
+
+// isWebURL checks if value is an absolute http or https URL
+func isWebURL(value string) bool {
+	u, err := url.Parse(value)
+	if err != nil {
+		return false
+	}
+
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
@@ -1,10 +1,25 @@
 package users
 
+// kinds of the relations selected by selectUserRelations
 const (
-	selectUser = `SELECT *
+	followingRelation = "following"
+	blockedRelation   = "blocked"
+	mutedRelation     = "muted"
+)
+
+const (
+	selectUser = `SELECT username, joined_at, private, display_name, bio, location, website, avatar_url
                  FROM users
                  WHERE username = $1`
 
+	updateUserProfile = `UPDATE users
+                 SET display_name = COALESCE($2, display_name),
+                     bio = COALESCE($3, bio),
+                     location = COALESCE($4, location),
+                     website = COALESCE($5, website),
+                     avatar_url = COALESCE($6, avatar_url)
+                 WHERE username = $1`
+
 	countFollowers = `SELECT COUNT(*) as followers
                  FROM followers
                  WHERE username = $1`
@@ -13,15 +28,64 @@ const (
                  FROM followers
                  WHERE followed_by = $1`
 
-	listFollowers = `SELECT followed_by
+	listFollowers = `SELECT followed_by, followed_at
                  FROM followers
-                 WHERE username = $1`
+                 WHERE username = $1
+                 AND ($3::timestamptz IS NULL OR (followed_at, followed_by) < ($3, $4))
+                 ORDER BY followed_at DESC, followed_by DESC
+                 LIMIT 20
+                 OFFSET $2`
+
+	listFollowing = `SELECT username, followed_at
+                 FROM followers
+                 WHERE followed_by = $1
+                 AND ($3::timestamptz IS NULL OR (followed_at, username) < ($3, $4))
+                 ORDER BY followed_at DESC, username DESC
+                 LIMIT 20
+                 OFFSET $2`
 
 	countUserPosts = `SELECT COUNT(*) as no_posts
                  FROM posts
-                 WHERE username = $1`
+                 WHERE username = $1
+                 AND deleted_at IS NULL`
 
 	isFollowerOf = `SELECT COUNT(*) as is_follower
                  FROM followers
                  WHERE username = $1 AND followed_by = $2`
+
+	selectUserRelations = `SELECT 'following', username
+                 FROM followers
+                 WHERE followed_by = $1
+                 UNION ALL
+                 SELECT 'blocked', blocked
+                 FROM blocks
+                 WHERE username = $1
+                 UNION ALL
+                 SELECT 'muted', muted
+                 FROM mutes
+                 WHERE username = $1`
+
+	insertFollowNotification = `INSERT INTO notifications (username, kind, actor)
+                 VALUES ($1, $2, $3)`
+
+	countBlocksBetween = `SELECT COUNT(*) as blocks
+                 FROM blocks
+                 WHERE (username = $1 AND blocked = $2)
+                 OR (username = $2 AND blocked = $1)`
+
+	deleteFollowsBetween = `DELETE FROM followers
+                 WHERE (username = $1 AND followed_by = $2)
+                 OR (username = $2 AND followed_by = $1)`
+
+	deleteFollowRequestsBetween = `DELETE FROM follow_requests
+                 WHERE (username = $1 AND requested_by = $2)
+                 OR (username = $2 AND requested_by = $1)`
+
+	listFollowRequests = `SELECT requested_by, created_at
+                 FROM follow_requests
+                 WHERE username = $1
+                 AND ($3::timestamptz IS NULL OR (created_at, requested_by) < ($3, $4))
+                 ORDER BY created_at DESC, requested_by DESC
+                 LIMIT 20
+                 OFFSET $2`
 )
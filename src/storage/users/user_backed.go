@@ -6,14 +6,16 @@ import (
 	"regexp"
 	"sync"
 
-	storagedb "posterr/src/storage/db"
 	"posterr/src/types"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
 )
 
 type userBacked struct {
 	sync.RWMutex
-	// An accessor to the database
-	db storagedb.ConnectDB
+	// The connection pool to the database
+	pool *pgxpool.Pool
 	// A cache map to store how many followers a user has
 	followersCount map[string]int
 	// A cache map to store how many users a user is following
@@ -22,55 +24,69 @@ type userBacked struct {
 	rgx *regexp.Regexp
 }
 
-func NewUserBacked(db storagedb.ConnectDB) *userBacked {
-	// TODO: should the connection pool be reset for every call?
+func NewUserBacked(pool *pgxpool.Pool) *userBacked {
 	return &userBacked{
-		db:             db,
+		pool:           pool,
 		followersCount: make(map[string]int),
 		followingCount: make(map[string]int),
-		rgx:            regexp.MustCompile(`^[a-zA-Z0-9]*$`),
+		rgx:            regexp.MustCompile(`^[` + types.UsernameCharset + `]+$`),
 	}
 }
 
-// CreateUser creates a user. This method is not exposed through an API
-func (ub *userBacked) CreateUser(username string) error {
+// CreateUser creates a user. Usernames must be alphanumeric, with 14 characters at most
+func (ub *userBacked) CreateUser(ctx context.Context, username string) error {
 	if !ub.rgx.MatchString(username) {
 		return InvalidUsernameError{username}
 	}
 
-	conn, err := ub.db.Connect()
-	if err != nil {
-		return fmt.Errorf("could not connect to database: %w", err)
-	}
-	defer conn.Close()
-
-	_, err = conn.Exec(context.Background(), "INSERT INTO users (username) VALUES ($1)", username)
+	_, err := ub.pool.Exec(ctx, "INSERT INTO users (username) VALUES ($1)", username)
 	if err != nil {
 		err = fmt.Errorf("could not insert into users: %w", err)
-		return getErrorFromString(err, username)
+		return translateError(err, username)
 	}
 
 	return nil
 }
 
+// RegisterUser creates a user along with the credentials of passwordHash, within a transaction.
+// Usernames must be alphanumeric, with 14 characters at most
+func (ub *userBacked) RegisterUser(ctx context.Context, username, passwordHash string) error {
+	if !ub.rgx.MatchString(username) {
+		return InvalidUsernameError{username}
+	}
+
+	return ub.pool.BeginFunc(ctx, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, "INSERT INTO users (username) VALUES ($1)", username); err != nil {
+			err = fmt.Errorf("could not insert into users: %w", err)
+			return translateError(err, username)
+		}
+
+		if _, err := tx.Exec(ctx, "INSERT INTO credentials (username, password_hash) VALUES ($1, $2)", username, passwordHash); err != nil {
+			return fmt.Errorf("could not insert into credentials: %w", err)
+		}
+
+		return nil
+	})
+}
+
 // GetUserProfile returns a user detailed information
-func (ub *userBacked) GetUserProfile(username string) (types.PosterrUserDetailed, error) {
-	userProfile, err := ub.getUserDetails(username)
+func (ub *userBacked) GetUserProfile(ctx context.Context, username string) (types.PosterrUserDetailed, error) {
+	userProfile, err := ub.getUserDetails(ctx, username)
 	if err != nil {
 		return types.PosterrUserDetailed{}, err
 	}
 
-	userProfile.Followers, err = ub.CountUserFollowers(username)
+	userProfile.Followers, err = ub.CountUserFollowers(ctx, username)
 	if err != nil {
 		return types.PosterrUserDetailed{}, err
 	}
 
-	userProfile.Following, err = ub.CountUserFollowing(username)
+	userProfile.Following, err = ub.CountUserFollowing(ctx, username)
 	if err != nil {
 		return types.PosterrUserDetailed{}, err
 	}
 
-	userProfile.PostsCount, err = ub.CountUserPosts(username)
+	userProfile.PostsCount, err = ub.CountUserPosts(ctx, username)
 	if err != nil {
 		return types.PosterrUserDetailed{}, err
 	}
@@ -78,17 +94,30 @@ func (ub *userBacked) GetUserProfile(username string) (types.PosterrUserDetailed
 	return userProfile, nil
 }
 
-// CountUserPosts returns how many posts a user has made
-func (ub *userBacked) CountUserPosts(username string) (int, error) {
-	conn, err := ub.db.Connect()
+// UpdateUserProfile changes the profile fields of a user set by update
+func (ub *userBacked) UpdateUserProfile(ctx context.Context, username string, update types.PosterrProfileUpdate) error {
+	if err := validateProfileUpdate(update); err != nil {
+		return err
+	}
+
+	tag, err := ub.pool.Exec(ctx, updateUserProfile, username,
+		update.DisplayName, update.Bio, update.Location, update.Website, update.AvatarURL)
 	if err != nil {
-		return 0, fmt.Errorf("could not connect to database: %w", err)
+		return fmt.Errorf("could not update users: %w", err)
 	}
-	defer conn.Close()
 
+	if tag.RowsAffected() == 0 {
+		return UserDoesNotExistError{username}
+	}
+
+	return nil
+}
+
+// CountUserPosts returns how many posts a user has made
+func (ub *userBacked) CountUserPosts(ctx context.Context, username string) (int, error) {
 	var dailyPosts int
-	row := conn.QueryRow(context.Background(), countUserPosts, username)
-	if err = row.Scan(&dailyPosts); err != nil {
+	row := ub.pool.QueryRow(ctx, countUserPosts, username)
+	if err := row.Scan(&dailyPosts); err != nil {
 		return 0, fmt.Errorf("could not scan countUserPosts rows: %w", err)
 	}
 
@@ -96,7 +125,7 @@ func (ub *userBacked) CountUserPosts(username string) (int, error) {
 }
 
 // CountUserFollowing returns how many followers a user has
-func (ub *userBacked) CountUserFollowers(username string) (int, error) {
+func (ub *userBacked) CountUserFollowers(ctx context.Context, username string) (int, error) {
 	count, exists := func(username string) (int, bool) {
 		ub.RLock()
 		defer ub.RUnlock()
@@ -108,15 +137,9 @@ func (ub *userBacked) CountUserFollowers(username string) (int, error) {
 		return count, nil
 	}
 
-	conn, err := ub.db.Connect()
-	if err != nil {
-		return 0, fmt.Errorf("could not connect to database: %w", err)
-	}
-	defer conn.Close()
-
 	var followers int
-	row := conn.QueryRow(context.Background(), countFollowers, username)
-	if err = row.Scan(&followers); err != nil {
+	row := ub.pool.QueryRow(ctx, countFollowers, username)
+	if err := row.Scan(&followers); err != nil {
 		return 0, fmt.Errorf("could not scan countFollowers rows: %w", err)
 	}
 
@@ -130,7 +153,7 @@ func (ub *userBacked) CountUserFollowers(username string) (int, error) {
 }
 
 // CountUserFollowing returns how many users a user is following
-func (ub *userBacked) CountUserFollowing(username string) (int, error) {
+func (ub *userBacked) CountUserFollowing(ctx context.Context, username string) (int, error) {
 	count, exists := func(username string) (int, bool) {
 		ub.RLock()
 		defer ub.RUnlock()
@@ -142,15 +165,9 @@ func (ub *userBacked) CountUserFollowing(username string) (int, error) {
 		return count, nil
 	}
 
-	conn, err := ub.db.Connect()
-	if err != nil {
-		return 0, fmt.Errorf("could not connect to database: %w", err)
-	}
-	defer conn.Close()
-
 	var following int
-	row := conn.QueryRow(context.Background(), countFollowing, username)
-	if err = row.Scan(&following); err != nil {
+	row := ub.pool.QueryRow(ctx, countFollowing, username)
+	if err := row.Scan(&following); err != nil {
 		return 0, fmt.Errorf("could not scan countFollowing rows: %w", err)
 	}
 
@@ -163,146 +180,393 @@ func (ub *userBacked) CountUserFollowing(username string) (int, error) {
 	return following, nil
 }
 
-// ListFollowers returns a list of followers of a user
-func (ub *userBacked) ListFollowers(username string) ([]types.PosterrUser, error) {
-	conn, err := ub.db.Connect()
-	if err != nil {
-		return nil, fmt.Errorf("could not connect to database: %w", err)
+// ListFollowers returns a list of followers of a user, from the latest to the earliest follow.
+// Each call returns 20 users at most.
+func (ub *userBacked) ListFollowers(ctx context.Context, username string, page types.Page) (types.PosterrFollowPage, error) {
+	return ub.listFollows(ctx, listFollowers, username, page)
+}
+
+// ListFollowing returns a list of users a user is following, from the latest to the earliest follow.
+// Each call returns 20 users at most.
+func (ub *userBacked) ListFollowing(ctx context.Context, username string, page types.Page) (types.PosterrFollowPage, error) {
+	return ub.listFollows(ctx, listFollowing, username, page)
+}
+
+// FollowUser ensures that username is followed by follower,
+// i.e., follower follows username. If username is private,
+// a request to follow them is made instead
+func (ub *userBacked) FollowUser(ctx context.Context, username, follower string) error {
+	if username == follower {
+		return SelfFollowError{username}
 	}
-	defer conn.Close()
 
-	_, err = ub.getUserDetails(username)
+	isFollowingUser, err := ub.IsFollowingUser(ctx, username, follower)
 	if err != nil {
-		return nil, err
+		return fmt.Errorf("could not check follower: %w", err)
 	}
 
-	rows, err := conn.Query(context.Background(), listFollowers, username)
-	if err != nil {
-		return nil, fmt.Errorf("could not perform listFollowers query: %w", err)
+	if isFollowingUser {
+		return UserAlreadyFollowsError{username, follower}
 	}
 
-	followers := make([]types.PosterrUser, 0)
-	for rows.Next() {
-		var follower types.PosterrUser
-		if err = rows.Scan(&follower.Username); err != nil {
-			return nil, fmt.Errorf("could not scan listFollowers rows: %w", err)
+	defer ub.resetCountCache(username, follower)
+	err = ub.pool.BeginFunc(ctx, func(tx pgx.Tx) error {
+		var countBlocks int
+		row := tx.QueryRow(ctx, countBlocksBetween, username, follower)
+		if err := row.Scan(&countBlocks); err != nil {
+			return fmt.Errorf("could not scan countBlocksBetween rows: %w", err)
+		}
+
+		if countBlocks > 0 {
+			return UserBlockedError{username, follower}
 		}
 
-		followers = append(followers, follower)
+		var private bool
+		row = tx.QueryRow(ctx, "SELECT private FROM users WHERE username = $1", username)
+		if err := row.Scan(&private); err != nil {
+			err = fmt.Errorf("could not scan users rows: %w", err)
+			return translateError(err, username)
+		}
+
+		if private {
+			_, err := tx.Exec(ctx, "INSERT INTO follow_requests (username, requested_by) VALUES ($1, $2)",
+				username, follower)
+			if err != nil {
+				return fmt.Errorf("could not insert into follow_requests: %w", err)
+			}
+
+			_, err = tx.Exec(ctx, insertFollowNotification, username, types.FollowRequestNotification, follower)
+			if err != nil {
+				return fmt.Errorf("could not insert into notifications: %w", err)
+			}
+
+			return nil
+		}
+
+		// a request left by a user who is no longer private is fulfilled
+		_, err := tx.Exec(ctx, "DELETE FROM follow_requests WHERE username = $1 AND requested_by = $2",
+			username, follower)
+		if err != nil {
+			return fmt.Errorf("could not delete row from follow_requests: %w", err)
+		}
+
+		_, err = tx.Exec(ctx, "INSERT INTO followers (username, followed_by) VALUES ($1, $2)",
+			username, follower)
+		if err != nil {
+			return fmt.Errorf("could not insert into followers: %w", err)
+		}
+
+		_, err = tx.Exec(ctx, insertFollowNotification, username, types.FollowNotification, follower)
+		if err != nil {
+			return fmt.Errorf("could not insert into notifications: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return translateFollowError(err, username, follower)
 	}
 
-	return followers, nil
+	return nil
 }
 
-// FollowUser ensures that username is followed by follower,
-// i.e., follower follows username
-func (ub *userBacked) FollowUser(username, follower string) error {
+// UnfollowUser ensures that username is unfollowed by follower,
+// i.e., follower unfollows username
+func (ub *userBacked) UnfollowUser(ctx context.Context, username, follower string) error {
 	if username == follower {
 		return SelfFollowError{username}
 	}
 
-	conn, err := ub.db.Connect()
-	if err != nil {
-		return fmt.Errorf("could not connect to database: %w", err)
-	}
-	defer conn.Close()
-
-	isFollowingUser, err := ub.IsFollowingUser(username, follower)
+	isFollowingUser, err := ub.IsFollowingUser(ctx, username, follower)
 	if err != nil {
 		return fmt.Errorf("could not check follower: %w", err)
 	}
 
-	if isFollowingUser {
-		return UserAlreadyFollowsError{username, follower}
+	if !isFollowingUser {
+		return UserDoesNotFollowError{username, follower}
 	}
 
 	defer ub.resetCountCache(username, follower)
-	_, err = conn.Exec(context.Background(), "INSERT INTO followers (username, followed_by) VALUES ($1, $2)",
+	_, err = ub.pool.Exec(ctx, "DELETE FROM followers WHERE username = $1 AND followed_by = $2",
 		username, follower)
 	if err != nil {
-		return fmt.Errorf("could not insert into followers: %w", err)
+		return fmt.Errorf("could not delete row from followers: %w", err)
 	}
 
 	return nil
 }
 
-// UnfollowUser ensures that username is unfollowed by follower,
-// i.e., follower unfollows username
-func (ub *userBacked) UnfollowUser(username, follower string) error {
-	if username == follower {
-		return SelfFollowError{username}
+// BlockUser ensures that username is blocked by blocker. Follows and follow requests between
+// them are removed in both directions, and neither can follow the other afterwards
+func (ub *userBacked) BlockUser(ctx context.Context, username, blocker string) error {
+	if username == blocker {
+		return SelfBlockError{username}
 	}
 
-	conn, err := ub.db.Connect()
+	defer ub.resetCountCache(username, blocker)
+	defer ub.resetCountCache(blocker, username)
+	err := ub.pool.BeginFunc(ctx, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, "INSERT INTO blocks (username, blocked) VALUES ($1, $2)", blocker, username)
+		if err != nil {
+			return fmt.Errorf("could not insert into blocks: %w", err)
+		}
+
+		_, err = tx.Exec(ctx, deleteFollowsBetween, username, blocker)
+		if err != nil {
+			return fmt.Errorf("could not delete rows from followers: %w", err)
+		}
+
+		_, err = tx.Exec(ctx, deleteFollowRequestsBetween, username, blocker)
+		if err != nil {
+			return fmt.Errorf("could not delete rows from follow_requests: %w", err)
+		}
+
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("could not connect to database: %w", err)
+		return translateBlockError(err, username, blocker)
 	}
-	defer conn.Close()
 
-	isFollowingUser, err := ub.IsFollowingUser(username, follower)
+	return nil
+}
+
+// UnblockUser ensures that username is unblocked by blocker.
+// Follows removed by the block are not restored
+func (ub *userBacked) UnblockUser(ctx context.Context, username, blocker string) error {
+	if username == blocker {
+		return SelfBlockError{username}
+	}
+
+	tag, err := ub.pool.Exec(ctx, "DELETE FROM blocks WHERE username = $1 AND blocked = $2", blocker, username)
 	if err != nil {
-		return fmt.Errorf("could not check follower: %w", err)
+		return fmt.Errorf("could not delete row from blocks: %w", err)
 	}
 
-	if !isFollowingUser {
-		return UserDoesNotFollowError{username, follower}
+	if tag.RowsAffected() == 0 {
+		return UserNotBlockedError{username, blocker}
 	}
 
-	defer ub.resetCountCache(username, follower)
-	_, err = conn.Exec(context.Background(), "DELETE FROM followers WHERE username = $1 AND followed_by = $2",
-		username, follower)
+	return nil
+}
+
+// MuteUser ensures that username is muted by muter, which
+// hides the posts of username from the home page and searches of muter
+func (ub *userBacked) MuteUser(ctx context.Context, username, muter string) error {
+	if username == muter {
+		return SelfMuteError{username}
+	}
+
+	_, err := ub.pool.Exec(ctx, "INSERT INTO mutes (username, muted) VALUES ($1, $2)", muter, username)
 	if err != nil {
-		return fmt.Errorf("could not delete row from followers: %w", err)
+		err = fmt.Errorf("could not insert into mutes: %w", err)
+		return translateMuteError(err, username, muter)
 	}
 
 	return nil
 }
 
-// IsFollowingUser checks if username is followed by follower,
-// i.e., follower follows username
-func (ub *userBacked) IsFollowingUser(username, follower string) (bool, error) {
-	// TODO: this could be cached as well
-	conn, err := ub.db.Connect()
+// UnmuteUser ensures that username is unmuted by muter
+func (ub *userBacked) UnmuteUser(ctx context.Context, username, muter string) error {
+	if username == muter {
+		return SelfMuteError{username}
+	}
+
+	tag, err := ub.pool.Exec(ctx, "DELETE FROM mutes WHERE username = $1 AND muted = $2", muter, username)
 	if err != nil {
-		return false, fmt.Errorf("could not connect to database: %w", err)
+		return fmt.Errorf("could not delete row from mutes: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return UserNotMutedError{username, muter}
 	}
-	defer conn.Close()
 
+	return nil
+}
+
+// SetUserPrivate sets whether only the followers of username can see their posts.
+// Pending follow requests are kept when username is no longer private
+func (ub *userBacked) SetUserPrivate(ctx context.Context, username string, private bool) error {
+	tag, err := ub.pool.Exec(ctx, "UPDATE users SET private = $2 WHERE username = $1", username, private)
+	if err != nil {
+		return fmt.Errorf("could not update users: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return UserDoesNotExistError{username}
+	}
+
+	return nil
+}
+
+// ListFollowRequests returns the pending requests to follow username, from the latest to the earliest.
+// Each call returns 20 requests at most.
+func (ub *userBacked) ListFollowRequests(ctx context.Context, username string, page types.Page) (types.PosterrFollowRequestPage, error) {
+	args, err := pageArgs(page)
+	if err != nil {
+		return types.PosterrFollowRequestPage{}, err
+	}
+
+	_, err = ub.getUserDetails(ctx, username)
+	if err != nil {
+		return types.PosterrFollowRequestPage{}, err
+	}
+
+	rows, err := ub.pool.Query(ctx, listFollowRequests, append([]interface{}{username}, args...)...)
+	if err != nil {
+		return types.PosterrFollowRequestPage{}, fmt.Errorf("could not perform listFollowRequests query: %w", err)
+	}
+	defer rows.Close()
+
+	requests := make([]types.PosterrFollowRequest, 0)
+	for rows.Next() {
+		var request types.PosterrFollowRequest
+		if err = rows.Scan(&request.Username, &request.RequestedAt); err != nil {
+			return types.PosterrFollowRequestPage{}, fmt.Errorf("could not scan listFollowRequests rows: %w", err)
+		}
+
+		requests = append(requests, request)
+	}
+
+	return newRequestPage(requests), nil
+}
+
+// ApproveFollowRequest makes requester follow username, as requested
+func (ub *userBacked) ApproveFollowRequest(ctx context.Context, username, requester string) error {
+	defer ub.resetCountCache(username, requester)
+	return ub.pool.BeginFunc(ctx, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, "DELETE FROM follow_requests WHERE username = $1 AND requested_by = $2",
+			username, requester)
+		if err != nil {
+			return fmt.Errorf("could not delete row from follow_requests: %w", err)
+		}
+
+		if tag.RowsAffected() == 0 {
+			return FollowRequestDoesNotExistError{username, requester}
+		}
+
+		_, err = tx.Exec(ctx, "INSERT INTO followers (username, followed_by) VALUES ($1, $2) ON CONFLICT DO NOTHING",
+			username, requester)
+		if err != nil {
+			return fmt.Errorf("could not insert into followers: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// RejectFollowRequest discards the request of requester to follow username
+func (ub *userBacked) RejectFollowRequest(ctx context.Context, username, requester string) error {
+	tag, err := ub.pool.Exec(ctx, "DELETE FROM follow_requests WHERE username = $1 AND requested_by = $2",
+		username, requester)
+	if err != nil {
+		return fmt.Errorf("could not delete row from follow_requests: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return FollowRequestDoesNotExistError{username, requester}
+	}
+
+	return nil
+}
+
+// IsFollowingUser checks if username is followed by follower,
+// i.e., follower follows username
+func (ub *userBacked) IsFollowingUser(ctx context.Context, username, follower string) (bool, error) {
+	// TODO: this could be cached as well
 	var countRows int
-	row := conn.QueryRow(context.Background(), isFollowerOf, username, follower)
-	if err = row.Scan(&countRows); err != nil {
+	row := ub.pool.QueryRow(ctx, isFollowerOf, username, follower)
+	if err := row.Scan(&countRows); err != nil {
 		return false, fmt.Errorf("could not scan isFollowerOf rows: %w", err)
 	}
 
 	return countRows == 1, nil
 }
 
-// getUserDetails returns a PosterrUser containing
-// the username and the date they joined
-func (ub *userBacked) getUserDetails(username string) (types.PosterrUserDetailed, error) {
-	conn, err := ub.db.Connect()
+// GetUserRelations returns the users a given username follows, blocked and muted, in a single query.
+// Users who don't exist have no relations
+func (ub *userBacked) GetUserRelations(ctx context.Context, username string) (types.PosterrRelations, error) {
+	rows, err := ub.pool.Query(ctx, selectUserRelations, username)
+	if err != nil {
+		return types.PosterrRelations{}, fmt.Errorf("could not perform selectUserRelations query: %w", err)
+	}
+	defer rows.Close()
+
+	relations := types.PosterrRelations{
+		Following: make(map[string]struct{}),
+		Blocked:   make(map[string]struct{}),
+		Muted:     make(map[string]struct{}),
+	}
+	for rows.Next() {
+		var kind, related string
+		if err = rows.Scan(&kind, &related); err != nil {
+			return types.PosterrRelations{}, fmt.Errorf("could not scan selectUserRelations rows: %w", err)
+		}
+
+		switch kind {
+		case followingRelation:
+			relations.Following[related] = struct{}{}
+		case blockedRelation:
+			relations.Blocked[related] = struct{}{}
+		case mutedRelation:
+			relations.Muted[related] = struct{}{}
+		}
+	}
+
+	return relations, rows.Err()
+}
+
+// listFollows returns a page of the follows listed by query for a given username
+func (ub *userBacked) listFollows(ctx context.Context, query, username string, page types.Page) (types.PosterrFollowPage, error) {
+	args, err := pageArgs(page)
+	if err != nil {
+		return types.PosterrFollowPage{}, err
+	}
+
+	_, err = ub.getUserDetails(ctx, username)
 	if err != nil {
-		return types.PosterrUserDetailed{}, fmt.Errorf("could not connect to database: %w", err)
+		return types.PosterrFollowPage{}, err
 	}
-	defer conn.Close()
 
+	rows, err := ub.pool.Query(ctx, query, append([]interface{}{username}, args...)...)
+	if err != nil {
+		return types.PosterrFollowPage{}, fmt.Errorf("could not perform listFollows query: %w", err)
+	}
+	defer rows.Close()
+
+	follows := make([]types.PosterrFollow, 0)
+	for rows.Next() {
+		var follow types.PosterrFollow
+		if err = rows.Scan(&follow.Username, &follow.FollowedAt); err != nil {
+			return types.PosterrFollowPage{}, fmt.Errorf("could not scan listFollows rows: %w", err)
+		}
+
+		follows = append(follows, follow)
+	}
+
+	return newPage(follows), nil
+}
+
+// getUserDetails returns a PosterrUser containing
+// the username, the date they joined and whether they are private
+func (ub *userBacked) getUserDetails(ctx context.Context, username string) (types.PosterrUserDetailed, error) {
 	var userProfile types.PosterrUserDetailed
-	row := conn.QueryRow(context.Background(), selectUser, username)
-	if err = row.Scan(&userProfile.Username, &userProfile.JoinedAt); err != nil {
+	row := ub.pool.QueryRow(ctx, selectUser, username)
+	if err := row.Scan(&userProfile.Username, &userProfile.JoinedAt, &userProfile.Private,
+		&userProfile.DisplayName, &userProfile.Bio, &userProfile.Location, &userProfile.Website, &userProfile.AvatarURL); err != nil {
 		err = fmt.Errorf("could not scan selectUser rows: %w", err)
-		return types.PosterrUserDetailed{}, getErrorFromString(err, username)
+		return types.PosterrUserDetailed{}, translateError(err, username)
 	}
 
 	return userProfile, nil
 }
 
 // resetCountCache resets the counter cache for
-// userA following count and userB followers count
-func (ub *userBacked) resetCountCache(userA, userB string) {
+// username followers count and follower following count
+func (ub *userBacked) resetCountCache(username, follower string) {
 	ub.Lock()
 	defer ub.Unlock()
-	// reset userB followers cache
-	delete(ub.followersCount, userB)
-	// reset userA following cache
-	delete(ub.followingCount, userA)
+	// reset username followers cache
+	delete(ub.followersCount, username)
+	// reset follower following cache
+	delete(ub.followingCount, follower)
 }
@@ -1,6 +1,7 @@
 package users
 
 import (
+	"context"
 	"fmt"
 	"sync"
 	"testing"
@@ -19,49 +20,67 @@ const (
 )
 
 func TestUserCreation(t *testing.T) {
+	ctx := context.Background()
 	assert := assertions.New(t)
 	rs := testrand.NewPseudoRandomString()
 	dbName := testdb.GenerateDBName()
 
-	db := storagedb.NewDatabase(dbName)
+	db := storagedb.NewDatabase(dbName, storagedb.DefaultPoolConfig())
 	err := db.InitializeDB()
 	defer testdb.DropDatabase(dbName)
-	assert.NoError(err)
+	if err != nil {
+		t.Fatalf("could not initialize database: %s", err)
+	}
+
+	pool, err := db.Connect()
+	defer db.Close()
+	if err != nil {
+		t.Fatalf("could not connect to database: %s", err)
+	}
 
-	users := NewUserBacked(db)
+	users := NewUserBacked(pool)
 
 	t.Run("Many random names", func(t *testing.T) {
 		for count := 0; count < 100; count++ {
 			username := rs.GenerateUnique(maxUsernameLength)
-			err = users.CreateUser(username)
+			err = users.CreateUser(ctx, username)
 			assert.NoError(err)
 		}
 	})
 
 	t.Run("Username too big", func(t *testing.T) {
 		username := rs.GenerateUnique(maxUsernameLength + 1)
-		err = users.CreateUser(username)
-		assert.Equal(UsernameExceededMaximumCharsError{username}, err)
+		err = users.CreateUser(ctx, username)
+		assert.ErrorIs(err, UsernameExceededMaximumCharsError{username})
 	})
 
 	t.Run("Username with invalid characters", func(t *testing.T) {
 		username := fmt.Sprintf("%s@", rs.GenerateUnique(maxUsernameLength-1))
-		err = users.CreateUser(username)
-		assert.Equal(InvalidUsernameError{username}, err)
+		err = users.CreateUser(ctx, username)
+		assert.ErrorIs(err, InvalidUsernameError{username})
 	})
 }
 
 func TestFollowUser(t *testing.T) {
+	ctx := context.Background()
 	assert := assertions.New(t)
 	rs := testrand.NewPseudoRandomString()
 	dbName := testdb.GenerateDBName()
 
-	db := storagedb.NewDatabase(dbName)
+	db := storagedb.NewDatabase(dbName, storagedb.DefaultPoolConfig())
 	err := db.InitializeDB()
 	defer testdb.DropDatabase(dbName)
-	assert.NoError(err)
+	if err != nil {
+		t.Fatalf("could not initialize database: %s", err)
+	}
+
+	pool, err := db.Connect()
+	defer db.Close()
+	if err != nil {
+		t.Fatalf("could not connect to database: %s", err)
+	}
 
-	users := NewUserBacked(db)
+	users := NewUserBacked(pool)
 
 	t.Run("Parallel follows", func(t *testing.T) {
 		// TODO: test fails for too many connections
@@ -69,13 +88,13 @@ func TestFollowUser(t *testing.T) {
 		usernames := make([]string, noUsers, noUsers)
 		for i := 0; i < noUsers; i++ {
 			usernames[i] = rs.GenerateUnique(maxUsernameLength)
-			err = users.CreateUser(usernames[i])
+			err = users.CreateUser(ctx, usernames[i])
 			assert.NoError(err)
 		}
 
 		var wg sync.WaitGroup
 		follow := func(userA, userB string, wg *sync.WaitGroup) {
-			err := users.FollowUser(userA, userB)
+			err := users.FollowUser(ctx, userA, userB)
 			assert.NoError(err)
 			wg.Done()
 		}
@@ -91,37 +110,46 @@ func TestFollowUser(t *testing.T) {
 
 	t.Run("Should not follow itself", func(t *testing.T) {
 		username := rs.GenerateUnique(maxUsernameLength)
-		err = users.FollowUser(username, username)
+		err = users.FollowUser(ctx, username, username)
 		assert.Error(err)
 	})
 
 	t.Run("Should fail if user already follows", func(t *testing.T) {
 		userA := rs.GenerateUnique(maxUsernameLength)
 		userB := rs.GenerateUnique(maxUsernameLength)
-		err = users.CreateUser(userA)
+		err = users.CreateUser(ctx, userA)
 		assert.NoError(err)
-		err = users.CreateUser(userB)
+		err = users.CreateUser(ctx, userB)
 		assert.NoError(err)
 
-		err = users.FollowUser(userA, userB)
+		err = users.FollowUser(ctx, userA, userB)
 		assert.NoError(err)
 
-		err = users.FollowUser(userA, userB)
+		err = users.FollowUser(ctx, userA, userB)
 		assert.Error(err)
 	})
 }
 
 func TestUnfollowUser(t *testing.T) {
+	ctx := context.Background()
 	assert := assertions.New(t)
 	rs := testrand.NewPseudoRandomString()
 	dbName := testdb.GenerateDBName()
 
-	db := storagedb.NewDatabase(dbName)
+	db := storagedb.NewDatabase(dbName, storagedb.DefaultPoolConfig())
 	err := db.InitializeDB()
 	defer testdb.DropDatabase(dbName)
-	assert.NoError(err)
+	if err != nil {
+		t.Fatalf("could not initialize database: %s", err)
+	}
 
-	users := NewUserBacked(db)
+	pool, err := db.Connect()
+	defer db.Close()
+	if err != nil {
+		t.Fatalf("could not connect to database: %s", err)
+	}
+
+	users := NewUserBacked(pool)
 
 	t.Run("Parallel unfollows", func(t *testing.T) {
 		// TODO: test fails for too many connections
@@ -129,13 +157,13 @@ func TestUnfollowUser(t *testing.T) {
 		usernames := make([]string, noUsers, noUsers)
 		for i := 0; i < noUsers; i++ {
 			usernames[i] = rs.GenerateUnique(maxUsernameLength)
-			err = users.CreateUser(usernames[i])
+			err = users.CreateUser(ctx, usernames[i])
 			assert.NoError(err)
 		}
 
 		var wg sync.WaitGroup
 		follow := func(userA, userB string, wg *sync.WaitGroup) {
-			err := users.FollowUser(userA, userB)
+			err := users.FollowUser(ctx, userA, userB)
 			assert.NoError(err)
 			wg.Done()
 		}
@@ -150,7 +178,7 @@ func TestUnfollowUser(t *testing.T) {
 
 		// after creating the list of followers, try to unfollow each pair
 		unfollow := func(userA, userB string, wg *sync.WaitGroup) {
-			err := users.UnfollowUser(userA, userB)
+			err := users.UnfollowUser(ctx, userA, userB)
 			assert.NoError(err)
 			wg.Done()
 		}
@@ -166,7 +194,7 @@ func TestUnfollowUser(t *testing.T) {
 
 	t.Run("Should not unfollow itself", func(t *testing.T) {
 		username := rs.GenerateUnique(maxUsernameLength)
-		err = users.UnfollowUser(username, username)
+		err = users.UnfollowUser(ctx, username, username)
 		assert.Error(err)
 	})
 
@@ -174,139 +202,175 @@ func TestUnfollowUser(t *testing.T) {
 		userA := rs.GenerateUnique(maxUsernameLength)
 		userB := rs.GenerateUnique(maxUsernameLength)
 		// if users do not exist, it will fail as well
-		err = users.UnfollowUser(userA, userB)
+		err = users.UnfollowUser(ctx, userA, userB)
 		assert.Error(err)
 	})
 }
 
 func TestIsFollowingUser(t *testing.T) {
+	ctx := context.Background()
 	assert := assertions.New(t)
 	rs := testrand.NewPseudoRandomString()
 	dbName := testdb.GenerateDBName()
 
-	db := storagedb.NewDatabase(dbName)
+	db := storagedb.NewDatabase(dbName, storagedb.DefaultPoolConfig())
 	err := db.InitializeDB()
 	defer testdb.DropDatabase(dbName)
-	assert.NoError(err)
+	if err != nil {
+		t.Fatalf("could not initialize database: %s", err)
+	}
 
-	users := NewUserBacked(db)
+	pool, err := db.Connect()
+	defer db.Close()
+	if err != nil {
+		t.Fatalf("could not connect to database: %s", err)
+	}
+
+	users := NewUserBacked(pool)
 
 	userA := rs.GenerateUnique(maxUsernameLength)
 	userB := rs.GenerateUnique(maxUsernameLength)
-	err = users.CreateUser(userA)
+	err = users.CreateUser(ctx, userA)
 	assert.NoError(err)
-	err = users.CreateUser(userB)
+	err = users.CreateUser(ctx, userB)
 	assert.NoError(err)
 
-	err = users.FollowUser(userA, userB)
+	err = users.FollowUser(ctx, userA, userB)
 	assert.NoError(err)
 
 	t.Run("Should be true if userA follows userB", func(t *testing.T) {
-		value, err := users.IsFollowingUser(userA, userB)
+		value, err := users.IsFollowingUser(ctx, userA, userB)
 		assert.NoError(err)
 		assert.True(value)
 	})
 
 	t.Run("Should be false if userB does not follow userA", func(t *testing.T) {
-		value, err := users.IsFollowingUser(userB, userA)
+		value, err := users.IsFollowingUser(ctx, userB, userA)
 		assert.NoError(err)
 		assert.False(value)
 	})
 }
 
 func TestCountUserPosts(t *testing.T) {
+	ctx := context.Background()
 	assert := assertions.New(t)
 	rs := testrand.NewPseudoRandomString()
 	dbName := testdb.GenerateDBName()
 
-	db := storagedb.NewDatabase(dbName)
+	db := storagedb.NewDatabase(dbName, storagedb.DefaultPoolConfig())
 	err := db.InitializeDB()
 	defer testdb.DropDatabase(dbName)
-	assert.NoError(err)
+	if err != nil {
+		t.Fatalf("could not initialize database: %s", err)
+	}
 
-	posts := posterr.NewPosterrBacked(db)
-	users := NewUserBacked(db)
+	pool, err := db.Connect()
+	defer db.Close()
+	if err != nil {
+		t.Fatalf("could not connect to database: %s", err)
+	}
+
+	posts := posterr.NewPosterrBacked(pool, posterr.DefaultEditWindow)
+	users := NewUserBacked(pool)
 
 	username := rs.GenerateUnique(14)
-	err = users.CreateUser(username)
+	err = users.CreateUser(ctx, username)
 	assert.NoError(err)
 
 	noPosts := 4
 	for i := 0; i < noPosts; i++ {
 		content := rs.GenerateAny(maxContentSize)
-		_, err = posts.WriteContent(username, content)
+		_, err = posts.WriteContent(ctx, username, content)
 		assert.NoError(err)
 	}
 
 	t.Run("Should match no. of posts for a username", func(t *testing.T) {
-		count, err := users.CountUserPosts(username)
+		count, err := users.CountUserPosts(ctx, username)
 		assert.NoError(err)
 		assert.Equal(noPosts, count)
 	})
 
 	t.Run("Should be empty if user does not have posts", func(t *testing.T) {
-		count, err := users.CountUserPosts("noSuchUser")
+		count, err := users.CountUserPosts(ctx, "noSuchUser")
 		assert.NoError(err)
 		assert.Empty(count)
 	})
 }
 
 func TestUserFollowers(t *testing.T) {
+	ctx := context.Background()
 	assert := assertions.New(t)
 	rs := testrand.NewPseudoRandomString()
 	dbName := testdb.GenerateDBName()
 
-	db := storagedb.NewDatabase(dbName)
+	db := storagedb.NewDatabase(dbName, storagedb.DefaultPoolConfig())
 	err := db.InitializeDB()
 	defer testdb.DropDatabase(dbName)
-	assert.NoError(err)
+	if err != nil {
+		t.Fatalf("could not initialize database: %s", err)
+	}
+
+	pool, err := db.Connect()
+	defer db.Close()
+	if err != nil {
+		t.Fatalf("could not connect to database: %s", err)
+	}
 
-	users := NewUserBacked(db)
+	users := NewUserBacked(pool)
 
 	noUsers := 10
 	usernames := make([]string, noUsers, noUsers)
 	for i := 0; i < noUsers; i++ {
 		usernames[i] = rs.GenerateUnique(maxUsernameLength)
-		err = users.CreateUser(usernames[i])
+		err = users.CreateUser(ctx, usernames[i])
 		assert.NoError(err)
 	}
 
 	for a := 0; a < noUsers-1; a++ {
-		err := users.FollowUser(usernames[0], usernames[a+1])
+		err := users.FollowUser(ctx, usernames[0], usernames[a+1])
 		assert.NoError(err)
 	}
 
-	count, err := users.CountUserFollowers(usernames[0])
+	count, err := users.CountUserFollowers(ctx, usernames[0])
 	assert.NoError(err)
 	assert.Equal(noUsers-1, count)
 }
 
 func TestUserFollowing(t *testing.T) {
+	ctx := context.Background()
 	assert := assertions.New(t)
 	rs := testrand.NewPseudoRandomString()
 	dbName := testdb.GenerateDBName()
 
-	db := storagedb.NewDatabase(dbName)
+	db := storagedb.NewDatabase(dbName, storagedb.DefaultPoolConfig())
 	err := db.InitializeDB()
 	defer testdb.DropDatabase(dbName)
-	assert.NoError(err)
+	if err != nil {
+		t.Fatalf("could not initialize database: %s", err)
+	}
+
+	pool, err := db.Connect()
+	defer db.Close()
+	if err != nil {
+		t.Fatalf("could not connect to database: %s", err)
+	}
 
-	users := NewUserBacked(db)
+	users := NewUserBacked(pool)
 
 	noUsers := 10
 	usernames := make([]string, noUsers, noUsers)
 	for i := 0; i < noUsers; i++ {
 		usernames[i] = rs.GenerateUnique(maxUsernameLength)
-		err = users.CreateUser(usernames[i])
+		err = users.CreateUser(ctx, usernames[i])
 		assert.NoError(err)
 	}
 
 	for a := 0; a < noUsers-1; a++ {
-		err := users.FollowUser(usernames[a+1], usernames[0])
+		err := users.FollowUser(ctx, usernames[a+1], usernames[0])
 		assert.NoError(err)
 	}
 
-	count, err := users.CountUserFollowing(usernames[0])
+	count, err := users.CountUserFollowing(ctx, usernames[0])
 	assert.NoError(err)
 	assert.Equal(noUsers-1, count)
 }
@@ -0,0 +1,568 @@
+package users
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"time"
+	"unicode/utf8"
+
+	"posterr/src/storage/memory"
+	"posterr/src/types"
+)
+
+const maxUsernameChars = 14
+
+type userMemory struct {
+	store *memory.Store
+	// A regex to validate usernames
+	rgx *regexp.Regexp
+}
+
+func NewUserMemory(store *memory.Store) *userMemory {
+	return &userMemory{
+		store: store,
+		rgx:   regexp.MustCompile(`^[` + types.UsernameCharset + `]+$`),
+	}
+}
+
+// CreateUser creates a user. Usernames must be alphanumeric, with 14 characters at most
+func (um *userMemory) CreateUser(ctx context.Context, username string) error {
+	if err := um.validateUsername(username); err != nil {
+		return err
+	}
+
+	um.store.Lock()
+	defer um.store.Unlock()
+
+	if _, exists := um.store.Users[username]; exists {
+		return UserAlreadyExistsError{username}
+	}
+
+	um.store.Users[username] = time.Now()
+	return nil
+}
+
+// RegisterUser creates a user along with the credentials of passwordHash.
+// Usernames must be alphanumeric, with 14 characters at most
+func (um *userMemory) RegisterUser(ctx context.Context, username, passwordHash string) error {
+	if err := um.validateUsername(username); err != nil {
+		return err
+	}
+
+	um.store.Lock()
+	defer um.store.Unlock()
+
+	if _, exists := um.store.Users[username]; exists {
+		return UserAlreadyExistsError{username}
+	}
+
+	um.store.Users[username] = time.Now()
+	um.store.Credentials[username] = passwordHash
+	return nil
+}
+
+// GetUserProfile returns a user detailed information
+func (um *userMemory) GetUserProfile(ctx context.Context, username string) (types.PosterrUserDetailed, error) {
+	um.store.RLock()
+	defer um.store.RUnlock()
+
+	joinedAt, exists := um.store.Users[username]
+	if !exists {
+		return types.PosterrUserDetailed{}, UserDoesNotExistError{username}
+	}
+
+	profile := um.store.Profiles[username]
+	return types.PosterrUserDetailed{
+		PosterrUser: types.PosterrUser{Username: username},
+		PosterrProfile: types.PosterrProfile{
+			DisplayName: profile.DisplayName,
+			Bio:         profile.Bio,
+			Location:    profile.Location,
+			Website:     profile.Website,
+			AvatarURL:   profile.AvatarURL,
+		},
+		Followers:  um.countFollowers(username),
+		Following:  um.countFollowing(username),
+		PostsCount: um.countPosts(username),
+		JoinedAt:   joinedAt,
+		Private:    um.isPrivate(username),
+	}, nil
+}
+
+// UpdateUserProfile changes the profile fields of a user set by update
+func (um *userMemory) UpdateUserProfile(ctx context.Context, username string, update types.PosterrProfileUpdate) error {
+	if err := validateProfileUpdate(update); err != nil {
+		return err
+	}
+
+	um.store.Lock()
+	defer um.store.Unlock()
+
+	if _, exists := um.store.Users[username]; !exists {
+		return UserDoesNotExistError{username}
+	}
+
+	profile := um.store.Profiles[username]
+	updateField(&profile.DisplayName, update.DisplayName)
+	updateField(&profile.Bio, update.Bio)
+	updateField(&profile.Location, update.Location)
+	updateField(&profile.Website, update.Website)
+	updateField(&profile.AvatarURL, update.AvatarURL)
+	um.store.Profiles[username] = profile
+
+	return nil
+}
+
+// CountUserPosts returns how many posts a user has made
+func (um *userMemory) CountUserPosts(ctx context.Context, username string) (int, error) {
+	um.store.RLock()
+	defer um.store.RUnlock()
+
+	return um.countPosts(username), nil
+}
+
+// CountUserFollowers returns how many followers a user has
+func (um *userMemory) CountUserFollowers(ctx context.Context, username string) (int, error) {
+	um.store.RLock()
+	defer um.store.RUnlock()
+
+	return um.countFollowers(username), nil
+}
+
+// CountUserFollowing returns how many users a user is following
+func (um *userMemory) CountUserFollowing(ctx context.Context, username string) (int, error) {
+	um.store.RLock()
+	defer um.store.RUnlock()
+
+	return um.countFollowing(username), nil
+}
+
+// ListFollowers returns a list of followers of a user, from the latest to the earliest follow.
+// Each call returns 20 users at most.
+func (um *userMemory) ListFollowers(ctx context.Context, username string, page types.Page) (types.PosterrFollowPage, error) {
+	um.store.RLock()
+	defer um.store.RUnlock()
+
+	if _, exists := um.store.Users[username]; !exists {
+		return types.PosterrFollowPage{}, UserDoesNotExistError{username}
+	}
+
+	follows := make([]types.PosterrFollow, 0, len(um.store.Followers[username]))
+	for follower, followedAt := range um.store.Followers[username] {
+		follows = append(follows, types.PosterrFollow{PosterrUser: types.PosterrUser{Username: follower}, FollowedAt: followedAt})
+	}
+
+	return selectFollows(follows, page)
+}
+
+// ListFollowing returns a list of users a user is following, from the latest to the earliest follow.
+// Each call returns 20 users at most.
+func (um *userMemory) ListFollowing(ctx context.Context, username string, page types.Page) (types.PosterrFollowPage, error) {
+	um.store.RLock()
+	defer um.store.RUnlock()
+
+	if _, exists := um.store.Users[username]; !exists {
+		return types.PosterrFollowPage{}, UserDoesNotExistError{username}
+	}
+
+	follows := make([]types.PosterrFollow, 0)
+	for followed, followers := range um.store.Followers {
+		if followedAt, exists := followers[username]; exists {
+			follows = append(follows, types.PosterrFollow{PosterrUser: types.PosterrUser{Username: followed}, FollowedAt: followedAt})
+		}
+	}
+
+	return selectFollows(follows, page)
+}
+
+// FollowUser ensures that username is followed by follower,
+// i.e., follower follows username. If username is private,
+// a request to follow them is made instead
+func (um *userMemory) FollowUser(ctx context.Context, username, follower string) error {
+	if username == follower {
+		return SelfFollowError{username}
+	}
+
+	um.store.Lock()
+	defer um.store.Unlock()
+
+	if um.store.IsFollowing(username, follower) {
+		return UserAlreadyFollowsError{username, follower}
+	}
+
+	if _, exists := um.store.Users[username]; !exists {
+		return UserDoesNotExistError{username}
+	}
+
+	if _, exists := um.store.Users[follower]; !exists {
+		return UserDoesNotExistError{follower}
+	}
+
+	if um.store.IsBlocking(username, follower) || um.store.IsBlocking(follower, username) {
+		return UserBlockedError{username, follower}
+	}
+
+	if um.isPrivate(username) {
+		if _, requested := um.store.FollowRequests[username][follower]; requested {
+			return FollowRequestAlreadyExistsError{username, follower}
+		}
+
+		if _, exists := um.store.FollowRequests[username]; !exists {
+			um.store.FollowRequests[username] = make(map[string]time.Time)
+		}
+		requestedAt := time.Now()
+		um.store.FollowRequests[username][follower] = requestedAt
+		um.store.AddNotification(memory.Notification{
+			Username:  username,
+			Kind:      types.FollowRequestNotification,
+			Actor:     follower,
+			CreatedAt: requestedAt,
+		})
+
+		return nil
+	}
+
+	// a request left by a user who is no longer private is fulfilled
+	delete(um.store.FollowRequests[username], follower)
+	followedAt := um.follow(username, follower)
+	um.store.AddNotification(memory.Notification{
+		Username:  username,
+		Kind:      types.FollowNotification,
+		Actor:     follower,
+		CreatedAt: followedAt,
+	})
+
+	return nil
+}
+
+// UnfollowUser ensures that username is unfollowed by follower,
+// i.e., follower unfollows username
+func (um *userMemory) UnfollowUser(ctx context.Context, username, follower string) error {
+	if username == follower {
+		return SelfFollowError{username}
+	}
+
+	um.store.Lock()
+	defer um.store.Unlock()
+
+	if !um.store.IsFollowing(username, follower) {
+		return UserDoesNotFollowError{username, follower}
+	}
+
+	delete(um.store.Followers[username], follower)
+	return nil
+}
+
+// BlockUser ensures that username is blocked by blocker. Follows and follow requests between
+// them are removed in both directions, and neither can follow the other afterwards
+func (um *userMemory) BlockUser(ctx context.Context, username, blocker string) error {
+	if username == blocker {
+		return SelfBlockError{username}
+	}
+
+	um.store.Lock()
+	defer um.store.Unlock()
+
+	if _, exists := um.store.Users[username]; !exists {
+		return UserDoesNotExistError{username}
+	}
+
+	if _, exists := um.store.Users[blocker]; !exists {
+		return UserDoesNotExistError{blocker}
+	}
+
+	if um.store.IsBlocking(blocker, username) {
+		return UserAlreadyBlockedError{username, blocker}
+	}
+
+	if _, exists := um.store.Blocks[blocker]; !exists {
+		um.store.Blocks[blocker] = make(map[string]time.Time)
+	}
+	um.store.Blocks[blocker][username] = time.Now()
+	delete(um.store.Followers[username], blocker)
+	delete(um.store.Followers[blocker], username)
+	delete(um.store.FollowRequests[username], blocker)
+	delete(um.store.FollowRequests[blocker], username)
+
+	return nil
+}
+
+// UnblockUser ensures that username is unblocked by blocker.
+// Follows removed by the block are not restored
+func (um *userMemory) UnblockUser(ctx context.Context, username, blocker string) error {
+	if username == blocker {
+		return SelfBlockError{username}
+	}
+
+	um.store.Lock()
+	defer um.store.Unlock()
+
+	if !um.store.IsBlocking(blocker, username) {
+		return UserNotBlockedError{username, blocker}
+	}
+
+	delete(um.store.Blocks[blocker], username)
+	return nil
+}
+
+// MuteUser ensures that username is muted by muter, which
+// hides the posts of username from the home page and searches of muter
+func (um *userMemory) MuteUser(ctx context.Context, username, muter string) error {
+	if username == muter {
+		return SelfMuteError{username}
+	}
+
+	um.store.Lock()
+	defer um.store.Unlock()
+
+	if _, exists := um.store.Users[username]; !exists {
+		return UserDoesNotExistError{username}
+	}
+
+	if _, exists := um.store.Users[muter]; !exists {
+		return UserDoesNotExistError{muter}
+	}
+
+	if um.store.IsMuting(muter, username) {
+		return UserAlreadyMutedError{username, muter}
+	}
+
+	if _, exists := um.store.Mutes[muter]; !exists {
+		um.store.Mutes[muter] = make(map[string]time.Time)
+	}
+	um.store.Mutes[muter][username] = time.Now()
+
+	return nil
+}
+
+// UnmuteUser ensures that username is unmuted by muter
+func (um *userMemory) UnmuteUser(ctx context.Context, username, muter string) error {
+	if username == muter {
+		return SelfMuteError{username}
+	}
+
+	um.store.Lock()
+	defer um.store.Unlock()
+
+	if !um.store.IsMuting(muter, username) {
+		return UserNotMutedError{username, muter}
+	}
+
+	delete(um.store.Mutes[muter], username)
+	return nil
+}
+
+// SetUserPrivate sets whether only the followers of username can see their posts.
+// Pending follow requests are kept when username is no longer private
+func (um *userMemory) SetUserPrivate(ctx context.Context, username string, private bool) error {
+	um.store.Lock()
+	defer um.store.Unlock()
+
+	if _, exists := um.store.Users[username]; !exists {
+		return UserDoesNotExistError{username}
+	}
+
+	if private {
+		um.store.PrivateUsers[username] = struct{}{}
+	} else {
+		delete(um.store.PrivateUsers, username)
+	}
+
+	return nil
+}
+
+// ListFollowRequests returns the pending requests to follow username, from the latest to the earliest.
+// Each call returns 20 requests at most.
+func (um *userMemory) ListFollowRequests(ctx context.Context, username string, page types.Page) (types.PosterrFollowRequestPage, error) {
+	um.store.RLock()
+	defer um.store.RUnlock()
+
+	if _, exists := um.store.Users[username]; !exists {
+		return types.PosterrFollowRequestPage{}, UserDoesNotExistError{username}
+	}
+
+	follows := make([]types.PosterrFollow, 0, len(um.store.FollowRequests[username]))
+	for requester, requestedAt := range um.store.FollowRequests[username] {
+		follows = append(follows, types.PosterrFollow{PosterrUser: types.PosterrUser{Username: requester}, FollowedAt: requestedAt})
+	}
+
+	followPage, err := selectFollows(follows, page)
+	if err != nil {
+		return types.PosterrFollowRequestPage{}, err
+	}
+
+	requests := make([]types.PosterrFollowRequest, 0, len(followPage.Users))
+	for _, follow := range followPage.Users {
+		requests = append(requests, types.PosterrFollowRequest{PosterrUser: follow.PosterrUser, RequestedAt: follow.FollowedAt})
+	}
+
+	return newRequestPage(requests), nil
+}
+
+// ApproveFollowRequest makes requester follow username, as requested
+func (um *userMemory) ApproveFollowRequest(ctx context.Context, username, requester string) error {
+	um.store.Lock()
+	defer um.store.Unlock()
+
+	if _, requested := um.store.FollowRequests[username][requester]; !requested {
+		return FollowRequestDoesNotExistError{username, requester}
+	}
+
+	delete(um.store.FollowRequests[username], requester)
+	if !um.store.IsFollowing(username, requester) {
+		um.follow(username, requester)
+	}
+
+	return nil
+}
+
+// RejectFollowRequest discards the request of requester to follow username
+func (um *userMemory) RejectFollowRequest(ctx context.Context, username, requester string) error {
+	um.store.Lock()
+	defer um.store.Unlock()
+
+	if _, requested := um.store.FollowRequests[username][requester]; !requested {
+		return FollowRequestDoesNotExistError{username, requester}
+	}
+
+	delete(um.store.FollowRequests[username], requester)
+	return nil
+}
+
+// IsFollowingUser checks if username is followed by follower,
+// i.e., follower follows username
+func (um *userMemory) IsFollowingUser(ctx context.Context, username, follower string) (bool, error) {
+	um.store.RLock()
+	defer um.store.RUnlock()
+
+	return um.store.IsFollowing(username, follower), nil
+}
+
+// GetUserRelations returns the users a given username follows, blocked and muted.
+// Users who don't exist have no relations
+func (um *userMemory) GetUserRelations(ctx context.Context, username string) (types.PosterrRelations, error) {
+	um.store.RLock()
+	defer um.store.RUnlock()
+
+	relations := types.PosterrRelations{
+		Following: make(map[string]struct{}),
+		Blocked:   make(map[string]struct{}),
+		Muted:     make(map[string]struct{}),
+	}
+	for followed, followers := range um.store.Followers {
+		if _, exists := followers[username]; exists {
+			relations.Following[followed] = struct{}{}
+		}
+	}
+	for blocked := range um.store.Blocks[username] {
+		relations.Blocked[blocked] = struct{}{}
+	}
+	for muted := range um.store.Mutes[username] {
+		relations.Muted[muted] = struct{}{}
+	}
+
+	return relations, nil
+}
+
+// updateField sets field to value, unless value is nil
+func updateField(field, value *string) {
+	if value != nil {
+		*field = *value
+	}
+}
+
+// validateUsername checks username the same way the users table does
+func (um *userMemory) validateUsername(username string) error {
+	if !um.rgx.MatchString(username) {
+		return InvalidUsernameError{username}
+	}
+
+	if utf8.RuneCountInString(username) > maxUsernameChars {
+		return UsernameExceededMaximumCharsError{username}
+	}
+
+	return nil
+}
+
+// follow makes follower follow username and returns the date they started following
+func (um *userMemory) follow(username, follower string) time.Time {
+	if _, exists := um.store.Followers[username]; !exists {
+		um.store.Followers[username] = make(map[string]time.Time)
+	}
+	followedAt := time.Now()
+	um.store.Followers[username][follower] = followedAt
+	return followedAt
+}
+
+func (um *userMemory) countPosts(username string) int {
+	var posts int
+	for _, post := range um.store.Posts {
+		if post.Username == username && !post.Deleted {
+			posts++
+		}
+	}
+	return posts
+}
+
+func (um *userMemory) isPrivate(username string) bool {
+	_, private := um.store.PrivateUsers[username]
+	return private
+}
+
+func (um *userMemory) countFollowers(username string) int {
+	return len(um.store.Followers[username])
+}
+
+func (um *userMemory) countFollowing(username string) int {
+	var following int
+	for _, followers := range um.store.Followers {
+		if _, exists := followers[username]; exists {
+			following++
+		}
+	}
+	return following
+}
+
+// selectFollows returns a page of follows from the newest to the oldest,
+// starting after the page cursor or skipping the page offset
+func selectFollows(follows []types.PosterrFollow, page types.Page) (types.PosterrFollowPage, error) {
+	offset := page.Offset
+	after := func(follow types.PosterrFollow) bool { return true }
+	if len(page.Cursor) > 0 {
+		c, err := decodeCursor(page.Cursor)
+		if err != nil {
+			return types.PosterrFollowPage{}, err
+		}
+
+		offset = 0
+		after = func(follow types.PosterrFollow) bool { return c.isAfter(follow.FollowedAt, follow.Username) }
+	}
+
+	sort.Slice(follows, func(i, j int) bool {
+		if !follows[i].FollowedAt.Equal(follows[j].FollowedAt) {
+			return follows[i].FollowedAt.After(follows[j].FollowedAt)
+		}
+		return follows[i].Username > follows[j].Username
+	})
+
+	selected := make([]types.PosterrFollow, 0)
+	for _, follow := range follows {
+		if len(selected) == followPageLimit {
+			break
+		}
+
+		if !after(follow) {
+			continue
+		}
+
+		if offset > 0 {
+			offset--
+			continue
+		}
+
+		selected = append(selected, follow)
+	}
+
+	return newPage(selected), nil
+}
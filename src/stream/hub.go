@@ -0,0 +1,159 @@
+package stream
+
+import (
+	"sync"
+
+	"posterr/src/types"
+)
+
+const (
+	// DefaultHistorySize is how many events are kept to resume subscriptions
+	DefaultHistorySize = 1024
+	// subscriptionBuffer is how many events a subscriber may fall behind before it is dropped
+	subscriptionBuffer = 64
+)
+
+// Event is a new post, identified by a sequence number
+// which increases with each post published to a Hub
+type Event struct {
+	ID   uint64
+	Post types.PosterrContent
+	// The authors of the post and of its embedded posts who were private when it was published,
+	// whose posts are only delivered to themselves and their followers
+	Private map[string]struct{}
+}
+
+// Subscription receives the events published to a Hub. Events is closed
+// once the subscription is cancelled or the subscriber falls behind
+type Subscription struct {
+	Events <-chan Event
+	// Set if some of the events after the resumed one are no longer kept,
+	// so the subscriber must reload the posts it may have missed
+	Missed bool
+	// Signaled whenever the relations of the subscriber to other users change,
+	// so that they are reloaded. Pending signals are merged into one
+	Refresh <-chan struct{}
+
+	username string
+	events   chan Event
+	refresh  chan struct{}
+}
+
+// Hub is an in-process publisher of new posts to its subscribers.
+// Its history is lost on restart, so events are only resumable within a process
+type Hub struct {
+	sync.Mutex
+	lastId      uint64
+	history     []Event
+	historySize int
+	subscribers map[*Subscription]struct{}
+}
+
+func NewHub(historySize int) *Hub {
+	return &Hub{
+		history:     make([]Event, 0, historySize),
+		historySize: historySize,
+		subscribers: make(map[*Subscription]struct{}),
+	}
+}
+
+// Publish sends a post, along with its private authors, to every subscriber.
+// Subscribers which fell behind are dropped, so that publishing never blocks
+func (h *Hub) Publish(post types.PosterrContent, private map[string]struct{}) {
+	h.Lock()
+	defer h.Unlock()
+
+	h.lastId++
+	event := Event{ID: h.lastId, Post: post, Private: private}
+	if len(h.history) == h.historySize {
+		h.history = append(h.history[:0], h.history[1:]...)
+	}
+	h.history = append(h.history, event)
+
+	for subscription := range h.subscribers {
+		select {
+		case subscription.events <- event:
+		default:
+			h.drop(subscription)
+		}
+	}
+}
+
+// Subscribe returns a subscription of username to the posts published from now on.
+// If resume is set, the kept events after lastEventId are sent first
+func (h *Hub) Subscribe(username string, lastEventId uint64, resume bool) *Subscription {
+	h.Lock()
+	defer h.Unlock()
+
+	replay := make([]Event, 0)
+	var missed bool
+	if resume {
+		// event ids restart along with the process
+		missed = lastEventId > h.lastId
+		for _, event := range h.history {
+			if event.ID > lastEventId {
+				replay = append(replay, event)
+			}
+		}
+
+		if !missed && len(replay) > 0 && replay[0].ID != lastEventId+1 {
+			missed, replay = true, replay[:0]
+		}
+	}
+
+	events := make(chan Event, len(replay)+subscriptionBuffer)
+	for _, event := range replay {
+		events <- event
+	}
+
+	refresh := make(chan struct{}, 1)
+	subscription := &Subscription{
+		Events:   events,
+		Missed:   missed,
+		Refresh:  refresh,
+		username: username,
+		events:   events,
+		refresh:  refresh,
+	}
+	h.subscribers[subscription] = struct{}{}
+
+	return subscription
+}
+
+// Refresh signals the subscriptions of usernames that their relations changed.
+// Signaling never blocks, since a pending signal already causes a reload
+func (h *Hub) Refresh(usernames ...string) {
+	h.Lock()
+	defer h.Unlock()
+
+	refreshed := make(map[string]struct{}, len(usernames))
+	for _, username := range usernames {
+		refreshed[username] = struct{}{}
+	}
+
+	for subscription := range h.subscribers {
+		if _, exists := refreshed[subscription.username]; !exists {
+			continue
+		}
+
+		select {
+		case subscription.refresh <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Unsubscribe cancels a subscription, closing its events
+func (h *Hub) Unsubscribe(subscription *Subscription) {
+	h.Lock()
+	defer h.Unlock()
+
+	h.drop(subscription)
+}
+
+func (h *Hub) drop(subscription *Subscription) {
+	if _, exists := h.subscribers[subscription]; exists {
+		delete(h.subscribers, subscription)
+		close(subscription.events)
+	}
+}
@@ -0,0 +1,96 @@
+package stream
+
+import (
+	"fmt"
+	"testing"
+
+	"posterr/src/types"
+
+	assertions "github.com/stretchr/testify/assert"
+)
+
+func TestHub(t *testing.T) {
+	assert := assertions.New(t)
+
+	publish := func(hub *Hub, noPosts int) {
+		for i := 0; i < noPosts; i++ {
+			hub.Publish(types.PosterrContent{ID: fmt.Sprintf("post-%d", i)}, nil)
+		}
+	}
+
+	eventIdsOf := func(subscription *Subscription) []uint64 {
+		eventIds := make([]uint64, 0)
+		for len(subscription.Events) > 0 {
+			eventIds = append(eventIds, (<-subscription.Events).ID)
+		}
+		return eventIds
+	}
+
+	t.Run("Should send the posts published after subscribing", func(t *testing.T) {
+		hub := NewHub(DefaultHistorySize)
+		publish(hub, 2)
+
+		subscription := hub.Subscribe("user", 0, false)
+		publish(hub, 2)
+		assert.False(subscription.Missed)
+		assert.Equal([]uint64{3, 4}, eventIdsOf(subscription))
+	})
+
+	t.Run("Should resume after the last event", func(t *testing.T) {
+		hub := NewHub(DefaultHistorySize)
+		publish(hub, 3)
+
+		subscription := hub.Subscribe("user", 1, true)
+		publish(hub, 1)
+		assert.False(subscription.Missed)
+		assert.Equal([]uint64{2, 3, 4}, eventIdsOf(subscription))
+	})
+
+	t.Run("Should report missed events which are no longer kept", func(t *testing.T) {
+		hub := NewHub(2)
+		publish(hub, 4)
+
+		subscription := hub.Subscribe("user", 1, true)
+		assert.True(subscription.Missed)
+		assert.Empty(eventIdsOf(subscription))
+
+		// events of a previous process
+		subscription = hub.Subscribe("user", 10, true)
+		assert.True(subscription.Missed)
+		assert.Empty(eventIdsOf(subscription))
+	})
+
+	t.Run("Should drop subscribers which fell behind", func(t *testing.T) {
+		hub := NewHub(DefaultHistorySize)
+		subscription := hub.Subscribe("user", 0, false)
+		publish(hub, subscriptionBuffer+1)
+
+		received := 0
+		for range subscription.Events {
+			received++
+		}
+		assert.Equal(subscriptionBuffer, received)
+	})
+
+	t.Run("Should close the events once unsubscribed", func(t *testing.T) {
+		hub := NewHub(DefaultHistorySize)
+		subscription := hub.Subscribe("user", 0, false)
+		hub.Unsubscribe(subscription)
+		hub.Unsubscribe(subscription)
+		publish(hub, 1)
+
+		_, open := <-subscription.Events
+		assert.False(open)
+	})
+
+	t.Run("Should signal the subscriptions of refreshed users once", func(t *testing.T) {
+		hub := NewHub(DefaultHistorySize)
+		subscription := hub.Subscribe("user", 0, false)
+		other := hub.Subscribe("other", 0, false)
+		hub.Refresh("user", "nobody")
+		hub.Refresh("user")
+
+		assert.Len(subscription.Refresh, 1)
+		assert.Empty(other.Refresh)
+	})
+}
@@ -0,0 +1,111 @@
+package stream
+
+import (
+	"context"
+
+	"posterr/src/types"
+
+	"github.com/sirupsen/logrus"
+)
+
+// publishingPosterr publishes the posts written through a types.Posterr to a Hub
+type publishingPosterr struct {
+	types.Posterr
+	users  types.Users
+	hub    *Hub
+	logger *logrus.Entry
+}
+
+func NewPublishingPosterr(posts types.Posterr, users types.Users, hub *Hub) *publishingPosterr {
+	return &publishingPosterr{
+		Posterr: posts,
+		users:   users,
+		hub:     hub,
+		logger:  logrus.WithFields(logrus.Fields{"stream": "Posterr"}),
+	}
+}
+
+// WriteContent creates a post for a given username, publishes it and returns the postId.
+func (pp *publishingPosterr) WriteContent(ctx context.Context, username, postContent string) (string, error) {
+	postId, err := pp.Posterr.WriteContent(ctx, username, postContent)
+	if err != nil {
+		return "", err
+	}
+
+	pp.publish(ctx, postId)
+	return postId, nil
+}
+
+// WriteRepostContent creates a repost for a given username, publishes it and returns the postId.
+func (pp *publishingPosterr) WriteRepostContent(ctx context.Context, username, repostedId string) (string, error) {
+	postId, err := pp.Posterr.WriteRepostContent(ctx, username, repostedId)
+	if err != nil {
+		return "", err
+	}
+
+	pp.publish(ctx, postId)
+	return postId, nil
+}
+
+// WriteQuoteRepostContent creates a quote repost for a given username, publishes it and returns the postId.
+func (pp *publishingPosterr) WriteQuoteRepostContent(ctx context.Context, username, postContent, repostedId string) (string, error) {
+	postId, err := pp.Posterr.WriteQuoteRepostContent(ctx, username, postContent, repostedId)
+	if err != nil {
+		return "", err
+	}
+
+	pp.publish(ctx, postId)
+	return postId, nil
+}
+
+// WriteReplyContent creates a reply to replyId for a given username, publishes it and returns the postId.
+// Replies are published as well, since they are listed in the home page as any other post
+func (pp *publishingPosterr) WriteReplyContent(ctx context.Context, username, postContent, replyId string) (string, error) {
+	postId, err := pp.Posterr.WriteReplyContent(ctx, username, postContent, replyId)
+	if err != nil {
+		return "", err
+	}
+
+	pp.publish(ctx, postId)
+	return postId, nil
+}
+
+// publish sends a post, as listed in the home page, to the hub. The post is already
+// written, so failing to read it back is only logged, and the post is left for polling
+func (pp *publishingPosterr) publish(ctx context.Context, postId string) {
+	post, err := pp.Posterr.GetContent(ctx, postId)
+	if err != nil {
+		pp.logger.Errorf("Could not publish post %s: %s", postId, err)
+		return
+	}
+
+	private, err := pp.privateAuthors(ctx, post)
+	if err != nil {
+		pp.logger.Errorf("Could not publish post %s: %s", postId, err)
+		return
+	}
+
+	pp.hub.Publish(post, private)
+}
+
+// privateAuthors returns the private users among the authors of post and of its embedded posts
+func (pp *publishingPosterr) privateAuthors(ctx context.Context, post types.PosterrContent) (map[string]struct{}, error) {
+	private := make(map[string]struct{})
+	for embedded := &post; embedded != nil; embedded = embedded.Reposted {
+		// tombstones have no author
+		if embedded.Deleted {
+			continue
+		}
+
+		user, err := pp.users.GetUserProfile(ctx, embedded.Username)
+		if err != nil {
+			return nil, err
+		}
+
+		if user.Private {
+			private[embedded.Username] = struct{}{}
+		}
+	}
+
+	return private, nil
+}
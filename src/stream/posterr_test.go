@@ -0,0 +1,50 @@
+package stream
+
+import (
+	"context"
+	"testing"
+
+	"posterr/src/storage/memory"
+	storageposterr "posterr/src/storage/posterr"
+	storageusers "posterr/src/storage/users"
+
+	assertions "github.com/stretchr/testify/assert"
+)
+
+func TestPublishingPosterr(t *testing.T) {
+	ctx := context.Background()
+	assert := assertions.New(t)
+
+	store := memory.NewStore()
+	users := storageusers.NewUserMemory(store)
+	hub := NewHub(DefaultHistorySize)
+	posts := NewPublishingPosterr(storageposterr.NewPosterrMemory(store, storageposterr.DefaultEditWindow), users, hub)
+
+	assert.NoError(users.CreateUser(ctx, "author"))
+	assert.NoError(users.CreateUser(ctx, "replier"))
+	subscription := hub.Subscribe("author", 0, false)
+
+	t.Run("Should publish posts, reposts, quote reposts and replies", func(t *testing.T) {
+		postId, err := posts.WriteContent(ctx, "author", "a post")
+		assert.NoError(err)
+		repostId, err := posts.WriteRepostContent(ctx, "replier", postId)
+		assert.NoError(err)
+		quoteId, err := posts.WriteQuoteRepostContent(ctx, "replier", "a quote", postId)
+		assert.NoError(err)
+		replyId, err := posts.WriteReplyContent(ctx, "replier", "a reply", postId)
+		assert.NoError(err)
+
+		published := make([]string, 0)
+		for len(subscription.Events) > 0 {
+			published = append(published, (<-subscription.Events).Post.ID)
+		}
+		assert.Equal([]string{postId, repostId, quoteId, replyId}, published)
+	})
+
+	t.Run("Should not publish posts which could not be written", func(t *testing.T) {
+		_, err := posts.WriteReplyContent(ctx, "replier", "a reply", "8bef15ac-27ae-4349-b357-2edc27445c51")
+		assert.Error(err)
+		assert.Empty(subscription.Events)
+	})
+
+}
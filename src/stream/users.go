@@ -0,0 +1,92 @@
+package stream
+
+import (
+	"context"
+
+	"posterr/src/types"
+)
+
+// refreshingUsers signals the subscribers of a Hub whose relations
+// are changed through a types.Users, so that they reload them
+type refreshingUsers struct {
+	types.Users
+	hub *Hub
+}
+
+func NewRefreshingUsers(users types.Users, hub *Hub) *refreshingUsers {
+	return &refreshingUsers{
+		Users: users,
+		hub:   hub,
+	}
+}
+
+// FollowUser ensures that targetUser is followed by currentUser and refreshes currentUser.
+func (ru *refreshingUsers) FollowUser(ctx context.Context, targetUser, currentUser string) error {
+	if err := ru.Users.FollowUser(ctx, targetUser, currentUser); err != nil {
+		return err
+	}
+
+	ru.hub.Refresh(currentUser)
+	return nil
+}
+
+// UnfollowUser ensures that targetUser is unfollowed by currentUser and refreshes currentUser.
+func (ru *refreshingUsers) UnfollowUser(ctx context.Context, targetUser, currentUser string) error {
+	if err := ru.Users.UnfollowUser(ctx, targetUser, currentUser); err != nil {
+		return err
+	}
+
+	ru.hub.Refresh(currentUser)
+	return nil
+}
+
+// BlockUser ensures that targetUser is blocked by currentUser and refreshes both,
+// since the follows between them are removed.
+func (ru *refreshingUsers) BlockUser(ctx context.Context, targetUser, currentUser string) error {
+	if err := ru.Users.BlockUser(ctx, targetUser, currentUser); err != nil {
+		return err
+	}
+
+	ru.hub.Refresh(targetUser, currentUser)
+	return nil
+}
+
+// UnblockUser ensures that targetUser is unblocked by currentUser and refreshes currentUser.
+func (ru *refreshingUsers) UnblockUser(ctx context.Context, targetUser, currentUser string) error {
+	if err := ru.Users.UnblockUser(ctx, targetUser, currentUser); err != nil {
+		return err
+	}
+
+	ru.hub.Refresh(currentUser)
+	return nil
+}
+
+// MuteUser ensures that targetUser is muted by currentUser and refreshes currentUser.
+func (ru *refreshingUsers) MuteUser(ctx context.Context, targetUser, currentUser string) error {
+	if err := ru.Users.MuteUser(ctx, targetUser, currentUser); err != nil {
+		return err
+	}
+
+	ru.hub.Refresh(currentUser)
+	return nil
+}
+
+// UnmuteUser ensures that targetUser is unmuted by currentUser and refreshes currentUser.
+func (ru *refreshingUsers) UnmuteUser(ctx context.Context, targetUser, currentUser string) error {
+	if err := ru.Users.UnmuteUser(ctx, targetUser, currentUser); err != nil {
+		return err
+	}
+
+	ru.hub.Refresh(currentUser)
+	return nil
+}
+
+// ApproveFollowRequest makes requester follow username and refreshes requester.
+func (ru *refreshingUsers) ApproveFollowRequest(ctx context.Context, username, requester string) error {
+	if err := ru.Users.ApproveFollowRequest(ctx, username, requester); err != nil {
+		return err
+	}
+
+	ru.hub.Refresh(requester)
+	return nil
+}
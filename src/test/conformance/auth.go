@@ -0,0 +1,103 @@
+package conformance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	storageauth "posterr/src/storage/auth"
+	testrand "posterr/src/test/rand"
+
+	assertions "github.com/stretchr/testify/assert"
+)
+
+// RunAuth checks that the backends returned by factory
+// follow the documented behavior of types.Auth
+func RunAuth(t *testing.T, factory Factory) {
+	t.Run("SetPassword", func(t *testing.T) { testSetPassword(t, factory) })
+	t.Run("Sessions", func(t *testing.T) { testSessions(t, factory) })
+}
+
+func testSetPassword(t *testing.T, factory Factory) {
+	ctx := context.Background()
+	assert := assertions.New(t)
+	rs := testrand.NewPseudoRandomString()
+
+	backends, release := factory(t)
+	defer release()
+
+	username := createUsers(t, backends, rs, 1)[0]
+
+	t.Run("Should set and replace a password", func(t *testing.T) {
+		assert.NoError(backends.Auth.SetPassword(ctx, username, "correct horse"))
+		assert.NoError(backends.Auth.SetPassword(ctx, username, "battery staple"))
+
+		_, err := backends.Auth.Login(ctx, username, "correct horse")
+		assert.ErrorAs(err, &storageauth.InvalidCredentialsError{})
+
+		_, err = backends.Auth.Login(ctx, username, "battery staple")
+		assert.NoError(err)
+	})
+
+	t.Run("Should not set a password too short", func(t *testing.T) {
+		err := backends.Auth.SetPassword(ctx, username, "short")
+		assert.ErrorAs(err, &storageauth.PasswordTooShortError{})
+	})
+
+	t.Run("Should not set the password of a non existing user", func(t *testing.T) {
+		err := backends.Auth.SetPassword(ctx, "notauser", "correct horse")
+		assert.ErrorAs(err, &storageauth.UserDoesNotExistError{})
+	})
+}
+
+func testSessions(t *testing.T, factory Factory) {
+	ctx := context.Background()
+	assert := assertions.New(t)
+	rs := testrand.NewPseudoRandomString()
+
+	backends, release := factory(t)
+	defer release()
+
+	usernames := createUsers(t, backends, rs, 2)
+	assert.NoError(backends.Auth.SetPassword(ctx, usernames[0], "correct horse"))
+
+	t.Run("Should authenticate a token until logout", func(t *testing.T) {
+		session, err := backends.Auth.Login(ctx, usernames[0], "correct horse")
+		assert.NoError(err)
+		assert.NotEmpty(session.Token)
+		assert.True(session.ExpiresAt.After(time.Now()))
+
+		username, err := backends.Auth.Authenticate(ctx, session.Token)
+		assert.NoError(err)
+		assert.Equal(usernames[0], username)
+
+		assert.NoError(backends.Auth.Logout(ctx, session.Token))
+
+		_, err = backends.Auth.Authenticate(ctx, session.Token)
+		assert.ErrorAs(err, &storageauth.InvalidTokenError{})
+	})
+
+	t.Run("Should issue a different token per login", func(t *testing.T) {
+		first, err := backends.Auth.Login(ctx, usernames[0], "correct horse")
+		assert.NoError(err)
+		second, err := backends.Auth.Login(ctx, usernames[0], "correct horse")
+		assert.NoError(err)
+		assert.NotEqual(first.Token, second.Token)
+	})
+
+	t.Run("Should not login with invalid credentials", func(t *testing.T) {
+		_, err := backends.Auth.Login(ctx, usernames[0], "wrong password")
+		assert.ErrorAs(err, &storageauth.InvalidCredentialsError{})
+
+		_, err = backends.Auth.Login(ctx, usernames[1], "correct horse")
+		assert.ErrorAs(err, &storageauth.InvalidCredentialsError{})
+
+		_, err = backends.Auth.Login(ctx, "notauser", "correct horse")
+		assert.ErrorAs(err, &storageauth.InvalidCredentialsError{})
+	})
+
+	t.Run("Should not authenticate an unknown token", func(t *testing.T) {
+		_, err := backends.Auth.Authenticate(ctx, "sometoken")
+		assert.ErrorAs(err, &storageauth.InvalidTokenError{})
+	})
+}
@@ -0,0 +1,34 @@
+package conformance
+
+import (
+	"testing"
+	"time"
+
+	"posterr/src/types"
+)
+
+const (
+	maxContentSize    = 777
+	maxUsernameLength = 14
+	maxDailyPosts     = 5
+	homePageSize      = 10
+	profilePageSize   = 5
+	searchPageSize    = 10
+	followPageSize    = 20
+	maxBioChars       = 160
+	bookmarkPageSize  = 10
+	// editWindow is short, so that tests can wait for it to expire
+	editWindow = time.Second
+)
+
+// Backends groups the storage backends under test.
+// All of them must share the same underlying storage
+type Backends struct {
+	Posts         types.Posterr
+	Users         types.Users
+	Auth          types.Auth
+	Notifications types.Notifications
+}
+
+// Factory returns empty backends and a function releasing them
+type Factory func(t *testing.T) (Backends, func())
@@ -0,0 +1,53 @@
+package conformance
+
+import (
+	"testing"
+
+	storageauth "posterr/src/storage/auth"
+	storagedb "posterr/src/storage/db"
+	storagememory "posterr/src/storage/memory"
+	storagenotifications "posterr/src/storage/notifications"
+	storageposterr "posterr/src/storage/posterr"
+	storageusers "posterr/src/storage/users"
+	testdb "posterr/src/test/db"
+)
+
+// MemoryFactory returns in-memory backends sharing a new store
+func MemoryFactory(t *testing.T) (Backends, func()) {
+	store := storagememory.NewStore()
+	return Backends{
+		Posts:         storageposterr.NewPosterrMemory(store, editWindow),
+		Users:         storageusers.NewUserMemory(store),
+		Auth:          storageauth.NewAuthMemory(store),
+		Notifications: storagenotifications.NewNotificationsMemory(store),
+	}, func() {}
+}
+
+// PostgresFactory returns Postgres-backed backends using a new database,
+// which is dropped on release
+func PostgresFactory(t *testing.T) (Backends, func()) {
+	dbName := testdb.GenerateDBName()
+	db := storagedb.NewDatabase(dbName, storagedb.DefaultPoolConfig())
+	release := func() {
+		db.Close()
+		testdb.DropDatabase(dbName)
+	}
+
+	if err := db.InitializeDB(); err != nil {
+		release()
+		t.Fatalf("could not initialize database: %s", err)
+	}
+
+	pool, err := db.Connect()
+	if err != nil {
+		release()
+		t.Fatalf("could not connect to database: %s", err)
+	}
+
+	return Backends{
+		Posts:         storageposterr.NewPosterrBacked(pool, editWindow),
+		Users:         storageusers.NewUserBacked(pool),
+		Auth:          storageauth.NewAuthBacked(pool),
+		Notifications: storagenotifications.NewNotificationsBacked(pool),
+	}, release
+}
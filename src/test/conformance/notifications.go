@@ -0,0 +1,219 @@
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	storagenotifications "posterr/src/storage/notifications"
+	testrand "posterr/src/test/rand"
+	"posterr/src/types"
+
+	assertions "github.com/stretchr/testify/assert"
+)
+
+const notificationsPageSize = 20
+
+// RunNotifications checks that the backends returned by factory
+// follow the documented behavior of types.Notifications
+func RunNotifications(t *testing.T, factory Factory) {
+	t.Run("NotificationEntries", func(t *testing.T) { testNotificationEntries(t, factory) })
+	t.Run("ReadNotifications", func(t *testing.T) { testReadNotifications(t, factory) })
+	t.Run("NotificationsPagination", func(t *testing.T) { testNotificationsPagination(t, factory) })
+}
+
+func testNotificationEntries(t *testing.T, factory Factory) {
+	ctx := context.Background()
+	assert := assertions.New(t)
+	rs := testrand.NewPseudoRandomString()
+
+	backends, release := factory(t)
+	defer release()
+
+	usernames := createUsers(t, backends, rs, 3)
+	author, actor := usernames[0], usernames[1]
+
+	postId, err := backends.Posts.WriteContent(ctx, author, fmt.Sprintf("hello @%s", actor))
+	assert.NoError(err)
+	assert.NoError(backends.Users.FollowUser(ctx, author, actor))
+	repostId, err := backends.Posts.WriteRepostContent(ctx, actor, postId)
+	assert.NoError(err)
+	quoteId, err := backends.Posts.WriteQuoteRepostContent(ctx, actor, "so true", postId)
+	assert.NoError(err)
+	// the author is notified of the reply, but not again of being mentioned by it
+	replyId, err := backends.Posts.WriteReplyContent(ctx, actor, fmt.Sprintf("@%s hi", author), postId)
+	assert.NoError(err)
+	mentionId, err := backends.Posts.WriteContent(ctx, actor, fmt.Sprintf("cc @%s", author))
+	assert.NoError(err)
+
+	t.Run("Should notify follows, reposts, quotes, replies and mentions", func(t *testing.T) {
+		page, err := backends.Notifications.ListNotifications(ctx, author, false, types.Page{})
+		assert.NoError(err)
+		assert.Equal(5, page.Unread)
+		assert.Equal([]types.PosterrNotification{
+			{Kind: types.MentionNotification, Actor: actor, PostId: mentionId},
+			{Kind: types.ReplyNotification, Actor: actor, PostId: replyId},
+			{Kind: types.QuoteNotification, Actor: actor, PostId: quoteId},
+			{Kind: types.RepostNotification, Actor: actor, PostId: repostId},
+			{Kind: types.FollowNotification, Actor: actor},
+		}, withoutIdsAndDates(page.Notifications))
+		assertNotificationsNewestFirst(t, page.Notifications)
+
+		page, err = backends.Notifications.ListNotifications(ctx, actor, false, types.Page{})
+		assert.NoError(err)
+		assert.Equal([]types.PosterrNotification{
+			{Kind: types.MentionNotification, Actor: author, PostId: postId},
+		}, withoutIdsAndDates(page.Notifications))
+	})
+
+	t.Run("Should not notify users of their own actions", func(t *testing.T) {
+		otherId, err := backends.Posts.WriteReplyContent(ctx, author, fmt.Sprintf("thanks @%s and @%s", author, usernames[2]), postId)
+		assert.NoError(err)
+
+		page, err := backends.Notifications.ListNotifications(ctx, author, false, types.Page{})
+		assert.NoError(err)
+		assert.Len(page.Notifications, 5)
+
+		page, err = backends.Notifications.ListNotifications(ctx, usernames[2], false, types.Page{})
+		assert.NoError(err)
+		assert.Equal([]types.PosterrNotification{
+			{Kind: types.MentionNotification, Actor: author, PostId: otherId},
+		}, withoutIdsAndDates(page.Notifications))
+	})
+
+	t.Run("Should hide notifications of deleted posts", func(t *testing.T) {
+		assert.NoError(backends.Posts.DeleteContent(ctx, actor, quoteId))
+
+		page, err := backends.Notifications.ListNotifications(ctx, author, false, types.Page{})
+		assert.NoError(err)
+		assert.Equal(4, page.Unread)
+		assert.NotContains(postIdsOfNotifications(page.Notifications), quoteId)
+	})
+}
+
+func testReadNotifications(t *testing.T, factory Factory) {
+	ctx := context.Background()
+	assert := assertions.New(t)
+	rs := testrand.NewPseudoRandomString()
+
+	backends, release := factory(t)
+	defer release()
+
+	usernames := createUsers(t, backends, rs, 4)
+	for _, follower := range usernames[1:] {
+		assert.NoError(backends.Users.FollowUser(ctx, usernames[0], follower))
+	}
+
+	page, err := backends.Notifications.ListNotifications(ctx, usernames[0], false, types.Page{})
+	assert.NoError(err)
+	assert.Len(page.Notifications, 3)
+	notifications := page.Notifications
+
+	t.Run("Should mark a notification as read", func(t *testing.T) {
+		assert.NoError(backends.Notifications.MarkNotificationRead(ctx, usernames[0], notifications[1].ID))
+		// marking it again keeps it read
+		assert.NoError(backends.Notifications.MarkNotificationRead(ctx, usernames[0], notifications[1].ID))
+
+		page, err := backends.Notifications.ListNotifications(ctx, usernames[0], false, types.Page{})
+		assert.NoError(err)
+		assert.Equal(2, page.Unread)
+		if assert.Len(page.Notifications, 3) {
+			assert.False(page.Notifications[0].Read)
+			assert.True(page.Notifications[1].Read)
+			assert.False(page.Notifications[2].Read)
+		}
+	})
+
+	t.Run("Should list only unread notifications", func(t *testing.T) {
+		page, err := backends.Notifications.ListNotifications(ctx, usernames[0], true, types.Page{})
+		assert.NoError(err)
+		assert.Equal(2, page.Unread)
+		if assert.Len(page.Notifications, 2) {
+			assert.Equal(notifications[0].ID, page.Notifications[0].ID)
+			assert.Equal(notifications[2].ID, page.Notifications[1].ID)
+		}
+	})
+
+	t.Run("Should not mark notifications of other users", func(t *testing.T) {
+		err := backends.Notifications.MarkNotificationRead(ctx, usernames[1], notifications[0].ID)
+		assert.ErrorAs(err, &storagenotifications.NotificationDoesNotExistError{})
+
+		err = backends.Notifications.MarkNotificationRead(ctx, usernames[0], notifications[0].ID+1000)
+		assert.ErrorAs(err, &storagenotifications.NotificationDoesNotExistError{})
+	})
+
+	t.Run("Should mark every notification as read", func(t *testing.T) {
+		assert.NoError(backends.Notifications.MarkAllNotificationsRead(ctx, usernames[0]))
+
+		page, err := backends.Notifications.ListNotifications(ctx, usernames[0], true, types.Page{})
+		assert.NoError(err)
+		assert.Zero(page.Unread)
+		assert.Empty(page.Notifications)
+
+		page, err = backends.Notifications.ListNotifications(ctx, usernames[0], false, types.Page{})
+		assert.NoError(err)
+		assert.Len(page.Notifications, 3)
+	})
+}
+
+func testNotificationsPagination(t *testing.T, factory Factory) {
+	ctx := context.Background()
+	assert := assertions.New(t)
+	rs := testrand.NewPseudoRandomString()
+
+	backends, release := factory(t)
+	defer release()
+
+	usernames := createUsers(t, backends, rs, notificationsPageSize+3)
+	for _, follower := range usernames[1:] {
+		assert.NoError(backends.Users.FollowUser(ctx, usernames[0], follower))
+	}
+
+	t.Run("Should paginate by cursor", func(t *testing.T) {
+		page, err := backends.Notifications.ListNotifications(ctx, usernames[0], false, types.Page{})
+		assert.NoError(err)
+		assert.Len(page.Notifications, notificationsPageSize)
+		assert.Equal(notificationsPageSize+2, page.Unread)
+		assert.NotEmpty(page.NextCursor)
+		assertNotificationsNewestFirst(t, page.Notifications)
+
+		nextPage, err := backends.Notifications.ListNotifications(ctx, usernames[0], false, types.Page{Cursor: page.NextCursor})
+		assert.NoError(err)
+		assert.Len(nextPage.Notifications, 2)
+		assert.Empty(nextPage.NextCursor)
+		if assert.NotEmpty(nextPage.Notifications) {
+			assert.Less(nextPage.Notifications[0].ID, page.Notifications[notificationsPageSize-1].ID)
+		}
+	})
+
+	t.Run("Should not accept an invalid cursor", func(t *testing.T) {
+		_, err := backends.Notifications.ListNotifications(ctx, usernames[0], false, types.Page{Cursor: "notacursor"})
+		assert.ErrorAs(err, &storagenotifications.InvalidCursorError{})
+	})
+}
+
+// withoutIdsAndDates returns notifications without the fields which are generated by the backends
+func withoutIdsAndDates(notifications []types.PosterrNotification) []types.PosterrNotification {
+	result := make([]types.PosterrNotification, 0, len(notifications))
+	for _, notification := range notifications {
+		notification.ID = 0
+		notification.CreatedAt = time.Time{}
+		result = append(result, notification)
+	}
+	return result
+}
+
+func postIdsOfNotifications(notifications []types.PosterrNotification) []string {
+	postIds := make([]string, 0, len(notifications))
+	for _, notification := range notifications {
+		postIds = append(postIds, notification.PostId)
+	}
+	return postIds
+}
+
+func assertNotificationsNewestFirst(t *testing.T, notifications []types.PosterrNotification) {
+	for i := 1; i < len(notifications); i++ {
+		assertions.Greater(t, notifications[i-1].ID, notifications[i].ID)
+	}
+}
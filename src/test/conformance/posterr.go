@@ -0,0 +1,1558 @@
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	storageposterr "posterr/src/storage/posterr"
+	testrand "posterr/src/test/rand"
+	"posterr/src/types"
+
+	assertions "github.com/stretchr/testify/assert"
+)
+
+// RunPosterr checks that the backends returned by factory
+// follow the documented behavior of types.Posterr
+func RunPosterr(t *testing.T, factory Factory) {
+	t.Run("WriteContent", func(t *testing.T) { testWriteContent(t, factory) })
+	t.Run("DailyPostsLimit", func(t *testing.T) { testDailyPostsLimit(t, factory) })
+	t.Run("Repost", func(t *testing.T) { testRepost(t, factory) })
+	t.Run("Replies", func(t *testing.T) { testReplies(t, factory) })
+	t.Run("ListHomePageContent", func(t *testing.T) { testListHomePageContent(t, factory) })
+	t.Run("ListProfileContent", func(t *testing.T) { testListProfileContent(t, factory) })
+	t.Run("SearchContent", func(t *testing.T) { testSearchContent(t, factory) })
+	t.Run("SearchOperators", func(t *testing.T) { testSearchOperators(t, factory) })
+	t.Run("SearchRelevance", func(t *testing.T) { testSearchRelevance(t, factory) })
+	t.Run("DeleteContent", func(t *testing.T) { testDeleteContent(t, factory) })
+	t.Run("HydratedReposts", func(t *testing.T) { testHydratedReposts(t, factory) })
+	t.Run("CursorPagination", func(t *testing.T) { testCursorPagination(t, factory) })
+	t.Run("Hashtags", func(t *testing.T) { testHashtags(t, factory) })
+	t.Run("TrendingHashtags", func(t *testing.T) { testTrendingHashtags(t, factory) })
+	t.Run("Mentions", func(t *testing.T) { testMentions(t, factory) })
+	t.Run("Likes", func(t *testing.T) { testLikes(t, factory) })
+	t.Run("BlocksAndMutes", func(t *testing.T) { testBlocksAndMutes(t, factory) })
+	t.Run("PrivateAccounts", func(t *testing.T) { testPrivateAccounts(t, factory) })
+	t.Run("PrivateContent", func(t *testing.T) { testPrivateContent(t, factory) })
+	t.Run("EditContent", func(t *testing.T) { testEditContent(t, factory) })
+	t.Run("Bookmarks", func(t *testing.T) { testBookmarks(t, factory) })
+}
+
+func testWriteContent(t *testing.T, factory Factory) {
+	ctx := context.Background()
+	assert := assertions.New(t)
+	rs := testrand.NewPseudoRandomString()
+
+	backends, release := factory(t)
+	defer release()
+
+	username := createUsers(t, backends, rs, 1)[0]
+
+	t.Run("Should post if content is just right", func(t *testing.T) {
+		postId, err := backends.Posts.WriteContent(ctx, username, rs.GenerateAny(maxContentSize))
+		assert.NoError(err)
+		assert.NotEmpty(postId)
+	})
+
+	t.Run("Should not post if content is too long", func(t *testing.T) {
+		_, err := backends.Posts.WriteContent(ctx, username, rs.GenerateAny(maxContentSize+1))
+		assert.ErrorAs(err, &storageposterr.PostExceededMaximumCharsError{})
+	})
+
+	t.Run("Should not post if username does not exist", func(t *testing.T) {
+		_, err := backends.Posts.WriteContent(ctx, "notauser", "hello there")
+		assert.ErrorAs(err, &storageposterr.UserDoesNotExistError{})
+	})
+}
+
+func testDailyPostsLimit(t *testing.T, factory Factory) {
+	ctx := context.Background()
+	assert := assertions.New(t)
+	rs := testrand.NewPseudoRandomString()
+
+	backends, release := factory(t)
+	defer release()
+
+	usernames := createUsers(t, backends, rs, 2)
+	postId := writePosts(t, backends, rs, usernames[0], maxDailyPosts)[0]
+
+	t.Run("Should not post, repost, quote repost or reply after the limit", func(t *testing.T) {
+		_, err := backends.Posts.WriteContent(ctx, usernames[0], "hello there")
+		assert.ErrorAs(err, &storageposterr.ExceededMaximumDailyPostsError{})
+
+		_, err = backends.Posts.WriteRepostContent(ctx, usernames[0], postId)
+		assert.ErrorAs(err, &storageposterr.ExceededMaximumDailyPostsError{})
+
+		_, err = backends.Posts.WriteQuoteRepostContent(ctx, usernames[0], "check this out", postId)
+		assert.ErrorAs(err, &storageposterr.ExceededMaximumDailyPostsError{})
+
+		_, err = backends.Posts.WriteReplyContent(ctx, usernames[0], "general kenobi", postId)
+		assert.ErrorAs(err, &storageposterr.ExceededMaximumDailyPostsError{})
+	})
+
+	t.Run("Should not limit other users", func(t *testing.T) {
+		_, err := backends.Posts.WriteContent(ctx, usernames[1], "hello there")
+		assert.NoError(err)
+	})
+}
+
+func testRepost(t *testing.T, factory Factory) {
+	ctx := context.Background()
+	assert := assertions.New(t)
+	rs := testrand.NewPseudoRandomString()
+
+	backends, release := factory(t)
+	defer release()
+
+	username := createUsers(t, backends, rs, 1)[0]
+	postId := writePosts(t, backends, rs, username, 1)[0]
+
+	t.Run("Should repost an existing post", func(t *testing.T) {
+		_, err := backends.Posts.WriteRepostContent(ctx, username, postId)
+		assert.NoError(err)
+	})
+
+	t.Run("Should quote repost an existing post", func(t *testing.T) {
+		_, err := backends.Posts.WriteQuoteRepostContent(ctx, username, "check this out", postId)
+		assert.NoError(err)
+	})
+
+	t.Run("Should not repost a non existing post", func(t *testing.T) {
+		_, err := backends.Posts.WriteRepostContent(ctx, username, "somePostId")
+		assert.ErrorAs(err, &storageposterr.PostIdDoesNotExistError{})
+
+		_, err = backends.Posts.WriteQuoteRepostContent(ctx, username, "check this out", "somePostId")
+		assert.ErrorAs(err, &storageposterr.PostIdDoesNotExistError{})
+	})
+
+	t.Run("Should not repost if username does not exist", func(t *testing.T) {
+		_, err := backends.Posts.WriteRepostContent(ctx, "notauser", postId)
+		assert.ErrorAs(err, &storageposterr.UserDoesNotExistError{})
+	})
+}
+
+func testReplies(t *testing.T, factory Factory) {
+	ctx := context.Background()
+	assert := assertions.New(t)
+	rs := testrand.NewPseudoRandomString()
+
+	backends, release := factory(t)
+	defer release()
+
+	usernames := createUsers(t, backends, rs, 2)
+	postId := writePosts(t, backends, rs, usernames[0], 1)[0]
+
+	replyId, err := backends.Posts.WriteReplyContent(ctx, usernames[1], "first reply", postId)
+	assert.NoError(err)
+	nestedReplyId, err := backends.Posts.WriteReplyContent(ctx, usernames[0], "nested reply", replyId)
+	assert.NoError(err)
+	secondReplyId, err := backends.Posts.WriteReplyContent(ctx, usernames[1], "second reply", postId)
+	assert.NoError(err)
+
+	t.Run("Should list the reply tree in ascending order", func(t *testing.T) {
+		replies, err := backends.Posts.ListReplies(ctx, postId, types.Ascending)
+		assert.NoError(err)
+		if assert.Len(replies, 2) {
+			assert.Equal(replyId, replies[0].ID)
+			assert.Equal(postId, replies[0].ReplyId)
+			assert.Equal(secondReplyId, replies[1].ID)
+			assert.Empty(replies[1].Replies)
+			if assert.Len(replies[0].Replies, 1) {
+				assert.Equal(nestedReplyId, replies[0].Replies[0].ID)
+				assert.Equal("nested reply", replies[0].Replies[0].Content)
+			}
+		}
+	})
+
+	t.Run("Should list the reply tree in descending order", func(t *testing.T) {
+		replies, err := backends.Posts.ListReplies(ctx, postId, types.Descending)
+		assert.NoError(err)
+		if assert.Len(replies, 2) {
+			assert.Equal(secondReplyId, replies[0].ID)
+			assert.Equal(replyId, replies[1].ID)
+		}
+	})
+
+	t.Run("Should list no replies of a post without replies", func(t *testing.T) {
+		replies, err := backends.Posts.ListReplies(ctx, nestedReplyId, types.Ascending)
+		assert.NoError(err)
+		assert.Empty(replies)
+	})
+
+	t.Run("Should not reply to a non existing post", func(t *testing.T) {
+		_, err := backends.Posts.WriteReplyContent(ctx, usernames[0], "hello?", "somePostId")
+		assert.ErrorAs(err, &storageposterr.PostIdDoesNotExistError{})
+	})
+
+	t.Run("Should not list replies of a non existing post", func(t *testing.T) {
+		_, err := backends.Posts.ListReplies(ctx, "somePostId", types.Ascending)
+		assert.ErrorAs(err, &storageposterr.PostIdDoesNotExistError{})
+	})
+
+	t.Run("Should not list replies in an invalid order", func(t *testing.T) {
+		_, err := backends.Posts.ListReplies(ctx, postId, "sideways")
+		assert.ErrorAs(err, &storageposterr.InvalidOrderError{})
+	})
+}
+
+func testListHomePageContent(t *testing.T, factory Factory) {
+	ctx := context.Background()
+	assert := assertions.New(t)
+	rs := testrand.NewPseudoRandomString()
+
+	backends, release := factory(t)
+	defer release()
+
+	usernames := createUsers(t, backends, rs, 3)
+	reader, followed, other := usernames[0], usernames[1], usernames[2]
+	assert.NoError(backends.Users.FollowUser(ctx, followed, reader))
+
+	// posts are written alternately, so that both toggles need to skip posts
+	postIds := make([]string, 0)
+	for i := 0; i < maxDailyPosts; i++ {
+		postIds = append(postIds, writePosts(t, backends, rs, followed, 1)...)
+		postIds = append(postIds, writePosts(t, backends, rs, other, 1)...)
+		postIds = append(postIds, writePosts(t, backends, rs, reader, 1)...)
+	}
+
+	t.Run("Should list every post from the newest to the oldest", func(t *testing.T) {
+		firstPage, err := backends.Posts.ListHomePageContent(ctx, reader, types.Page{}, types.All)
+		assert.NoError(err)
+		assert.Len(firstPage.Posts, homePageSize)
+
+		secondPage, err := backends.Posts.ListHomePageContent(ctx, reader, types.Page{Offset: homePageSize}, types.All)
+		assert.NoError(err)
+		assert.Len(secondPage.Posts, len(postIds)-homePageSize)
+
+		assert.Equal(reversed(postIds), postIdsOf(append(firstPage.Posts, secondPage.Posts...)))
+	})
+
+	t.Run("Should list only posts from followed users", func(t *testing.T) {
+		page, err := backends.Posts.ListHomePageContent(ctx, reader, types.Page{}, types.Following)
+		assert.NoError(err)
+		assert.Len(page.Posts, maxDailyPosts)
+		for _, post := range page.Posts {
+			assert.Equal(followed, post.Username)
+		}
+		assertNewestFirst(assert, page.Posts)
+
+		page, err = backends.Posts.ListHomePageContent(ctx, reader, types.Page{Offset: 2}, types.Following)
+		assert.NoError(err)
+		assert.Len(page.Posts, maxDailyPosts-2)
+	})
+
+	t.Run("Should list nothing for users who follow no one", func(t *testing.T) {
+		page, err := backends.Posts.ListHomePageContent(ctx, other, types.Page{}, types.Following)
+		assert.NoError(err)
+		assert.Empty(page.Posts)
+	})
+}
+
+func testListProfileContent(t *testing.T, factory Factory) {
+	ctx := context.Background()
+	assert := assertions.New(t)
+	rs := testrand.NewPseudoRandomString()
+
+	backends, release := factory(t)
+	defer release()
+
+	usernames := createUsers(t, backends, rs, 2)
+	postIds := writePosts(t, backends, rs, usernames[0], maxDailyPosts-1)
+	writePosts(t, backends, rs, usernames[1], maxDailyPosts)
+	repostId, err := backends.Posts.WriteRepostContent(ctx, usernames[0], postIds[0])
+	assert.NoError(err)
+	postIds = append(postIds, repostId)
+
+	t.Run("Should list posts and reposts of a user from the newest to the oldest", func(t *testing.T) {
+		page, err := backends.Posts.ListProfileContent(ctx, usernames[0], types.Page{})
+		assert.NoError(err)
+		assert.Len(page.Posts, profilePageSize)
+		assert.Equal(reversed(postIds), postIdsOf(page.Posts))
+		assert.Equal(postIds[0], page.Posts[0].RepostedId)
+		assert.Empty(page.Posts[0].Content)
+	})
+
+	t.Run("Should paginate by 5 posts", func(t *testing.T) {
+		page, err := backends.Posts.ListProfileContent(ctx, usernames[0], types.Page{Offset: 3})
+		assert.NoError(err)
+		assert.Equal(reversed(postIds)[3:], postIdsOf(page.Posts))
+
+		page, err = backends.Posts.ListProfileContent(ctx, usernames[0], types.Page{Offset: profilePageSize})
+		assert.NoError(err)
+		assert.Empty(page.Posts)
+	})
+}
+
+func testSearchContent(t *testing.T, factory Factory) {
+	ctx := context.Background()
+	assert := assertions.New(t)
+	rs := testrand.NewPseudoRandomString()
+
+	backends, release := factory(t)
+	defer release()
+
+	usernames := createUsers(t, backends, rs, 3)
+	term := rs.GenerateUnique(12)
+
+	matchIds := make([]string, 0)
+	for _, username := range usernames {
+		for i := 0; i < maxDailyPosts-1; i++ {
+			postId, err := backends.Posts.WriteContent(ctx, username, fmt.Sprintf("post %d about %s", i, term))
+			assert.NoError(err)
+			matchIds = append(matchIds, postId)
+		}
+	}
+
+	// reposts have no content of their own, so they only match by the post they repost
+	otherId, err := backends.Posts.WriteContent(ctx, usernames[1], "nothing to see here")
+	assert.NoError(err)
+	_, err = backends.Posts.WriteRepostContent(ctx, usernames[0], otherId)
+	assert.NoError(err)
+	query := types.SearchQuery{Terms: []string{term}, Sort: types.Recent}
+
+	t.Run("Should return 10 matches by default from the newest to the oldest", func(t *testing.T) {
+		page, err := backends.Posts.SearchContent(ctx, query, 0, types.Page{})
+		assert.NoError(err)
+		assert.Len(page.Posts, searchPageSize)
+		assert.Equal(reversed(matchIds)[:searchPageSize], postIdsOf(page.Posts))
+	})
+
+	t.Run("Should honor limit and offset", func(t *testing.T) {
+		page, err := backends.Posts.SearchContent(ctx, query, 3, types.Page{})
+		assert.NoError(err)
+		assert.Equal(reversed(matchIds)[:3], postIdsOf(page.Posts))
+
+		page, err = backends.Posts.SearchContent(ctx, query, 5, types.Page{Offset: 10})
+		assert.NoError(err)
+		assert.Equal(reversed(matchIds)[10:], postIdsOf(page.Posts))
+	})
+
+	t.Run("Should return nothing if no post matches", func(t *testing.T) {
+		page, err := backends.Posts.SearchContent(ctx, types.SearchQuery{Terms: []string{rs.GenerateUnique(12)}, Sort: types.Recent}, 0, types.Page{})
+		assert.NoError(err)
+		assert.Empty(page.Posts)
+	})
+}
+
+func testSearchOperators(t *testing.T, factory Factory) {
+	ctx := context.Background()
+	assert := assertions.New(t)
+	rs := testrand.NewPseudoRandomString()
+
+	backends, release := factory(t)
+	defer release()
+
+	usernames := createUsers(t, backends, rs, 2)
+	first, second, third := rs.GenerateUnique(12), rs.GenerateUnique(12), rs.GenerateUnique(12)
+
+	inOrderId, err := backends.Posts.WriteContent(ctx, usernames[0], fmt.Sprintf("%s %s", first, second))
+	assert.NoError(err)
+	reversedId, err := backends.Posts.WriteContent(ctx, usernames[0], fmt.Sprintf("%s, %s!", second, first))
+	assert.NoError(err)
+	withThirdId, err := backends.Posts.WriteContent(ctx, usernames[1], fmt.Sprintf("%s %s %s", first, third, second))
+	assert.NoError(err)
+
+	search := func(query types.SearchQuery) []string {
+		query.Sort = types.Recent
+		page, err := backends.Posts.SearchContent(ctx, query, 0, types.Page{})
+		assert.NoError(err)
+		return postIdsOf(page.Posts)
+	}
+
+	t.Run("Should match every term regardless of their case or order", func(t *testing.T) {
+		postIds := search(types.SearchQuery{Terms: []string{strings.ToUpper(second), strings.ToLower(first)}})
+		assert.Equal([]string{withThirdId, reversedId, inOrderId}, postIds)
+	})
+
+	t.Run("Should match phrases only if their words are in sequence", func(t *testing.T) {
+		postIds := search(types.SearchQuery{Phrases: []string{fmt.Sprintf("%s %s", second, first)}})
+		assert.Equal([]string{reversedId}, postIds)
+	})
+
+	t.Run("Should not match excluded terms or phrases", func(t *testing.T) {
+		postIds := search(types.SearchQuery{Terms: []string{first}, Exclude: []string{third}})
+		assert.Equal([]string{reversedId, inOrderId}, postIds)
+
+		postIds = search(types.SearchQuery{Terms: []string{first}, Exclude: []string{fmt.Sprintf("%s %s", first, second)}})
+		assert.Equal([]string{withThirdId, reversedId}, postIds)
+	})
+
+	t.Run("Should only match posts of a given username", func(t *testing.T) {
+		postIds := search(types.SearchQuery{Terms: []string{first}, From: usernames[1]})
+		assert.Equal([]string{withThirdId}, postIds)
+	})
+
+	t.Run("Should only match posts created within the dates", func(t *testing.T) {
+		now := time.Now()
+		postIds := search(types.SearchQuery{Terms: []string{first}, Since: now.Add(-time.Hour), Until: now.Add(time.Hour)})
+		assert.Len(postIds, 3)
+
+		assert.Empty(search(types.SearchQuery{Terms: []string{first}, Since: now.Add(time.Hour)}))
+		assert.Empty(search(types.SearchQuery{Terms: []string{first}, Until: now.Add(-time.Hour)}))
+	})
+
+	t.Run("Should match reposts by the post they repost", func(t *testing.T) {
+		repostId, err := backends.Posts.WriteRepostContent(ctx, usernames[1], withThirdId)
+		assert.NoError(err)
+
+		postIds := search(types.SearchQuery{Terms: []string{third}})
+		assert.Equal([]string{repostId, withThirdId}, postIds)
+
+		assert.NoError(backends.Posts.DeleteContent(ctx, usernames[1], withThirdId))
+		assert.Empty(search(types.SearchQuery{Terms: []string{third}}))
+	})
+
+	t.Run("Should not accept an invalid sort", func(t *testing.T) {
+		_, err := backends.Posts.SearchContent(ctx, types.SearchQuery{Terms: []string{first}, Sort: "popular"}, 0, types.Page{})
+		assert.ErrorAs(err, &storageposterr.InvalidSortError{})
+	})
+}
+
+func testSearchRelevance(t *testing.T, factory Factory) {
+	ctx := context.Background()
+	assert := assertions.New(t)
+	rs := testrand.NewPseudoRandomString()
+
+	backends, release := factory(t)
+	defer release()
+
+	usernames := createUsers(t, backends, rs, 2)
+	term := rs.GenerateUnique(12)
+
+	mostRelevantId, err := backends.Posts.WriteContent(ctx, usernames[0], fmt.Sprintf("%s %s %s", term, term, term))
+	assert.NoError(err)
+	relevantId, err := backends.Posts.WriteContent(ctx, usernames[0], fmt.Sprintf("%s is here", term))
+	assert.NoError(err)
+	// matches in the post it reposts are worth half
+	repostId, err := backends.Posts.WriteRepostContent(ctx, usernames[1], relevantId)
+	assert.NoError(err)
+
+	query := types.SearchQuery{Terms: []string{term}, Sort: types.Relevance}
+
+	t.Run("Should return the most relevant posts first", func(t *testing.T) {
+		page, err := backends.Posts.SearchContent(ctx, query, 0, types.Page{})
+		assert.NoError(err)
+		assert.Equal([]string{mostRelevantId, relevantId, repostId}, postIdsOf(page.Posts))
+	})
+
+	t.Run("Should paginate by cursor", func(t *testing.T) {
+		page, err := backends.Posts.SearchContent(ctx, query, 2, types.Page{})
+		assert.NoError(err)
+		assert.Equal([]string{mostRelevantId, relevantId}, postIdsOf(page.Posts))
+		assert.NotEmpty(page.NextCursor)
+
+		page, err = backends.Posts.SearchContent(ctx, query, 2, types.Page{Cursor: page.NextCursor})
+		assert.NoError(err)
+		assert.Equal([]string{repostId}, postIdsOf(page.Posts))
+		assert.Empty(page.NextCursor)
+	})
+
+	t.Run("Should not mix cursors of different sorts", func(t *testing.T) {
+		page, err := backends.Posts.SearchContent(ctx, query, 1, types.Page{})
+		assert.NoError(err)
+
+		recent := types.SearchQuery{Terms: []string{term}, Sort: types.Recent}
+		_, err = backends.Posts.SearchContent(ctx, recent, 1, types.Page{Cursor: page.NextCursor})
+		assert.ErrorAs(err, &storageposterr.InvalidCursorError{})
+	})
+}
+
+func testDeleteContent(t *testing.T, factory Factory) {
+	ctx := context.Background()
+	assert := assertions.New(t)
+	rs := testrand.NewPseudoRandomString()
+
+	backends, release := factory(t)
+	defer release()
+
+	usernames := createUsers(t, backends, rs, 2)
+	postIds := writePosts(t, backends, rs, usernames[0], 2)
+	repostId, err := backends.Posts.WriteRepostContent(ctx, usernames[1], postIds[0])
+	assert.NoError(err)
+	replyId, err := backends.Posts.WriteReplyContent(ctx, usernames[1], "general kenobi", postIds[0])
+	assert.NoError(err)
+	nestedReplyId, err := backends.Posts.WriteReplyContent(ctx, usernames[0], "you are a bold one", replyId)
+	assert.NoError(err)
+
+	t.Run("Should not delete a post of another user", func(t *testing.T) {
+		err := backends.Posts.DeleteContent(ctx, usernames[1], postIds[0])
+		assert.ErrorAs(err, &storageposterr.NotPostAuthorError{})
+	})
+
+	t.Run("Should delete a post and keep its reposts", func(t *testing.T) {
+		assert.NoError(backends.Posts.DeleteContent(ctx, usernames[0], postIds[0]))
+
+		page, err := backends.Posts.ListProfileContent(ctx, usernames[0], types.Page{})
+		assert.NoError(err)
+		assert.NotContains(postIdsOf(page.Posts), postIds[0])
+
+		page, err = backends.Posts.ListProfileContent(ctx, usernames[1], types.Page{})
+		assert.NoError(err)
+		assert.Contains(postIdsOf(page.Posts), repostId)
+
+		profile, err := backends.Users.GetUserProfile(ctx, usernames[0])
+		assert.NoError(err)
+		assert.Equal(2, profile.PostsCount)
+	})
+
+	t.Run("Should keep a deleted reply as a tombstone", func(t *testing.T) {
+		assert.NoError(backends.Posts.DeleteContent(ctx, usernames[1], replyId))
+
+		replies, err := backends.Posts.ListReplies(ctx, postIds[0], types.Ascending)
+		assert.NoError(err)
+		if assert.Len(replies, 1) {
+			assert.Equal(replyId, replies[0].ID)
+			assert.True(replies[0].Deleted)
+			assert.Empty(replies[0].Content)
+			if assert.Len(replies[0].Replies, 1) {
+				assert.Equal(nestedReplyId, replies[0].Replies[0].ID)
+				assert.False(replies[0].Replies[0].Deleted)
+			}
+		}
+	})
+
+	t.Run("Should not repost or reply a deleted post", func(t *testing.T) {
+		_, err := backends.Posts.WriteRepostContent(ctx, usernames[1], postIds[0])
+		assert.ErrorAs(err, &storageposterr.PostIdDoesNotExistError{})
+
+		_, err = backends.Posts.WriteReplyContent(ctx, usernames[1], "too late", postIds[0])
+		assert.ErrorAs(err, &storageposterr.PostIdDoesNotExistError{})
+	})
+
+	t.Run("Should not delete a post twice", func(t *testing.T) {
+		err := backends.Posts.DeleteContent(ctx, usernames[0], postIds[0])
+		assert.ErrorAs(err, &storageposterr.PostIdDoesNotExistError{})
+	})
+
+	t.Run("Should not free daily posts", func(t *testing.T) {
+		// two posts and a reply were written so far
+		writePosts(t, backends, rs, usernames[0], 2)
+
+		_, err := backends.Posts.WriteContent(ctx, usernames[0], "over the limit")
+		assert.ErrorAs(err, &storageposterr.ExceededMaximumDailyPostsError{})
+	})
+}
+
+func testHydratedReposts(t *testing.T, factory Factory) {
+	ctx := context.Background()
+	assert := assertions.New(t)
+	rs := testrand.NewPseudoRandomString()
+
+	backends, release := factory(t)
+	defer release()
+
+	usernames := createUsers(t, backends, rs, 3)
+	originalId, err := backends.Posts.WriteContent(ctx, usernames[0], "hello there")
+	assert.NoError(err)
+	quoteId, err := backends.Posts.WriteQuoteRepostContent(ctx, usernames[1], "general kenobi", originalId)
+	assert.NoError(err)
+	repostId, err := backends.Posts.WriteRepostContent(ctx, usernames[2], quoteId)
+	assert.NoError(err)
+	deepRepostId, err := backends.Posts.WriteRepostContent(ctx, usernames[2], repostId)
+	assert.NoError(err)
+
+	t.Run("Should get a post with its reposted posts embedded", func(t *testing.T) {
+		post, err := backends.Posts.GetContent(ctx, repostId)
+		assert.NoError(err)
+		assert.Equal(usernames[2], post.Username)
+		if assert.NotNil(post.Reposted) {
+			assert.Equal(quoteId, post.Reposted.ID)
+			assert.Equal(usernames[1], post.Reposted.Username)
+			assert.Equal("general kenobi", post.Reposted.Content)
+			assert.False(post.Reposted.CreatedAt.IsZero())
+			if assert.NotNil(post.Reposted.Reposted) {
+				assert.Equal(originalId, post.Reposted.Reposted.ID)
+				assert.Equal("hello there", post.Reposted.Reposted.Content)
+				assert.Nil(post.Reposted.Reposted.Reposted)
+			}
+		}
+	})
+
+	t.Run("Should embed reposted posts up to the depth limit", func(t *testing.T) {
+		post, err := backends.Posts.GetContent(ctx, deepRepostId)
+		assert.NoError(err)
+		if assert.NotNil(post.Reposted) && assert.NotNil(post.Reposted.Reposted) {
+			assert.Equal(quoteId, post.Reposted.Reposted.ID)
+			assert.Equal(originalId, post.Reposted.Reposted.RepostedId)
+			assert.Nil(post.Reposted.Reposted.Reposted)
+		}
+	})
+
+	t.Run("Should embed reposted posts in feeds", func(t *testing.T) {
+		page, err := backends.Posts.ListProfileContent(ctx, usernames[1], types.Page{})
+		assert.NoError(err)
+		if assert.Len(page.Posts, 1) && assert.NotNil(page.Posts[0].Reposted) {
+			assert.Equal(originalId, page.Posts[0].Reposted.ID)
+		}
+
+		page, err = backends.Posts.ListHomePageContent(ctx, "", types.Page{}, types.All)
+		assert.NoError(err)
+		for _, post := range page.Posts {
+			assert.Equal(len(post.RepostedId) > 0, post.Reposted != nil)
+		}
+	})
+
+	t.Run("Should embed a deleted reposted post as unavailable", func(t *testing.T) {
+		assert.NoError(backends.Posts.DeleteContent(ctx, usernames[0], originalId))
+
+		post, err := backends.Posts.GetContent(ctx, quoteId)
+		assert.NoError(err)
+		if assert.NotNil(post.Reposted) {
+			assert.Equal(originalId, post.Reposted.ID)
+			assert.True(post.Reposted.Deleted)
+			assert.Empty(post.Reposted.Username)
+			assert.Empty(post.Reposted.Content)
+		}
+	})
+
+	t.Run("Should not get a deleted or non existing post", func(t *testing.T) {
+		_, err := backends.Posts.GetContent(ctx, originalId)
+		assert.ErrorAs(err, &storageposterr.PostIdDoesNotExistError{})
+
+		_, err = backends.Posts.GetContent(ctx, "8bef15ac-27ae-4349-b357-2edc27445c51")
+		assert.ErrorAs(err, &storageposterr.PostIdDoesNotExistError{})
+	})
+}
+
+func testCursorPagination(t *testing.T, factory Factory) {
+	ctx := context.Background()
+	assert := assertions.New(t)
+	rs := testrand.NewPseudoRandomString()
+
+	backends, release := factory(t)
+	defer release()
+
+	usernames := createUsers(t, backends, rs, 3)
+	postIds := make([]string, 0)
+	for _, username := range usernames[:2] {
+		postIds = append(postIds, writePosts(t, backends, rs, username, maxDailyPosts-1)...)
+	}
+
+	t.Run("Should not skip or duplicate posts written between pages", func(t *testing.T) {
+		firstPage, err := backends.Posts.ListHomePageContent(ctx, "", types.Page{}, types.All)
+		assert.NoError(err)
+		assert.Len(firstPage.Posts, homePageSize-2)
+		assert.Empty(firstPage.NextCursor)
+
+		profilePage, err := backends.Posts.ListProfileContent(ctx, usernames[0], types.Page{})
+		assert.NoError(err)
+		assert.Equal(reversed(postIds[:maxDailyPosts-1]), postIdsOf(profilePage.Posts))
+		assert.Empty(profilePage.NextCursor)
+
+		searchPage, err := backends.Posts.SearchContent(ctx, types.SearchQuery{Sort: types.Recent}, 3, types.Page{})
+		assert.NoError(err)
+		assert.Equal(reversed(postIds)[:3], postIdsOf(searchPage.Posts))
+		assert.NotEmpty(searchPage.NextCursor)
+
+		writePosts(t, backends, rs, usernames[2], 1)
+
+		searchPage, err = backends.Posts.SearchContent(ctx, types.SearchQuery{Sort: types.Recent}, 3, types.Page{Cursor: searchPage.NextCursor})
+		assert.NoError(err)
+		assert.Equal(reversed(postIds)[3:6], postIdsOf(searchPage.Posts))
+
+		// the offset is ignored when a cursor is given
+		searchPage, err = backends.Posts.SearchContent(ctx, types.SearchQuery{Sort: types.Recent}, 3, types.Page{Offset: 1, Cursor: searchPage.NextCursor})
+		assert.NoError(err)
+		assert.Equal(reversed(postIds)[6:], postIdsOf(searchPage.Posts))
+		assert.Empty(searchPage.NextCursor)
+	})
+
+	t.Run("Should not accept an invalid cursor", func(t *testing.T) {
+		_, err := backends.Posts.ListHomePageContent(ctx, "", types.Page{Cursor: "notacursor"}, types.All)
+		assert.ErrorAs(err, &storageposterr.InvalidCursorError{})
+
+		_, err = backends.Posts.ListProfileContent(ctx, usernames[0], types.Page{Cursor: "bm90YWN1cnNvcg"})
+		assert.ErrorAs(err, &storageposterr.InvalidCursorError{})
+	})
+}
+
+func testHashtags(t *testing.T, factory Factory) {
+	ctx := context.Background()
+	assert := assertions.New(t)
+	rs := testrand.NewPseudoRandomString()
+
+	backends, release := factory(t)
+	defer release()
+
+	usernames := createUsers(t, backends, rs, 2)
+	hashtag := strings.ToLower(rs.GenerateUnique(12))
+
+	postId, err := backends.Posts.WriteContent(ctx, usernames[0], fmt.Sprintf("#%s and #%s again", hashtag, strings.ToUpper(hashtag)))
+	assert.NoError(err)
+	quoteId, err := backends.Posts.WriteQuoteRepostContent(ctx, usernames[1], fmt.Sprintf("so true (#%s)", hashtag), postId)
+	assert.NoError(err)
+	// reposts have no content of their own, so they have no hashtags
+	_, err = backends.Posts.WriteRepostContent(ctx, usernames[1], postId)
+	assert.NoError(err)
+	replyId, err := backends.Posts.WriteReplyContent(ctx, usernames[1], fmt.Sprintf("#%s, indeed", hashtag), postId)
+	assert.NoError(err)
+	_, err = backends.Posts.WriteContent(ctx, usernames[0], fmt.Sprintf("not a hashtag: a#%s", hashtag))
+	assert.NoError(err)
+
+	t.Run("Should list posts using a hashtag regardless of its case", func(t *testing.T) {
+		page, err := backends.Posts.ListHashtagContent(ctx, hashtag, types.Page{})
+		assert.NoError(err)
+		assert.Equal([]string{replyId, quoteId, postId}, postIdsOf(page.Posts))
+
+		page, err = backends.Posts.ListHashtagContent(ctx, "#"+strings.ToUpper(hashtag), types.Page{})
+		assert.NoError(err)
+		assert.Equal([]string{replyId, quoteId, postId}, postIdsOf(page.Posts))
+	})
+
+	t.Run("Should return nothing if no post uses a hashtag", func(t *testing.T) {
+		page, err := backends.Posts.ListHashtagContent(ctx, rs.GenerateUnique(12), types.Page{})
+		assert.NoError(err)
+		assert.Empty(page.Posts)
+	})
+
+	t.Run("Should not list deleted posts", func(t *testing.T) {
+		assert.NoError(backends.Posts.DeleteContent(ctx, usernames[1], quoteId))
+
+		page, err := backends.Posts.ListHashtagContent(ctx, hashtag, types.Page{})
+		assert.NoError(err)
+		assert.Equal([]string{replyId, postId}, postIdsOf(page.Posts))
+	})
+
+	t.Run("Should not accept an invalid hashtag", func(t *testing.T) {
+		_, err := backends.Posts.ListHashtagContent(ctx, "not a hashtag", types.Page{})
+		assert.ErrorAs(err, &storageposterr.InvalidHashtagError{})
+
+		_, err = backends.Posts.ListHashtagContent(ctx, rs.GenerateAny(51), types.Page{})
+		assert.ErrorAs(err, &storageposterr.InvalidHashtagError{})
+	})
+}
+
+func testTrendingHashtags(t *testing.T, factory Factory) {
+	ctx := context.Background()
+	assert := assertions.New(t)
+	rs := testrand.NewPseudoRandomString()
+
+	backends, release := factory(t)
+	defer release()
+
+	usernames := createUsers(t, backends, rs, 3)
+	popular, rising, fading := strings.ToLower(rs.GenerateUnique(12)), strings.ToLower(rs.GenerateUnique(12)), strings.ToLower(rs.GenerateUnique(12))
+
+	_, err := backends.Posts.WriteContent(ctx, usernames[0], fmt.Sprintf("#%s #%s", fading, popular))
+	assert.NoError(err)
+	_, err = backends.Posts.WriteContent(ctx, usernames[1], fmt.Sprintf("#%s", popular))
+	assert.NoError(err)
+	deletedId, err := backends.Posts.WriteContent(ctx, usernames[1], fmt.Sprintf("#%s #%s", rising, rising))
+	assert.NoError(err)
+	_, err = backends.Posts.WriteContent(ctx, usernames[2], fmt.Sprintf("#%s #%s", popular, rising))
+	assert.NoError(err)
+
+	t.Run("Should return the most used hashtags first", func(t *testing.T) {
+		hashtags, err := backends.Posts.ListTrendingHashtags(ctx, 0, 0)
+		assert.NoError(err)
+		assert.Equal([]types.PosterrHashtag{
+			{Hashtag: popular, Count: 3},
+			{Hashtag: rising, Count: 2},
+			{Hashtag: fading, Count: 1},
+		}, hashtags)
+	})
+
+	t.Run("Should honor limit", func(t *testing.T) {
+		hashtags, err := backends.Posts.ListTrendingHashtags(ctx, time.Hour, 1)
+		assert.NoError(err)
+		assert.Equal([]types.PosterrHashtag{{Hashtag: popular, Count: 3}}, hashtags)
+	})
+
+	t.Run("Should not count deleted posts", func(t *testing.T) {
+		assert.NoError(backends.Posts.DeleteContent(ctx, usernames[1], deletedId))
+
+		hashtags, err := backends.Posts.ListTrendingHashtags(ctx, 0, 0)
+		assert.NoError(err)
+		// ties are broken by the most recently used hashtag
+		assert.Equal([]types.PosterrHashtag{
+			{Hashtag: popular, Count: 3},
+			{Hashtag: rising, Count: 1},
+			{Hashtag: fading, Count: 1},
+		}, hashtags)
+	})
+
+	t.Run("Should only count posts within the window", func(t *testing.T) {
+		hashtags, err := backends.Posts.ListTrendingHashtags(ctx, time.Nanosecond, 0)
+		assert.NoError(err)
+		assert.Empty(hashtags)
+	})
+
+	t.Run("Should not accept an invalid window", func(t *testing.T) {
+		_, err := backends.Posts.ListTrendingHashtags(ctx, -time.Hour, 0)
+		assert.ErrorAs(err, &storageposterr.InvalidTrendingWindowError{})
+
+		_, err = backends.Posts.ListTrendingHashtags(ctx, 8*24*time.Hour, 0)
+		assert.ErrorAs(err, &storageposterr.InvalidTrendingWindowError{})
+	})
+}
+
+func testMentions(t *testing.T, factory Factory) {
+	ctx := context.Background()
+	assert := assertions.New(t)
+	rs := testrand.NewPseudoRandomString()
+
+	backends, release := factory(t)
+	defer release()
+
+	usernames := createUsers(t, backends, rs, 3)
+	mentioned := usernames[2]
+
+	postId, err := backends.Posts.WriteContent(ctx, usernames[0], fmt.Sprintf("hi @%s, meet @%s and @%s", mentioned, usernames[1], rs.GenerateUnique(12)))
+	assert.NoError(err)
+	quoteId, err := backends.Posts.WriteQuoteRepostContent(ctx, usernames[1], fmt.Sprintf("@%s: @%s", mentioned, mentioned), postId)
+	assert.NoError(err)
+	replyId, err := backends.Posts.WriteReplyContent(ctx, mentioned, fmt.Sprintf("thanks! (@%s)", mentioned), postId)
+	assert.NoError(err)
+	// reposts have no content of their own, so they mention nobody
+	_, err = backends.Posts.WriteRepostContent(ctx, usernames[1], postId)
+	assert.NoError(err)
+	_, err = backends.Posts.WriteContent(ctx, usernames[0], fmt.Sprintf("not a mention: mail%s@%s", mentioned, mentioned))
+	assert.NoError(err)
+
+	t.Run("Should list posts mentioning a user", func(t *testing.T) {
+		page, err := backends.Posts.ListMentionContent(ctx, mentioned, types.Page{})
+		assert.NoError(err)
+		assert.Equal([]string{replyId, quoteId, postId}, postIdsOf(page.Posts))
+
+		page, err = backends.Posts.ListMentionContent(ctx, usernames[1], types.Page{})
+		assert.NoError(err)
+		assert.Equal([]string{postId}, postIdsOf(page.Posts))
+	})
+
+	t.Run("Should return nothing if no post mentions a user", func(t *testing.T) {
+		page, err := backends.Posts.ListMentionContent(ctx, usernames[0], types.Page{})
+		assert.NoError(err)
+		assert.Empty(page.Posts)
+	})
+
+	t.Run("Should not list deleted posts", func(t *testing.T) {
+		assert.NoError(backends.Posts.DeleteContent(ctx, usernames[1], quoteId))
+
+		page, err := backends.Posts.ListMentionContent(ctx, mentioned, types.Page{})
+		assert.NoError(err)
+		assert.Equal([]string{replyId, postId}, postIdsOf(page.Posts))
+	})
+}
+
+func testLikes(t *testing.T, factory Factory) {
+	ctx := context.Background()
+	assert := assertions.New(t)
+	rs := testrand.NewPseudoRandomString()
+
+	backends, release := factory(t)
+	defer release()
+
+	usernames := createUsers(t, backends, rs, 3)
+	author := usernames[0]
+	postIds := writePosts(t, backends, rs, author, maxDailyPosts-1)
+	postId := postIds[0]
+	repostId, err := backends.Posts.WriteRepostContent(ctx, author, postId)
+	assert.NoError(err)
+
+	t.Run("Should like a post without counting towards the daily posts", func(t *testing.T) {
+		for _, username := range usernames {
+			assert.NoError(backends.Posts.LikePost(ctx, username, postId))
+			assert.NoError(backends.Posts.LikePost(ctx, username, postIds[1]))
+		}
+
+		likers, err := backends.Posts.ListPostLikers(ctx, postId)
+		assert.NoError(err)
+		assert.ElementsMatch([]types.PosterrUser{{Username: usernames[0]}, {Username: usernames[1]}, {Username: usernames[2]}}, likers)
+
+		_, err = backends.Posts.WriteContent(ctx, usernames[1], "still allowed")
+		assert.NoError(err)
+	})
+
+	t.Run("Should not like a post twice", func(t *testing.T) {
+		err := backends.Posts.LikePost(ctx, usernames[1], postId)
+		assert.ErrorAs(err, &storageposterr.AlreadyLikedError{})
+	})
+
+	t.Run("Should not like a non existing post", func(t *testing.T) {
+		err := backends.Posts.LikePost(ctx, usernames[1], "somePostId")
+		assert.ErrorAs(err, &storageposterr.PostIdDoesNotExistError{})
+
+		_, err = backends.Posts.ListPostLikers(ctx, "somePostId")
+		assert.ErrorAs(err, &storageposterr.PostIdDoesNotExistError{})
+	})
+
+	t.Run("Should not like if username does not exist", func(t *testing.T) {
+		err := backends.Posts.LikePost(ctx, "notauser", postId)
+		assert.ErrorAs(err, &storageposterr.UserDoesNotExistError{})
+	})
+
+	t.Run("Should return like counts flagged for the user of the context", func(t *testing.T) {
+		assert.NoError(backends.Posts.UnlikePost(ctx, usernames[2], postIds[1]))
+
+		viewerCtx := types.ContextWithUser(ctx, usernames[2])
+		page, err := backends.Posts.ListProfileContent(viewerCtx, author, types.Page{})
+		assert.NoError(err)
+		likes := make(map[string]types.PosterrContent)
+		for _, post := range page.Posts {
+			likes[post.ID] = post
+		}
+		assert.Equal(3, likes[postId].Likes)
+		assert.True(likes[postId].LikedByMe)
+		assert.Equal(2, likes[postIds[1]].Likes)
+		assert.False(likes[postIds[1]].LikedByMe)
+		assert.Equal(0, likes[postIds[2]].Likes)
+		assert.Equal(0, likes[repostId].Likes)
+		if assert.NotNil(likes[repostId].Reposted) {
+			assert.Equal(3, likes[repostId].Reposted.Likes)
+			assert.True(likes[repostId].Reposted.LikedByMe)
+		}
+
+		post, err := backends.Posts.GetContent(ctx, postId)
+		assert.NoError(err)
+		assert.Equal(3, post.Likes)
+		assert.False(post.LikedByMe)
+	})
+
+	t.Run("Should notify the author once of each user liking a post", func(t *testing.T) {
+		assert.NoError(backends.Posts.UnlikePost(ctx, usernames[1], postId))
+		assert.NoError(backends.Posts.LikePost(ctx, usernames[1], postId))
+
+		page, err := backends.Notifications.ListNotifications(ctx, author, false, types.Page{})
+		assert.NoError(err)
+		likeNotifications := make([]types.PosterrNotification, 0)
+		for _, notification := range withoutIdsAndDates(page.Notifications) {
+			if notification.Kind == types.LikeNotification {
+				likeNotifications = append(likeNotifications, notification)
+			}
+		}
+		assert.ElementsMatch([]types.PosterrNotification{
+			{Kind: types.LikeNotification, Actor: usernames[1], PostId: postId},
+			{Kind: types.LikeNotification, Actor: usernames[2], PostId: postId},
+			{Kind: types.LikeNotification, Actor: usernames[1], PostId: postIds[1]},
+			{Kind: types.LikeNotification, Actor: usernames[2], PostId: postIds[1]},
+		}, likeNotifications)
+	})
+
+	t.Run("Should not unlike a post which is not liked", func(t *testing.T) {
+		err := backends.Posts.UnlikePost(ctx, usernames[2], postIds[1])
+		assert.ErrorAs(err, &storageposterr.NotLikedError{})
+	})
+
+	t.Run("Should not like a deleted post", func(t *testing.T) {
+		assert.NoError(backends.Posts.DeleteContent(ctx, author, postIds[2]))
+
+		err := backends.Posts.LikePost(ctx, usernames[1], postIds[2])
+		assert.ErrorAs(err, &storageposterr.PostIdDoesNotExistError{})
+	})
+}
+
+func testBlocksAndMutes(t *testing.T, factory Factory) {
+	ctx := context.Background()
+	assert := assertions.New(t)
+	rs := testrand.NewPseudoRandomString()
+
+	backends, release := factory(t)
+	defer release()
+
+	usernames := createUsers(t, backends, rs, 4)
+	viewer, muted, blocked, other := usernames[0], usernames[1], usernames[2], usernames[3]
+	term := rs.GenerateUnique(12)
+
+	for _, followed := range usernames[1:] {
+		assert.NoError(backends.Users.FollowUser(ctx, followed, viewer))
+	}
+
+	postIds := make(map[string]string)
+	for _, username := range usernames {
+		postId, err := backends.Posts.WriteContent(ctx, username, fmt.Sprintf("%s by %s", term, username))
+		assert.NoError(err)
+		postIds[username] = postId
+	}
+	assert.NoError(backends.Users.MuteUser(ctx, muted, viewer))
+	assert.NoError(backends.Users.BlockUser(ctx, blocked, viewer))
+
+	home := func(username string, toggle bool) []string {
+		page, err := backends.Posts.ListHomePageContent(ctx, username, types.Page{}, toggle)
+		assert.NoError(err)
+		return postIdsOf(page.Posts)
+	}
+
+	search := func(ctx context.Context) []string {
+		page, err := backends.Posts.SearchContent(ctx, types.SearchQuery{Terms: []string{term}, Sort: types.Recent}, 0, types.Page{})
+		assert.NoError(err)
+		return postIdsOf(page.Posts)
+	}
+
+	t.Run("Should hide muted and blocked users from the home page of the viewer", func(t *testing.T) {
+		assert.Equal([]string{postIds[other], postIds[viewer]}, home(viewer, types.All))
+		assert.Equal([]string{postIds[other]}, home(viewer, types.Following))
+		assert.Len(home(other, types.All), 4)
+	})
+
+	t.Run("Should hide muted and blocked users from the searches of the viewer", func(t *testing.T) {
+		assert.Equal([]string{postIds[other], postIds[viewer]}, search(types.ContextWithUser(ctx, viewer)))
+		assert.Len(search(types.ContextWithUser(ctx, other)), 4)
+		assert.Len(search(ctx), 4)
+	})
+
+	t.Run("Should hide muted and blocked users from the hashtags, mentions and replies of the viewer", func(t *testing.T) {
+		hashtag := strings.ToLower(rs.GenerateUnique(12))
+		taggedIds := make(map[string]string)
+		replyIds := make(map[string]string)
+		// a blocker's posts can't be replied, so the block is lifted while replying
+		assert.NoError(backends.Users.UnblockUser(ctx, blocked, viewer))
+		for _, username := range []string{muted, blocked, other} {
+			postId, err := backends.Posts.WriteContent(ctx, username, fmt.Sprintf("#%s @%s", hashtag, viewer))
+			assert.NoError(err)
+			taggedIds[username] = postId
+
+			replyId, err := backends.Posts.WriteReplyContent(ctx, username, "reply", postIds[viewer])
+			assert.NoError(err)
+			replyIds[username] = replyId
+		}
+		assert.NoError(backends.Users.BlockUser(ctx, blocked, viewer))
+		// replies to hidden replies are hidden along with them
+		_, err := backends.Posts.WriteReplyContent(ctx, other, "nested reply", replyIds[muted])
+		assert.NoError(err)
+
+		viewerCtx := types.ContextWithUser(ctx, viewer)
+		page, err := backends.Posts.ListHashtagContent(viewerCtx, hashtag, types.Page{})
+		assert.NoError(err)
+		assert.Equal([]string{taggedIds[other]}, postIdsOf(page.Posts))
+
+		page, err = backends.Posts.ListHashtagContent(ctx, hashtag, types.Page{})
+		assert.NoError(err)
+		assert.Len(page.Posts, 3)
+
+		page, err = backends.Posts.ListMentionContent(viewerCtx, viewer, types.Page{})
+		assert.NoError(err)
+		assert.Equal([]string{taggedIds[other]}, postIdsOf(page.Posts))
+
+		replies, err := backends.Posts.ListReplies(viewerCtx, postIds[viewer], types.Ascending)
+		assert.NoError(err)
+		if assert.Len(replies, 1) {
+			assert.Equal(replyIds[other], replies[0].ID)
+		}
+
+		replies, err = backends.Posts.ListReplies(ctx, postIds[viewer], types.Ascending)
+		assert.NoError(err)
+		if assert.Len(replies, 3) {
+			assert.Len(replies[0].Replies, 1)
+		}
+	})
+
+	t.Run("Should not repost or quote repost posts of a blocker", func(t *testing.T) {
+		_, err := backends.Posts.WriteRepostContent(ctx, blocked, postIds[viewer])
+		assert.ErrorAs(err, &storageposterr.BlockedByAuthorError{})
+
+		_, err = backends.Posts.WriteQuoteRepostContent(ctx, blocked, "blocked", postIds[viewer])
+		assert.ErrorAs(err, &storageposterr.BlockedByAuthorError{})
+
+		_, err = backends.Posts.WriteRepostContent(ctx, muted, postIds[viewer])
+		assert.NoError(err)
+	})
+
+	t.Run("Should not reply, like or bookmark posts of a blocker", func(t *testing.T) {
+		_, err := backends.Posts.WriteReplyContent(ctx, blocked, "blocked", postIds[viewer])
+		assert.ErrorAs(err, &storageposterr.BlockedByAuthorError{})
+
+		err = backends.Posts.LikePost(ctx, blocked, postIds[viewer])
+		assert.ErrorAs(err, &storageposterr.BlockedByAuthorError{})
+
+		err = backends.Posts.BookmarkPost(ctx, blocked, postIds[viewer])
+		assert.ErrorAs(err, &storageposterr.BlockedByAuthorError{})
+
+		likers, err := backends.Posts.ListPostLikers(ctx, postIds[viewer])
+		assert.NoError(err)
+		assert.Empty(likers)
+	})
+
+	t.Run("Should show users again once unmuted", func(t *testing.T) {
+		assert.NoError(backends.Users.UnmuteUser(ctx, muted, viewer))
+
+		assert.Contains(home(viewer, types.Following), postIds[muted])
+		assert.Contains(search(types.ContextWithUser(ctx, viewer)), postIds[muted])
+	})
+}
+
+func testPrivateAccounts(t *testing.T, factory Factory) {
+	ctx := context.Background()
+	assert := assertions.New(t)
+	rs := testrand.NewPseudoRandomString()
+
+	backends, release := factory(t)
+	defer release()
+
+	usernames := createUsers(t, backends, rs, 3)
+	private, follower, other := usernames[0], usernames[1], usernames[2]
+	term := rs.GenerateUnique(12)
+
+	assert.NoError(backends.Users.SetUserPrivate(ctx, private, true))
+	assert.NoError(backends.Users.FollowUser(ctx, private, follower))
+	assert.NoError(backends.Users.ApproveFollowRequest(ctx, private, follower))
+	// a pending request does not grant access to the posts
+	assert.NoError(backends.Users.FollowUser(ctx, private, other))
+
+	postId, err := backends.Posts.WriteContent(ctx, private, fmt.Sprintf("%s by %s", term, private))
+	assert.NoError(err)
+
+	profile := func(ctx context.Context) []string {
+		page, err := backends.Posts.ListProfileContent(ctx, private, types.Page{})
+		assert.NoError(err)
+		return postIdsOf(page.Posts)
+	}
+
+	home := func(username string) []string {
+		page, err := backends.Posts.ListHomePageContent(ctx, username, types.Page{}, types.All)
+		assert.NoError(err)
+		return postIdsOf(page.Posts)
+	}
+
+	search := func(ctx context.Context) []string {
+		page, err := backends.Posts.SearchContent(ctx, types.SearchQuery{Terms: []string{term}, Sort: types.Recent}, 0, types.Page{})
+		assert.NoError(err)
+		return postIdsOf(page.Posts)
+	}
+
+	t.Run("Should show posts of a private user to their followers and themselves", func(t *testing.T) {
+		for _, viewer := range []string{private, follower} {
+			assert.Equal([]string{postId}, profile(types.ContextWithUser(ctx, viewer)))
+			assert.Equal([]string{postId}, home(viewer))
+			assert.Equal([]string{postId}, search(types.ContextWithUser(ctx, viewer)))
+		}
+	})
+
+	t.Run("Should hide posts of a private user from everyone else", func(t *testing.T) {
+		assert.Empty(profile(types.ContextWithUser(ctx, other)))
+		assert.Empty(profile(ctx))
+		assert.Empty(home(other))
+		assert.Empty(search(types.ContextWithUser(ctx, other)))
+		assert.Empty(search(ctx))
+	})
+
+	t.Run("Should show posts once the user is no longer private", func(t *testing.T) {
+		assert.NoError(backends.Users.SetUserPrivate(ctx, private, false))
+
+		assert.Equal([]string{postId}, profile(ctx))
+		assert.Equal([]string{postId}, home(other))
+		assert.Equal([]string{postId}, search(ctx))
+	})
+}
+
+func testPrivateContent(t *testing.T, factory Factory) {
+	ctx := context.Background()
+	assert := assertions.New(t)
+	rs := testrand.NewPseudoRandomString()
+
+	backends, release := factory(t)
+	defer release()
+
+	usernames := createUsers(t, backends, rs, 3)
+	private, follower, other := usernames[0], usernames[1], usernames[2]
+	hashtag := rs.GenerateUnique(12)
+
+	assert.NoError(backends.Users.SetUserPrivate(ctx, private, true))
+	assert.NoError(backends.Users.FollowUser(ctx, private, follower))
+	assert.NoError(backends.Users.ApproveFollowRequest(ctx, private, follower))
+
+	postId, err := backends.Posts.WriteContent(ctx, private, fmt.Sprintf("#%s cc @%s", hashtag, other))
+	assert.NoError(err)
+	otherPostId, err := backends.Posts.WriteContent(ctx, other, "any question?")
+	assert.NoError(err)
+	replyId, err := backends.Posts.WriteReplyContent(ctx, private, "an answer", otherPostId)
+	assert.NoError(err)
+	repostId, err := backends.Posts.WriteRepostContent(ctx, follower, postId)
+	assert.NoError(err)
+	quoteId, err := backends.Posts.WriteQuoteRepostContent(ctx, follower, "look at this", postId)
+	assert.NoError(err)
+	repostOfQuoteId, err := backends.Posts.WriteRepostContent(ctx, other, quoteId)
+	assert.NoError(err)
+	assert.NoError(backends.Posts.EditContent(ctx, private, postId, fmt.Sprintf("#%s cc @%s, edited", hashtag, other)))
+
+	list := func(ctx context.Context) (hashtags, mentions []string, replies []types.PosterrReply) {
+		page, err := backends.Posts.ListHashtagContent(ctx, hashtag, types.Page{})
+		assert.NoError(err)
+		hashtags = postIdsOf(page.Posts)
+
+		page, err = backends.Posts.ListMentionContent(ctx, other, types.Page{})
+		assert.NoError(err)
+		mentions = postIdsOf(page.Posts)
+
+		replies, err = backends.Posts.ListReplies(ctx, otherPostId, types.Ascending)
+		assert.NoError(err)
+		return hashtags, mentions, replies
+	}
+
+	t.Run("Should show posts of a private user to their followers and themselves", func(t *testing.T) {
+		for _, viewer := range []string{private, follower} {
+			viewerCtx := types.ContextWithUser(ctx, viewer)
+			post, err := backends.Posts.GetContent(viewerCtx, postId)
+			assert.NoError(err)
+			assert.Equal(postId, post.ID)
+
+			hashtags, mentions, replies := list(viewerCtx)
+			assert.Equal([]string{postId}, hashtags)
+			assert.Equal([]string{postId}, mentions)
+			if assert.Len(replies, 1) {
+				assert.Equal(replyId, replies[0].ID)
+			}
+
+			repost, err := backends.Posts.GetContent(viewerCtx, repostId)
+			assert.NoError(err)
+			if assert.NotNil(repost.Reposted) {
+				assert.Equal(private, repost.Reposted.Username)
+				assert.False(repost.Reposted.Deleted)
+			}
+
+			revisions, err := backends.Posts.ListContentRevisions(viewerCtx, postId)
+			assert.NoError(err)
+			assert.Len(revisions, 1)
+		}
+	})
+
+	t.Run("Should hide posts of a private user from everyone else", func(t *testing.T) {
+		for _, viewerCtx := range []context.Context{types.ContextWithUser(ctx, other), ctx} {
+			_, err := backends.Posts.GetContent(viewerCtx, postId)
+			assert.ErrorAs(err, &storageposterr.PostIdDoesNotExistError{})
+
+			_, err = backends.Posts.ListReplies(viewerCtx, postId, types.Ascending)
+			assert.ErrorAs(err, &storageposterr.PostIdDoesNotExistError{})
+
+			_, err = backends.Posts.ListContentRevisions(viewerCtx, postId)
+			assert.ErrorAs(err, &storageposterr.PostIdDoesNotExistError{})
+
+			hashtags, mentions, replies := list(viewerCtx)
+			assert.Empty(hashtags)
+			assert.Empty(mentions)
+			assert.Empty(replies)
+		}
+	})
+
+	t.Run("Should embed posts of a private user as unavailable to everyone else", func(t *testing.T) {
+		otherCtx := types.ContextWithUser(ctx, other)
+		repost, err := backends.Posts.GetContent(otherCtx, repostId)
+		assert.NoError(err)
+		if assert.NotNil(repost.Reposted) {
+			assert.Equal(postId, repost.Reposted.ID)
+			assert.True(repost.Reposted.Deleted)
+			assert.Empty(repost.Reposted.Username)
+			assert.Empty(repost.Reposted.Content)
+		}
+
+		page, err := backends.Posts.ListProfileContent(otherCtx, other, types.Page{})
+		assert.NoError(err)
+		if assert.Equal(repostOfQuoteId, page.Posts[0].ID) && assert.NotNil(page.Posts[0].Reposted) {
+			quote := page.Posts[0].Reposted
+			assert.Equal(quoteId, quote.ID)
+			assert.Equal("look at this", quote.Content)
+			if assert.NotNil(quote.Reposted) {
+				assert.Equal(postId, quote.Reposted.ID)
+				assert.True(quote.Reposted.Deleted)
+				assert.Empty(quote.Reposted.Content)
+			}
+		}
+	})
+
+	t.Run("Should not repost or quote repost posts of a private user the user doesn't follow", func(t *testing.T) {
+		_, err := backends.Posts.WriteRepostContent(ctx, other, postId)
+		assert.ErrorAs(err, &storageposterr.PostIdDoesNotExistError{})
+
+		_, err = backends.Posts.WriteQuoteRepostContent(ctx, other, "look at this", postId)
+		assert.ErrorAs(err, &storageposterr.PostIdDoesNotExistError{})
+	})
+
+	t.Run("Should not reply, like or bookmark posts of a private user the user doesn't follow", func(t *testing.T) {
+		_, err := backends.Posts.WriteReplyContent(ctx, other, "an answer", postId)
+		assert.ErrorAs(err, &storageposterr.PostIdDoesNotExistError{})
+
+		err = backends.Posts.LikePost(ctx, other, postId)
+		assert.ErrorAs(err, &storageposterr.PostIdDoesNotExistError{})
+
+		err = backends.Posts.BookmarkPost(ctx, other, postId)
+		assert.ErrorAs(err, &storageposterr.PostIdDoesNotExistError{})
+
+		_, err = backends.Posts.WriteReplyContent(ctx, follower, "an answer", postId)
+		assert.NoError(err)
+		assert.NoError(backends.Posts.LikePost(ctx, follower, postId))
+		assert.NoError(backends.Posts.BookmarkPost(ctx, follower, postId))
+	})
+
+	t.Run("Should hide the likers of posts of a private user from everyone else", func(t *testing.T) {
+		likers, err := backends.Posts.ListPostLikers(types.ContextWithUser(ctx, follower), postId)
+		assert.NoError(err)
+		if assert.Len(likers, 1) {
+			assert.Equal(follower, likers[0].Username)
+		}
+
+		for _, viewerCtx := range []context.Context{types.ContextWithUser(ctx, other), ctx} {
+			_, err := backends.Posts.ListPostLikers(viewerCtx, postId)
+			assert.ErrorAs(err, &storageposterr.PostIdDoesNotExistError{})
+		}
+	})
+
+	t.Run("Should show posts once the user is no longer private", func(t *testing.T) {
+		assert.NoError(backends.Users.SetUserPrivate(ctx, private, false))
+
+		_, err := backends.Posts.GetContent(ctx, postId)
+		assert.NoError(err)
+
+		repost, err := backends.Posts.GetContent(ctx, repostId)
+		assert.NoError(err)
+		if assert.NotNil(repost.Reposted) {
+			assert.False(repost.Reposted.Deleted)
+		}
+
+		hashtags, mentions, replies := list(ctx)
+		assert.Equal([]string{postId}, hashtags)
+		assert.Equal([]string{postId}, mentions)
+		assert.Len(replies, 1)
+	})
+}
+
+func testEditContent(t *testing.T, factory Factory) {
+	ctx := context.Background()
+	assert := assertions.New(t)
+	rs := testrand.NewPseudoRandomString()
+
+	backends, release := factory(t)
+	defer release()
+
+	usernames := createUsers(t, backends, rs, 3)
+	author, first, second := usernames[0], usernames[1], usernames[2]
+	oldTag, newTag := rs.GenerateUnique(12), rs.GenerateUnique(12)
+	original := fmt.Sprintf("#%s cc @%s", oldTag, first)
+	postId, err := backends.Posts.WriteContent(ctx, author, original)
+	assert.NoError(err)
+	repostId, err := backends.Posts.WriteRepostContent(ctx, first, postId)
+	assert.NoError(err)
+
+	t.Run("Should edit a post and keep the replaced content as a revision", func(t *testing.T) {
+		edited := fmt.Sprintf("#%s cc @%s", newTag, second)
+		assert.NoError(backends.Posts.EditContent(ctx, author, postId, edited))
+
+		post, err := backends.Posts.GetContent(ctx, postId)
+		assert.NoError(err)
+		assert.Equal(edited, post.Content)
+		if assert.NotNil(post.EditedAt) {
+			assert.False(post.EditedAt.Before(post.CreatedAt))
+		}
+
+		revisions, err := backends.Posts.ListContentRevisions(ctx, postId)
+		assert.NoError(err)
+		if assert.Len(revisions, 1) {
+			assert.Equal(original, revisions[0].Content)
+			assert.True(revisions[0].CreatedAt.Equal(post.CreatedAt))
+			assert.True(revisions[0].ReplacedAt.Equal(*post.EditedAt))
+		}
+	})
+
+	t.Run("Should list revisions from the original to the latest replaced one", func(t *testing.T) {
+		assert.NoError(backends.Posts.EditContent(ctx, author, postId, fmt.Sprintf("#%s cc @%s and @%s", newTag, first, second)))
+
+		revisions, err := backends.Posts.ListContentRevisions(ctx, postId)
+		assert.NoError(err)
+		if assert.Len(revisions, 2) {
+			assert.Equal(original, revisions[0].Content)
+			assert.True(revisions[1].CreatedAt.Equal(revisions[0].ReplacedAt))
+		}
+	})
+
+	t.Run("Should return the edit date in feeds and embedded posts", func(t *testing.T) {
+		page, err := backends.Posts.ListProfileContent(ctx, author, types.Page{})
+		assert.NoError(err)
+		assert.NotNil(page.Posts[0].EditedAt)
+
+		repost, err := backends.Posts.GetContent(ctx, repostId)
+		assert.NoError(err)
+		assert.Nil(repost.EditedAt)
+		if assert.NotNil(repost.Reposted) {
+			assert.NotNil(repost.Reposted.EditedAt)
+		}
+	})
+
+	t.Run("Should update hashtags and mentions, notifying new mentions once", func(t *testing.T) {
+		page, err := backends.Posts.ListHashtagContent(ctx, oldTag, types.Page{})
+		assert.NoError(err)
+		assert.Empty(page.Posts)
+
+		page, err = backends.Posts.ListHashtagContent(ctx, newTag, types.Page{})
+		assert.NoError(err)
+		assert.Equal([]string{postId}, postIdsOf(page.Posts))
+
+		for _, mentioned := range []string{first, second} {
+			page, err = backends.Posts.ListMentionContent(ctx, mentioned, types.Page{})
+			assert.NoError(err)
+			assert.Equal([]string{postId}, postIdsOf(page.Posts))
+
+			notifications, err := backends.Notifications.ListNotifications(ctx, mentioned, false, types.Page{})
+			assert.NoError(err)
+			assert.Equal([]types.PosterrNotification{
+				{Kind: types.MentionNotification, Actor: author, PostId: postId},
+			}, withoutIdsAndDates(notifications.Notifications))
+		}
+	})
+
+	t.Run("Should not edit a post of another user", func(t *testing.T) {
+		err := backends.Posts.EditContent(ctx, first, postId, "not mine")
+		assert.ErrorAs(err, &storageposterr.NotPostAuthorError{})
+	})
+
+	t.Run("Should not edit a repost", func(t *testing.T) {
+		err := backends.Posts.EditContent(ctx, first, repostId, "no content")
+		assert.ErrorAs(err, &storageposterr.RepostNotEditableError{})
+	})
+
+	t.Run("Should not edit a post if content is too long", func(t *testing.T) {
+		err := backends.Posts.EditContent(ctx, author, postId, rs.GenerateAny(maxContentSize+1))
+		assert.ErrorAs(err, &storageposterr.PostExceededMaximumCharsError{})
+
+		revisions, err := backends.Posts.ListContentRevisions(ctx, postId)
+		assert.NoError(err)
+		assert.Len(revisions, 2)
+	})
+
+	t.Run("Should not edit or list revisions of a non existing post", func(t *testing.T) {
+		err := backends.Posts.EditContent(ctx, author, "somePostId", "hello")
+		assert.ErrorAs(err, &storageposterr.PostIdDoesNotExistError{})
+
+		_, err = backends.Posts.ListContentRevisions(ctx, "somePostId")
+		assert.ErrorAs(err, &storageposterr.PostIdDoesNotExistError{})
+	})
+
+	t.Run("Should not edit a post after the edit window", func(t *testing.T) {
+		time.Sleep(editWindow)
+
+		err := backends.Posts.EditContent(ctx, author, postId, "too late")
+		assert.ErrorAs(err, &storageposterr.EditWindowExpiredError{})
+	})
+
+	t.Run("Should not edit or list revisions of a deleted post", func(t *testing.T) {
+		assert.NoError(backends.Posts.DeleteContent(ctx, author, postId))
+
+		err := backends.Posts.EditContent(ctx, author, postId, "hello")
+		assert.ErrorAs(err, &storageposterr.PostIdDoesNotExistError{})
+
+		_, err = backends.Posts.ListContentRevisions(ctx, postId)
+		assert.ErrorAs(err, &storageposterr.PostIdDoesNotExistError{})
+	})
+}
+
+func testBookmarks(t *testing.T, factory Factory) {
+	ctx := context.Background()
+	assert := assertions.New(t)
+	rs := testrand.NewPseudoRandomString()
+
+	backends, release := factory(t)
+	defer release()
+
+	usernames := createUsers(t, backends, rs, 4)
+	owner, authors := usernames[0], usernames[1:]
+	postIds := make([]string, 0)
+	for _, author := range authors {
+		postIds = append(postIds, writePosts(t, backends, rs, author, maxDailyPosts-1)...)
+	}
+
+	// posts are bookmarked from the newest to the oldest,
+	// so the latest bookmark is of the oldest post
+	for _, postId := range reversed(postIds) {
+		assert.NoError(backends.Posts.BookmarkPost(ctx, owner, postId))
+	}
+
+	t.Run("Should list bookmarks from the latest to the earliest bookmark", func(t *testing.T) {
+		page, err := backends.Posts.ListBookmarkedContent(ctx, owner, types.Page{})
+		assert.NoError(err)
+		assert.Equal(postIds[:bookmarkPageSize], postIdsOf(page.Posts))
+		assert.NotEmpty(page.NextCursor)
+
+		page, err = backends.Posts.ListBookmarkedContent(ctx, owner, types.Page{Cursor: page.NextCursor})
+		assert.NoError(err)
+		assert.Equal(postIds[bookmarkPageSize:], postIdsOf(page.Posts))
+		assert.Empty(page.NextCursor)
+
+		page, err = backends.Posts.ListBookmarkedContent(ctx, owner, types.Page{Offset: 3})
+		assert.NoError(err)
+		assert.Equal(postIds[3:], postIdsOf(page.Posts))
+	})
+
+	t.Run("Should only list the bookmarks of their owner", func(t *testing.T) {
+		page, err := backends.Posts.ListBookmarkedContent(ctx, authors[0], types.Page{})
+		assert.NoError(err)
+		assert.Empty(page.Posts)
+	})
+
+	t.Run("Should not bookmark a post twice", func(t *testing.T) {
+		err := backends.Posts.BookmarkPost(ctx, owner, postIds[0])
+		assert.ErrorAs(err, &storageposterr.AlreadyBookmarkedError{})
+	})
+
+	t.Run("Should not bookmark a non existing post", func(t *testing.T) {
+		err := backends.Posts.BookmarkPost(ctx, owner, "somePostId")
+		assert.ErrorAs(err, &storageposterr.PostIdDoesNotExistError{})
+	})
+
+	t.Run("Should not bookmark if username does not exist", func(t *testing.T) {
+		err := backends.Posts.BookmarkPost(ctx, "notauser", postIds[0])
+		assert.ErrorAs(err, &storageposterr.UserDoesNotExistError{})
+	})
+
+	t.Run("Should unbookmark a post only once", func(t *testing.T) {
+		assert.NoError(backends.Posts.UnbookmarkPost(ctx, owner, postIds[0]))
+
+		err := backends.Posts.UnbookmarkPost(ctx, owner, postIds[0])
+		assert.ErrorAs(err, &storageposterr.NotBookmarkedError{})
+
+		page, err := backends.Posts.ListBookmarkedContent(ctx, owner, types.Page{})
+		assert.NoError(err)
+		assert.NotContains(postIdsOf(page.Posts), postIds[0])
+	})
+
+	t.Run("Should hide deleted posts and posts of private users not followed", func(t *testing.T) {
+		assert.NoError(backends.Posts.DeleteContent(ctx, authors[0], postIds[1]))
+		assert.NoError(backends.Users.SetUserPrivate(ctx, authors[1], true))
+
+		// the first post was unbookmarked and the posts of authors[1] follow the ones of authors[0]
+		postsPerAuthor := maxDailyPosts - 1
+		expected := append(append([]string{}, postIds[2:postsPerAuthor]...), postIds[2*postsPerAuthor:]...)
+
+		page, err := backends.Posts.ListBookmarkedContent(ctx, owner, types.Page{})
+		assert.NoError(err)
+		assert.Equal(expected, postIdsOf(page.Posts))
+	})
+
+	t.Run("Should not accept an invalid cursor", func(t *testing.T) {
+		_, err := backends.Posts.ListBookmarkedContent(ctx, owner, types.Page{Cursor: "notacursor"})
+		assert.ErrorAs(err, &storageposterr.InvalidCursorError{})
+	})
+}
+
+// writePosts writes noPosts regular posts and returns their ids in creation order
+func writePosts(t *testing.T, backends Backends, rs testrand.PseudoRand, username string, noPosts int) []string {
+	postIds := make([]string, 0, noPosts)
+	for i := 0; i < noPosts; i++ {
+		postId, err := backends.Posts.WriteContent(context.Background(), username, rs.GenerateAny(maxContentSize))
+		if err != nil {
+			t.Fatalf("could not write post: %s", err)
+		}
+		postIds = append(postIds, postId)
+	}
+	return postIds
+}
+
+func postIdsOf(posts []types.PosterrContent) []string {
+	postIds := make([]string, 0, len(posts))
+	for _, post := range posts {
+		postIds = append(postIds, post.ID)
+	}
+	return postIds
+}
+
+func reversed(values []string) []string {
+	result := make([]string, 0, len(values))
+	for i := len(values) - 1; i >= 0; i-- {
+		result = append(result, values[i])
+	}
+	return result
+}
+
+func assertNewestFirst(assert *assertions.Assertions, posts []types.PosterrContent) {
+	for i := 1; i < len(posts); i++ {
+		assert.False(posts[i].CreatedAt.After(posts[i-1].CreatedAt))
+	}
+}
@@ -0,0 +1,727 @@
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	storageauth "posterr/src/storage/auth"
+	storageusers "posterr/src/storage/users"
+	testrand "posterr/src/test/rand"
+	"posterr/src/types"
+
+	assertions "github.com/stretchr/testify/assert"
+)
+
+// RunUsers checks that the backends returned by factory
+// follow the documented behavior of types.Users
+func RunUsers(t *testing.T, factory Factory) {
+	t.Run("CreateUser", func(t *testing.T) { testCreateUser(t, factory) })
+	t.Run("GetUserProfile", func(t *testing.T) { testGetUserProfile(t, factory) })
+	t.Run("UpdateUserProfile", func(t *testing.T) { testUpdateUserProfile(t, factory) })
+	t.Run("FollowUser", func(t *testing.T) { testFollowUser(t, factory) })
+	t.Run("UnfollowUser", func(t *testing.T) { testUnfollowUser(t, factory) })
+	t.Run("ListFollowers", func(t *testing.T) { testListFollowers(t, factory) })
+	t.Run("ListFollowing", func(t *testing.T) { testListFollowing(t, factory) })
+	t.Run("FollowsPagination", func(t *testing.T) { testFollowsPagination(t, factory) })
+	t.Run("BlockUser", func(t *testing.T) { testBlockUser(t, factory) })
+	t.Run("MuteUser", func(t *testing.T) { testMuteUser(t, factory) })
+	t.Run("FollowRequests", func(t *testing.T) { testFollowRequests(t, factory) })
+	t.Run("GetUserRelations", func(t *testing.T) { testGetUserRelations(t, factory) })
+}
+
+func testCreateUser(t *testing.T, factory Factory) {
+	ctx := context.Background()
+	assert := assertions.New(t)
+	rs := testrand.NewPseudoRandomString()
+
+	backends, release := factory(t)
+	defer release()
+
+	t.Run("Should create users with valid names", func(t *testing.T) {
+		for count := 0; count < 20; count++ {
+			err := backends.Users.CreateUser(ctx, rs.GenerateUnique(maxUsernameLength))
+			assert.NoError(err)
+		}
+	})
+
+	t.Run("Should not create a user with a name too big", func(t *testing.T) {
+		err := backends.Users.CreateUser(ctx, rs.GenerateUnique(maxUsernameLength+1))
+		assert.ErrorAs(err, &storageusers.UsernameExceededMaximumCharsError{})
+	})
+
+	t.Run("Should not create a user with invalid characters", func(t *testing.T) {
+		err := backends.Users.CreateUser(ctx, fmt.Sprintf("%s@", rs.GenerateUnique(maxUsernameLength-1)))
+		assert.ErrorAs(err, &storageusers.InvalidUsernameError{})
+	})
+
+	t.Run("Should not create a user with an empty name", func(t *testing.T) {
+		err := backends.Users.CreateUser(ctx, "")
+		assert.ErrorAs(err, &storageusers.InvalidUsernameError{})
+	})
+
+	t.Run("Should not create the same user twice", func(t *testing.T) {
+		username := rs.GenerateUnique(maxUsernameLength)
+		assert.NoError(backends.Users.CreateUser(ctx, username))
+
+		err := backends.Users.CreateUser(ctx, username)
+		assert.ErrorAs(err, &storageusers.UserAlreadyExistsError{})
+	})
+
+	t.Run("Should register a user along with their password", func(t *testing.T) {
+		username := rs.GenerateUnique(maxUsernameLength)
+		passwordHash, err := storageauth.HashPassword("correct horse")
+		assert.NoError(err)
+		assert.NoError(backends.Users.RegisterUser(ctx, username, passwordHash))
+
+		_, err = backends.Auth.Login(ctx, username, "correct horse")
+		assert.NoError(err)
+	})
+
+	t.Run("Should not register an existing user nor replace their password", func(t *testing.T) {
+		username := rs.GenerateUnique(maxUsernameLength)
+		assert.NoError(backends.Users.CreateUser(ctx, username))
+
+		passwordHash, err := storageauth.HashPassword("correct horse")
+		assert.NoError(err)
+		err = backends.Users.RegisterUser(ctx, username, passwordHash)
+		assert.ErrorAs(err, &storageusers.UserAlreadyExistsError{})
+
+		_, err = backends.Auth.Login(ctx, username, "correct horse")
+		assert.ErrorAs(err, &storageauth.InvalidCredentialsError{})
+	})
+
+	t.Run("Should not register a user with an invalid name", func(t *testing.T) {
+		username := fmt.Sprintf("%s@", rs.GenerateUnique(maxUsernameLength-1))
+		passwordHash, err := storageauth.HashPassword("correct horse")
+		assert.NoError(err)
+
+		err = backends.Users.RegisterUser(ctx, username, passwordHash)
+		assert.ErrorAs(err, &storageusers.InvalidUsernameError{})
+
+		_, err = backends.Users.GetUserProfile(ctx, username)
+		assert.ErrorAs(err, &storageusers.UserDoesNotExistError{})
+	})
+}
+
+func testGetUserProfile(t *testing.T, factory Factory) {
+	ctx := context.Background()
+	assert := assertions.New(t)
+	rs := testrand.NewPseudoRandomString()
+
+	backends, release := factory(t)
+	defer release()
+
+	before := time.Now().Add(-time.Minute)
+	usernames := createUsers(t, backends, rs, 4)
+	target := usernames[0]
+	for _, follower := range usernames[1:] {
+		assert.NoError(backends.Users.FollowUser(ctx, target, follower))
+	}
+	assert.NoError(backends.Users.FollowUser(ctx, usernames[1], target))
+	writePosts(t, backends, rs, target, 3)
+
+	t.Run("Should return counts and joined date", func(t *testing.T) {
+		profile, err := backends.Users.GetUserProfile(ctx, target)
+		assert.NoError(err)
+		assert.Equal(target, profile.Username)
+		assert.Equal(3, profile.Followers)
+		assert.Equal(1, profile.Following)
+		assert.Equal(3, profile.PostsCount)
+		assert.True(profile.JoinedAt.After(before))
+	})
+
+	t.Run("Should refresh counts of both users after following", func(t *testing.T) {
+		// the profiles are read first, so that backends caching counts have them cached
+		_, err := backends.Users.GetUserProfile(ctx, usernames[2])
+		assert.NoError(err)
+		_, err = backends.Users.GetUserProfile(ctx, usernames[3])
+		assert.NoError(err)
+
+		assert.NoError(backends.Users.FollowUser(ctx, usernames[2], usernames[3]))
+
+		profile, err := backends.Users.GetUserProfile(ctx, usernames[2])
+		assert.NoError(err)
+		assert.Equal(1, profile.Followers)
+		assert.Equal(1, profile.Following)
+
+		profile, err = backends.Users.GetUserProfile(ctx, usernames[3])
+		assert.NoError(err)
+		assert.Equal(0, profile.Followers)
+		assert.Equal(2, profile.Following)
+
+		assert.NoError(backends.Users.UnfollowUser(ctx, usernames[2], usernames[3]))
+	})
+
+	t.Run("Should refresh counts after unfollowing", func(t *testing.T) {
+		assert.NoError(backends.Users.UnfollowUser(ctx, target, usernames[1]))
+
+		profile, err := backends.Users.GetUserProfile(ctx, target)
+		assert.NoError(err)
+		assert.Equal(2, profile.Followers)
+
+		profile, err = backends.Users.GetUserProfile(ctx, usernames[1])
+		assert.NoError(err)
+		assert.Equal(0, profile.Following)
+		assert.Equal(1, profile.Followers)
+	})
+
+	t.Run("Should not return a non existing user", func(t *testing.T) {
+		_, err := backends.Users.GetUserProfile(ctx, "notauser")
+		assert.ErrorAs(err, &storageusers.UserDoesNotExistError{})
+	})
+}
+
+func testUpdateUserProfile(t *testing.T, factory Factory) {
+	ctx := context.Background()
+	assert := assertions.New(t)
+	rs := testrand.NewPseudoRandomString()
+
+	backends, release := factory(t)
+	defer release()
+
+	username := createUsers(t, backends, rs, 1)[0]
+	text := func(value string) *string { return &value }
+
+	t.Run("Should return an empty profile for new users", func(t *testing.T) {
+		profile, err := backends.Users.GetUserProfile(ctx, username)
+		assert.NoError(err)
+		assert.Equal(types.PosterrProfile{}, profile.PosterrProfile)
+	})
+
+	t.Run("Should update every given field", func(t *testing.T) {
+		assert.NoError(backends.Users.UpdateUserProfile(ctx, username, types.PosterrProfileUpdate{
+			DisplayName: text("Jiraia Sensei"),
+			Bio:         text("Writer.\nToad sage."),
+			Location:    text("Konoha"),
+			Website:     text("https://example.com/jiraia"),
+			AvatarURL:   text("https://example.com/jiraia.png"),
+		}))
+
+		profile, err := backends.Users.GetUserProfile(ctx, username)
+		assert.NoError(err)
+		assert.Equal(types.PosterrProfile{
+			DisplayName: "Jiraia Sensei",
+			Bio:         "Writer.\nToad sage.",
+			Location:    "Konoha",
+			Website:     "https://example.com/jiraia",
+			AvatarURL:   "https://example.com/jiraia.png",
+		}, profile.PosterrProfile)
+	})
+
+	t.Run("Should keep fields which are not given and clear empty ones", func(t *testing.T) {
+		assert.NoError(backends.Users.UpdateUserProfile(ctx, username, types.PosterrProfileUpdate{
+			Location: text(""),
+		}))
+
+		profile, err := backends.Users.GetUserProfile(ctx, username)
+		assert.NoError(err)
+		assert.Equal("Jiraia Sensei", profile.DisplayName)
+		assert.Empty(profile.Location)
+	})
+
+	t.Run("Should not update fields beyond their maximum chars", func(t *testing.T) {
+		err := backends.Users.UpdateUserProfile(ctx, username, types.PosterrProfileUpdate{
+			DisplayName: text("Jiraia"),
+			Bio:         text(strings.Repeat("a", maxBioChars+1)),
+		})
+		assert.ErrorAs(err, &storageusers.ProfileFieldTooLongError{})
+
+		assert.NoError(backends.Users.UpdateUserProfile(ctx, username, types.PosterrProfileUpdate{
+			Bio: text(strings.Repeat("á", maxBioChars)),
+		}))
+
+		profile, err := backends.Users.GetUserProfile(ctx, username)
+		assert.NoError(err)
+		assert.Equal("Jiraia Sensei", profile.DisplayName)
+	})
+
+	t.Run("Should not update invalid fields", func(t *testing.T) {
+		for _, update := range []types.PosterrProfileUpdate{
+			{DisplayName: text("Jiraia\nSensei")},
+			{Location: text("Konoha\t")},
+			{Website: text("example.com")},
+			{AvatarURL: text("javascript:alert(1)")},
+		} {
+			err := backends.Users.UpdateUserProfile(ctx, username, update)
+			assert.ErrorAs(err, &storageusers.InvalidProfileFieldError{})
+		}
+	})
+
+	t.Run("Should not update a non existing user", func(t *testing.T) {
+		err := backends.Users.UpdateUserProfile(ctx, "notauser", types.PosterrProfileUpdate{Bio: text("hi")})
+		assert.ErrorAs(err, &storageusers.UserDoesNotExistError{})
+	})
+}
+
+func testFollowUser(t *testing.T, factory Factory) {
+	ctx := context.Background()
+	assert := assertions.New(t)
+	rs := testrand.NewPseudoRandomString()
+
+	backends, release := factory(t)
+	defer release()
+
+	usernames := createUsers(t, backends, rs, 2)
+
+	t.Run("Should follow a user", func(t *testing.T) {
+		assert.NoError(backends.Users.FollowUser(ctx, usernames[0], usernames[1]))
+
+		isFollowing, err := backends.Users.IsFollowingUser(ctx, usernames[0], usernames[1])
+		assert.NoError(err)
+		assert.True(isFollowing)
+
+		isFollowing, err = backends.Users.IsFollowingUser(ctx, usernames[1], usernames[0])
+		assert.NoError(err)
+		assert.False(isFollowing)
+	})
+
+	t.Run("Should not follow itself", func(t *testing.T) {
+		err := backends.Users.FollowUser(ctx, usernames[0], usernames[0])
+		assert.ErrorAs(err, &storageusers.SelfFollowError{})
+	})
+
+	t.Run("Should not follow the same user twice", func(t *testing.T) {
+		err := backends.Users.FollowUser(ctx, usernames[0], usernames[1])
+		assert.ErrorAs(err, &storageusers.UserAlreadyFollowsError{})
+	})
+}
+
+func testUnfollowUser(t *testing.T, factory Factory) {
+	ctx := context.Background()
+	assert := assertions.New(t)
+	rs := testrand.NewPseudoRandomString()
+
+	backends, release := factory(t)
+	defer release()
+
+	usernames := createUsers(t, backends, rs, 2)
+	assert.NoError(backends.Users.FollowUser(ctx, usernames[0], usernames[1]))
+
+	t.Run("Should unfollow a user", func(t *testing.T) {
+		assert.NoError(backends.Users.UnfollowUser(ctx, usernames[0], usernames[1]))
+
+		isFollowing, err := backends.Users.IsFollowingUser(ctx, usernames[0], usernames[1])
+		assert.NoError(err)
+		assert.False(isFollowing)
+	})
+
+	t.Run("Should not unfollow itself", func(t *testing.T) {
+		err := backends.Users.UnfollowUser(ctx, usernames[0], usernames[0])
+		assert.ErrorAs(err, &storageusers.SelfFollowError{})
+	})
+
+	t.Run("Should not unfollow a user who is not followed", func(t *testing.T) {
+		err := backends.Users.UnfollowUser(ctx, usernames[0], usernames[1])
+		assert.ErrorAs(err, &storageusers.UserDoesNotFollowError{})
+	})
+}
+
+func testListFollowers(t *testing.T, factory Factory) {
+	ctx := context.Background()
+	assert := assertions.New(t)
+	rs := testrand.NewPseudoRandomString()
+
+	backends, release := factory(t)
+	defer release()
+
+	usernames := createUsers(t, backends, rs, 4)
+	for _, follower := range usernames[1:] {
+		assert.NoError(backends.Users.FollowUser(ctx, usernames[0], follower))
+	}
+
+	t.Run("Should list every follower from the latest to the earliest follow", func(t *testing.T) {
+		page, err := backends.Users.ListFollowers(ctx, usernames[0], types.Page{})
+		assert.NoError(err)
+		assert.Equal(reversed(usernames[1:]), usernamesOf(page.Users))
+		assertFollowedNewestFirst(assert, page.Users)
+		assert.Empty(page.NextCursor)
+	})
+
+	t.Run("Should list no followers", func(t *testing.T) {
+		page, err := backends.Users.ListFollowers(ctx, usernames[1], types.Page{})
+		assert.NoError(err)
+		assert.Empty(page.Users)
+	})
+
+	t.Run("Should not list followers of a non existing user", func(t *testing.T) {
+		_, err := backends.Users.ListFollowers(ctx, "notauser", types.Page{})
+		assert.ErrorAs(err, &storageusers.UserDoesNotExistError{})
+	})
+}
+
+func testListFollowing(t *testing.T, factory Factory) {
+	ctx := context.Background()
+	assert := assertions.New(t)
+	rs := testrand.NewPseudoRandomString()
+
+	backends, release := factory(t)
+	defer release()
+
+	usernames := createUsers(t, backends, rs, 4)
+	for _, followed := range usernames[1:] {
+		assert.NoError(backends.Users.FollowUser(ctx, followed, usernames[0]))
+	}
+
+	t.Run("Should list every followed user from the latest to the earliest follow", func(t *testing.T) {
+		page, err := backends.Users.ListFollowing(ctx, usernames[0], types.Page{})
+		assert.NoError(err)
+		assert.Equal(reversed(usernames[1:]), usernamesOf(page.Users))
+		assertFollowedNewestFirst(assert, page.Users)
+	})
+
+	t.Run("Should not list unfollowed users", func(t *testing.T) {
+		assert.NoError(backends.Users.UnfollowUser(ctx, usernames[2], usernames[0]))
+
+		page, err := backends.Users.ListFollowing(ctx, usernames[0], types.Page{})
+		assert.NoError(err)
+		assert.Equal([]string{usernames[3], usernames[1]}, usernamesOf(page.Users))
+	})
+
+	t.Run("Should list no followed users", func(t *testing.T) {
+		page, err := backends.Users.ListFollowing(ctx, usernames[1], types.Page{})
+		assert.NoError(err)
+		assert.Empty(page.Users)
+	})
+
+	t.Run("Should not list followed users of a non existing user", func(t *testing.T) {
+		_, err := backends.Users.ListFollowing(ctx, "notauser", types.Page{})
+		assert.ErrorAs(err, &storageusers.UserDoesNotExistError{})
+	})
+}
+
+func testFollowsPagination(t *testing.T, factory Factory) {
+	ctx := context.Background()
+	assert := assertions.New(t)
+	rs := testrand.NewPseudoRandomString()
+
+	backends, release := factory(t)
+	defer release()
+
+	usernames := createUsers(t, backends, rs, followPageSize+3)
+	for _, follower := range usernames[1:] {
+		assert.NoError(backends.Users.FollowUser(ctx, usernames[0], follower))
+		assert.NoError(backends.Users.FollowUser(ctx, follower, usernames[0]))
+	}
+
+	lists := []struct {
+		name   string
+		list   func(ctx context.Context, username string, page types.Page) (types.PosterrFollowPage, error)
+		follow func(ctx context.Context, username string) error
+	}{
+		{"followers", backends.Users.ListFollowers, func(ctx context.Context, username string) error {
+			return backends.Users.FollowUser(ctx, usernames[0], username)
+		}},
+		{"following", backends.Users.ListFollowing, func(ctx context.Context, username string) error {
+			return backends.Users.FollowUser(ctx, username, usernames[0])
+		}},
+	}
+	for _, follows := range lists {
+		t.Run("Should paginate "+follows.name+" by cursor without skipping or repeating users", func(t *testing.T) {
+			page, err := follows.list(ctx, usernames[0], types.Page{})
+			assert.NoError(err)
+			assert.Len(page.Users, followPageSize)
+			assert.NotEmpty(page.NextCursor)
+
+			// follows made after the first page do not shift the next one
+			extra := createUsers(t, backends, rs, 1)[0]
+			assert.NoError(follows.follow(ctx, extra))
+
+			next, err := follows.list(ctx, usernames[0], types.Page{Cursor: page.NextCursor})
+			assert.NoError(err)
+			assert.Empty(next.NextCursor)
+			assert.Equal(reversed(usernames[1:]), append(usernamesOf(page.Users), usernamesOf(next.Users)...))
+		})
+	}
+
+	t.Run("Should not accept an invalid cursor", func(t *testing.T) {
+		_, err := backends.Users.ListFollowers(ctx, usernames[0], types.Page{Cursor: "not a cursor"})
+		assert.ErrorAs(err, &storageusers.InvalidCursorError{})
+	})
+}
+
+func testBlockUser(t *testing.T, factory Factory) {
+	ctx := context.Background()
+	assert := assertions.New(t)
+	rs := testrand.NewPseudoRandomString()
+
+	backends, release := factory(t)
+	defer release()
+
+	usernames := createUsers(t, backends, rs, 3)
+	blocker, blocked := usernames[0], usernames[1]
+	assert.NoError(backends.Users.FollowUser(ctx, blocker, blocked))
+	assert.NoError(backends.Users.FollowUser(ctx, blocked, blocker))
+	assert.NoError(backends.Users.FollowUser(ctx, blocker, usernames[2]))
+
+	t.Run("Should block a user and remove follows in both directions", func(t *testing.T) {
+		// counts are cached by some backends, which must be reset
+		_, err := backends.Users.GetUserProfile(ctx, blocker)
+		assert.NoError(err)
+
+		assert.NoError(backends.Users.BlockUser(ctx, blocked, blocker))
+
+		isFollowing, err := backends.Users.IsFollowingUser(ctx, blocker, blocked)
+		assert.NoError(err)
+		assert.False(isFollowing)
+
+		isFollowing, err = backends.Users.IsFollowingUser(ctx, blocked, blocker)
+		assert.NoError(err)
+		assert.False(isFollowing)
+
+		profile, err := backends.Users.GetUserProfile(ctx, blocker)
+		assert.NoError(err)
+		assert.Equal(1, profile.Followers)
+		assert.Equal(0, profile.Following)
+	})
+
+	t.Run("Should not follow in either direction after a block", func(t *testing.T) {
+		err := backends.Users.FollowUser(ctx, blocker, blocked)
+		assert.ErrorAs(err, &storageusers.UserBlockedError{})
+
+		err = backends.Users.FollowUser(ctx, blocked, blocker)
+		assert.ErrorAs(err, &storageusers.UserBlockedError{})
+	})
+
+	t.Run("Should not block itself", func(t *testing.T) {
+		err := backends.Users.BlockUser(ctx, blocker, blocker)
+		assert.ErrorAs(err, &storageusers.SelfBlockError{})
+	})
+
+	t.Run("Should not block the same user twice", func(t *testing.T) {
+		err := backends.Users.BlockUser(ctx, blocked, blocker)
+		assert.ErrorAs(err, &storageusers.UserAlreadyBlockedError{})
+	})
+
+	t.Run("Should not block a non existing user", func(t *testing.T) {
+		err := backends.Users.BlockUser(ctx, "notauser", blocker)
+		assert.ErrorAs(err, &storageusers.UserDoesNotExistError{})
+	})
+
+	t.Run("Should follow again after an unblock", func(t *testing.T) {
+		assert.NoError(backends.Users.UnblockUser(ctx, blocked, blocker))
+		assert.NoError(backends.Users.FollowUser(ctx, blocker, blocked))
+	})
+
+	t.Run("Should not unblock a user who is not blocked", func(t *testing.T) {
+		err := backends.Users.UnblockUser(ctx, blocked, blocker)
+		assert.ErrorAs(err, &storageusers.UserNotBlockedError{})
+	})
+}
+
+func testMuteUser(t *testing.T, factory Factory) {
+	ctx := context.Background()
+	assert := assertions.New(t)
+	rs := testrand.NewPseudoRandomString()
+
+	backends, release := factory(t)
+	defer release()
+
+	usernames := createUsers(t, backends, rs, 2)
+	muter, muted := usernames[0], usernames[1]
+	assert.NoError(backends.Users.FollowUser(ctx, muted, muter))
+
+	t.Run("Should mute a user and keep following them", func(t *testing.T) {
+		assert.NoError(backends.Users.MuteUser(ctx, muted, muter))
+
+		isFollowing, err := backends.Users.IsFollowingUser(ctx, muted, muter)
+		assert.NoError(err)
+		assert.True(isFollowing)
+	})
+
+	t.Run("Should not mute itself", func(t *testing.T) {
+		err := backends.Users.MuteUser(ctx, muter, muter)
+		assert.ErrorAs(err, &storageusers.SelfMuteError{})
+	})
+
+	t.Run("Should not mute the same user twice", func(t *testing.T) {
+		err := backends.Users.MuteUser(ctx, muted, muter)
+		assert.ErrorAs(err, &storageusers.UserAlreadyMutedError{})
+	})
+
+	t.Run("Should not mute a non existing user", func(t *testing.T) {
+		err := backends.Users.MuteUser(ctx, "notauser", muter)
+		assert.ErrorAs(err, &storageusers.UserDoesNotExistError{})
+	})
+
+	t.Run("Should unmute a user only once", func(t *testing.T) {
+		assert.NoError(backends.Users.UnmuteUser(ctx, muted, muter))
+
+		err := backends.Users.UnmuteUser(ctx, muted, muter)
+		assert.ErrorAs(err, &storageusers.UserNotMutedError{})
+	})
+}
+
+func testFollowRequests(t *testing.T, factory Factory) {
+	ctx := context.Background()
+	assert := assertions.New(t)
+	rs := testrand.NewPseudoRandomString()
+
+	backends, release := factory(t)
+	defer release()
+
+	usernames := createUsers(t, backends, rs, 3)
+	private, requester, other := usernames[0], usernames[1], usernames[2]
+	assert.NoError(backends.Users.SetUserPrivate(ctx, private, true))
+
+	t.Run("Should mark the profile as private", func(t *testing.T) {
+		profile, err := backends.Users.GetUserProfile(ctx, private)
+		assert.NoError(err)
+		assert.True(profile.Private)
+
+		profile, err = backends.Users.GetUserProfile(ctx, requester)
+		assert.NoError(err)
+		assert.False(profile.Private)
+	})
+
+	t.Run("Should request to follow a private user without following them", func(t *testing.T) {
+		assert.NoError(backends.Users.FollowUser(ctx, private, requester))
+		assert.NoError(backends.Users.FollowUser(ctx, private, other))
+
+		isFollowing, err := backends.Users.IsFollowingUser(ctx, private, requester)
+		assert.NoError(err)
+		assert.False(isFollowing)
+
+		page, err := backends.Users.ListFollowRequests(ctx, private, types.Page{})
+		assert.NoError(err)
+		assert.Equal([]string{other, requester}, requestersOf(page.Requests))
+		assert.Empty(page.NextCursor)
+
+		notifications, err := backends.Notifications.ListNotifications(ctx, private, false, types.Page{})
+		assert.NoError(err)
+		assert.Equal([]types.PosterrNotification{
+			{Kind: types.FollowRequestNotification, Actor: other},
+			{Kind: types.FollowRequestNotification, Actor: requester},
+		}, withoutIdsAndDates(notifications.Notifications))
+	})
+
+	t.Run("Should not request to follow the same user twice", func(t *testing.T) {
+		err := backends.Users.FollowUser(ctx, private, requester)
+		assert.ErrorAs(err, &storageusers.FollowRequestAlreadyExistsError{})
+	})
+
+	t.Run("Should follow a private user once the request is approved", func(t *testing.T) {
+		assert.NoError(backends.Users.ApproveFollowRequest(ctx, private, requester))
+
+		isFollowing, err := backends.Users.IsFollowingUser(ctx, private, requester)
+		assert.NoError(err)
+		assert.True(isFollowing)
+
+		page, err := backends.Users.ListFollowRequests(ctx, private, types.Page{})
+		assert.NoError(err)
+		assert.Equal([]string{other}, requestersOf(page.Requests))
+	})
+
+	t.Run("Should not follow a private user once the request is rejected", func(t *testing.T) {
+		assert.NoError(backends.Users.RejectFollowRequest(ctx, private, other))
+
+		isFollowing, err := backends.Users.IsFollowingUser(ctx, private, other)
+		assert.NoError(err)
+		assert.False(isFollowing)
+
+		page, err := backends.Users.ListFollowRequests(ctx, private, types.Page{})
+		assert.NoError(err)
+		assert.Empty(page.Requests)
+	})
+
+	t.Run("Should not approve or reject a non existing request", func(t *testing.T) {
+		err := backends.Users.ApproveFollowRequest(ctx, private, other)
+		assert.ErrorAs(err, &storageusers.FollowRequestDoesNotExistError{})
+
+		err = backends.Users.RejectFollowRequest(ctx, private, requester)
+		assert.ErrorAs(err, &storageusers.FollowRequestDoesNotExistError{})
+	})
+
+	t.Run("Should follow a user directly once they are no longer private", func(t *testing.T) {
+		assert.NoError(backends.Users.SetUserPrivate(ctx, private, false))
+		assert.NoError(backends.Users.FollowUser(ctx, private, other))
+
+		isFollowing, err := backends.Users.IsFollowingUser(ctx, private, other)
+		assert.NoError(err)
+		assert.True(isFollowing)
+	})
+
+	t.Run("Should not list requests of a non existing user", func(t *testing.T) {
+		_, err := backends.Users.ListFollowRequests(ctx, "notauser", types.Page{})
+		assert.ErrorAs(err, &storageusers.UserDoesNotExistError{})
+	})
+}
+
+// createUsers creates noUsers users with random names
+func testGetUserRelations(t *testing.T, factory Factory) {
+	ctx := context.Background()
+	assert := assertions.New(t)
+	rs := testrand.NewPseudoRandomString()
+
+	backends, release := factory(t)
+	defer release()
+
+	usernames := createUsers(t, backends, rs, 4)
+	viewer := usernames[0]
+	assert.NoError(backends.Users.FollowUser(ctx, usernames[1], viewer))
+	assert.NoError(backends.Users.FollowUser(ctx, usernames[2], viewer))
+	assert.NoError(backends.Users.FollowUser(ctx, viewer, usernames[3]))
+	assert.NoError(backends.Users.MuteUser(ctx, usernames[1], viewer))
+	assert.NoError(backends.Users.BlockUser(ctx, usernames[3], viewer))
+	assert.NoError(backends.Users.MuteUser(ctx, viewer, usernames[3]))
+
+	t.Run("Should return the users a user follows, blocked and muted", func(t *testing.T) {
+		relations, err := backends.Users.GetUserRelations(ctx, viewer)
+		assert.NoError(err)
+		assert.Equal(map[string]struct{}{usernames[1]: {}, usernames[2]: {}}, relations.Following)
+		assert.Equal(map[string]struct{}{usernames[3]: {}}, relations.Blocked)
+		assert.Equal(map[string]struct{}{usernames[1]: {}}, relations.Muted)
+	})
+
+	t.Run("Should not return users who were unfollowed", func(t *testing.T) {
+		assert.NoError(backends.Users.UnfollowUser(ctx, usernames[2], viewer))
+
+		relations, err := backends.Users.GetUserRelations(ctx, viewer)
+		assert.NoError(err)
+		assert.Equal(map[string]struct{}{usernames[1]: {}}, relations.Following)
+	})
+
+	t.Run("Should return no relations of a non existing user", func(t *testing.T) {
+		relations, err := backends.Users.GetUserRelations(ctx, "notauser")
+		assert.NoError(err)
+		assert.Empty(relations.Following)
+		assert.Empty(relations.Blocked)
+		assert.Empty(relations.Muted)
+	})
+}
+
+func createUsers(t *testing.T, backends Backends, rs testrand.PseudoRand, noUsers int) []string {
+	usernames := make([]string, 0, noUsers)
+	for i := 0; i < noUsers; i++ {
+		username := rs.GenerateUnique(maxUsernameLength)
+		if err := backends.Users.CreateUser(context.Background(), username); err != nil {
+			t.Fatalf("could not create user: %s", err)
+		}
+		usernames = append(usernames, username)
+	}
+	return usernames
+}
+
+func usernamesOf(users []types.PosterrFollow) []string {
+	usernames := make([]string, 0, len(users))
+	for _, user := range users {
+		usernames = append(usernames, user.Username)
+	}
+	return usernames
+}
+
+func requestersOf(requests []types.PosterrFollowRequest) []string {
+	usernames := make([]string, 0, len(requests))
+	for _, request := range requests {
+		usernames = append(usernames, request.Username)
+	}
+	return usernames
+}
+
+func assertFollowedNewestFirst(assert *assertions.Assertions, follows []types.PosterrFollow) {
+	for i := 1; i < len(follows); i++ {
+		assert.False(follows[i].FollowedAt.After(follows[i-1].FollowedAt))
+	}
+}
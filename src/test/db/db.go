@@ -11,14 +11,14 @@ import (
 )
 
 func DropDatabase(dbName string) {
-	db := storagedb.NewDatabase("")
+	db := storagedb.NewDatabase("", storagedb.DefaultPoolConfig())
 
 	conn, err := db.Connect()
 	if err != nil {
 		log.Printf("Failed to connect to database: %s", err)
 		return
 	}
-	defer conn.Close()
+	defer db.Close()
 
 	_, err = conn.Exec(context.Background(), fmt.Sprintf(`DROP DATABASE IF EXISTS %s`, dbName))
 	if err != nil {
@@ -0,0 +1,18 @@
+package types
+
+import "context"
+
+type contextKey int
+
+const userContextKey contextKey = iota
+
+// ContextWithUser returns a copy of ctx carrying the authenticated username
+func ContextWithUser(ctx context.Context, username string) context.Context {
+	return context.WithValue(ctx, userContextKey, username)
+}
+
+// UserFromContext returns the authenticated username carried by ctx, if any
+func UserFromContext(ctx context.Context) (string, bool) {
+	username, exists := ctx.Value(userContextKey).(string)
+	return username, exists
+}
@@ -1,7 +1,8 @@
-//go:generate mockgen -destination=mocks/mocks.go -package=mocks posterr/src/types Posterr,Users
+//go:generate mockgen -destination=mocks/mocks.go -package=mocks posterr/src/types Posterr,Users,Auth,Notifications
 package types
 
 import (
+	"context"
 	"time"
 )
 
@@ -10,16 +11,96 @@ const (
 	Following = true
 )
 
+const (
+	Ascending  = "asc"
+	Descending = "desc"
+)
+
+const (
+	Relevance = "relevance"
+	Recent    = "recent"
+)
+
+const (
+	FollowNotification        = "follow"
+	FollowRequestNotification = "follow_request"
+	RepostNotification        = "repost"
+	QuoteNotification         = "quote"
+	ReplyNotification         = "reply"
+	MentionNotification       = "mention"
+	LikeNotification          = "like"
+)
+
+// UsernameCharset is the regex character set usernames are made of,
+// shared by the validation of usernames and the parsing of mentions
+const UsernameCharset = `a-zA-Z0-9`
+
 type PosterrUser struct {
 	Username string `json:"username"`
 }
 
+// PosterrFollow is a user following or followed by another user since FollowedAt
+type PosterrFollow struct {
+	PosterrUser
+	FollowedAt time.Time `json:"followed_at"`
+}
+
+type PosterrFollowPage struct {
+	Users []PosterrFollow `json:"users"`
+	// An opaque cursor to the next page, empty if there are no more users
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// PosterrFollowRequest is a user who requested to follow a private user at RequestedAt
+type PosterrFollowRequest struct {
+	PosterrUser
+	RequestedAt time.Time `json:"requested_at"`
+}
+
+type PosterrFollowRequestPage struct {
+	Requests []PosterrFollowRequest `json:"requests"`
+	// An opaque cursor to the next page, empty if there are no more requests
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// PosterrProfile holds the fields users fill in about themselves, empty if not filled in
+type PosterrProfile struct {
+	DisplayName string `json:"display_name"`
+	Bio         string `json:"bio"`
+	Location    string `json:"location"`
+	Website     string `json:"website"`
+	AvatarURL   string `json:"avatar_url"`
+}
+
+// PosterrProfileUpdate changes the fields of a PosterrProfile which are not nil.
+// An empty string clears a field
+type PosterrProfileUpdate struct {
+	DisplayName *string
+	Bio         *string
+	Location    *string
+	Website     *string
+	AvatarURL   *string
+}
+
+// PosterrRelations holds the usernames a user relates to, as sets
+type PosterrRelations struct {
+	// The users the user follows
+	Following map[string]struct{}
+	// The users the user blocked
+	Blocked map[string]struct{}
+	// The users the user muted
+	Muted map[string]struct{}
+}
+
 type PosterrUserDetailed struct {
 	PosterrUser
+	PosterrProfile
 	Followers  int       `json:"followers"`
 	Following  int       `json:"following"`
 	PostsCount int       `json:"posts_count"`
 	JoinedAt   time.Time `json:"joined_at"`
+	// Only followers can see the posts of private users
+	Private bool `json:"private"`
 }
 
 type PosterrContent struct {
@@ -27,26 +108,161 @@ type PosterrContent struct {
 	Username   string    `json:"username"`
 	Content    string    `json:"content,omitempty"`
 	RepostedId string    `json:"reposted_id,omitempty"`
+	ReplyId    string    `json:"reply_id,omitempty"`
 	CreatedAt  time.Time `json:"created_at,omitempty"`
+	// When the content was last edited, nil if it never was
+	EditedAt *time.Time `json:"edited_at,omitempty"`
+	// Deleted posts are only kept as tombstones within reply trees
+	// and as the reposted post of reposts
+	Deleted bool `json:"deleted,omitempty"`
+	// The post referenced by RepostedId, embedded up to a limited depth
+	Reposted *PosterrContent `json:"reposted,omitempty"`
+	// How many users liked the post
+	Likes int `json:"likes"`
+	// Whether the user authenticated by the context of the request liked the post
+	LikedByMe bool `json:"liked_by_me"`
+}
+
+// PosterrRevision is a prior version of the content of an edited post
+type PosterrRevision struct {
+	Content string `json:"content"`
+	// When the version was written, either by creating or by editing the post
+	CreatedAt time.Time `json:"created_at"`
+	// When the version was replaced by an edit
+	ReplacedAt time.Time `json:"replaced_at"`
+}
+
+type PosterrReply struct {
+	PosterrContent
+	Replies []PosterrReply `json:"replies"`
+}
+
+// Page selects a page of a list: a Cursor returned by a previous page
+// takes precedence over an Offset, which is kept for backward compatibility
+type Page struct {
+	Offset int
+	Cursor string
+}
+
+type PosterrPage struct {
+	Posts []PosterrContent `json:"posts"`
+	// An opaque cursor to the next page, empty if there are no more posts
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// SearchQuery selects the posts matching every term and phrase and none
+// of the excluded ones, either in their content or in the content of
+// the post they repost. An empty query matches every post with content
+type SearchQuery struct {
+	Terms   []string
+	Phrases []string
+	// Excluded terms or phrases
+	Exclude []string
+	// Only posts of a given username, if not empty
+	From string
+	// Only posts created at or after Since, if not zero
+	Since time.Time
+	// Only posts created before Until, if not zero
+	Until time.Time
+	// Either Relevance or Recent. Posts with the same relevance are sorted by Recent
+	Sort string
+}
+
+// PosterrHashtag is a hashtag along with how many posts used it
+type PosterrHashtag struct {
+	Hashtag string `json:"hashtag"`
+	Count   int    `json:"count"`
+}
+
+type PosterrNotification struct {
+	ID   int64  `json:"notification_id"`
+	Kind string `json:"kind"`
+	// The user who caused the notification
+	Actor string `json:"actor"`
+	// The post which caused the notification, if any
+	PostId    string    `json:"post_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	Read      bool      `json:"read"`
+}
+
+type PosterrNotificationPage struct {
+	Notifications []PosterrNotification `json:"notifications"`
+	// How many notifications are unread, regardless of the page
+	Unread int `json:"unread"`
+	// An opaque cursor to the next page, empty if there are no more notifications
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+type PosterrSession struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
 }
 
 type Posterr interface {
-	ListHomePageContent(username string, offset int, toggle bool) ([]PosterrContent, error)
-	ListProfileContent(username string, offset int) ([]PosterrContent, error)
-	SearchContent(text string, limit, offset int) ([]PosterrContent, error)
-	WriteContent(username, postContent string) (string, error)
-	WriteRepostContent(username, repostedId string) (string, error)
-	WriteQuoteRepostContent(username, postContent, repostedId string) (string, error)
+	ListHomePageContent(ctx context.Context, username string, page Page, toggle bool) (PosterrPage, error)
+	ListProfileContent(ctx context.Context, username string, page Page) (PosterrPage, error)
+	GetContent(ctx context.Context, postId string) (PosterrContent, error)
+	SearchContent(ctx context.Context, query SearchQuery, limit int, page Page) (PosterrPage, error)
+	WriteContent(ctx context.Context, username, postContent string) (string, error)
+	WriteRepostContent(ctx context.Context, username, repostedId string) (string, error)
+	WriteQuoteRepostContent(ctx context.Context, username, postContent, repostedId string) (string, error)
+	WriteReplyContent(ctx context.Context, username, postContent, replyId string) (string, error)
+	ListReplies(ctx context.Context, postId, order string) ([]PosterrReply, error)
+	DeleteContent(ctx context.Context, username, postId string) error
+	// EditContent only edits posts with content, within a window since they were created
+	EditContent(ctx context.Context, username, postId, postContent string) error
+	ListContentRevisions(ctx context.Context, postId string) ([]PosterrRevision, error)
+	ListHashtagContent(ctx context.Context, hashtag string, page Page) (PosterrPage, error)
+	ListMentionContent(ctx context.Context, username string, page Page) (PosterrPage, error)
+	ListTrendingHashtags(ctx context.Context, window time.Duration, limit int) ([]PosterrHashtag, error)
+	LikePost(ctx context.Context, username, postId string) error
+	UnlikePost(ctx context.Context, username, postId string) error
+	BookmarkPost(ctx context.Context, username, postId string) error
+	UnbookmarkPost(ctx context.Context, username, postId string) error
+	// ListBookmarkedContent returns posts from the latest to the earliest bookmark
+	ListBookmarkedContent(ctx context.Context, username string, page Page) (PosterrPage, error)
+	ListPostLikers(ctx context.Context, postId string) ([]PosterrUser, error)
 }
 
 type Users interface {
-	CreateUser(username string) error
-	GetUserProfile(username string) (PosterrUserDetailed, error)
-	CountUserPosts(username string) (int, error)
-	CountUserFollowers(username string) (int, error)
-	CountUserFollowing(username string) (int, error)
-	ListFollowers(username string) ([]PosterrUser, error)
-	FollowUser(targetUser, currentUser string) error
-	UnfollowUser(targetUser, currentUser string) error
-	IsFollowingUser(targetUser, currentUser string) (bool, error)
+	CreateUser(ctx context.Context, username string) error
+	// RegisterUser creates a user along with the credentials of passwordHash,
+	// so that either both or neither are stored
+	RegisterUser(ctx context.Context, username, passwordHash string) error
+	GetUserProfile(ctx context.Context, username string) (PosterrUserDetailed, error)
+	UpdateUserProfile(ctx context.Context, username string, update PosterrProfileUpdate) error
+	CountUserPosts(ctx context.Context, username string) (int, error)
+	CountUserFollowers(ctx context.Context, username string) (int, error)
+	CountUserFollowing(ctx context.Context, username string) (int, error)
+	ListFollowers(ctx context.Context, username string, page Page) (PosterrFollowPage, error)
+	ListFollowing(ctx context.Context, username string, page Page) (PosterrFollowPage, error)
+	// FollowUser requests to follow targetUser instead, if they are private
+	FollowUser(ctx context.Context, targetUser, currentUser string) error
+	UnfollowUser(ctx context.Context, targetUser, currentUser string) error
+	IsFollowingUser(ctx context.Context, targetUser, currentUser string) (bool, error)
+	// GetUserRelations returns the relations of a user at once, e.g., to filter long-lived streams
+	GetUserRelations(ctx context.Context, username string) (PosterrRelations, error)
+	BlockUser(ctx context.Context, targetUser, currentUser string) error
+	UnblockUser(ctx context.Context, targetUser, currentUser string) error
+	MuteUser(ctx context.Context, targetUser, currentUser string) error
+	UnmuteUser(ctx context.Context, targetUser, currentUser string) error
+	SetUserPrivate(ctx context.Context, username string, private bool) error
+	ListFollowRequests(ctx context.Context, username string, page Page) (PosterrFollowRequestPage, error)
+	ApproveFollowRequest(ctx context.Context, username, requester string) error
+	RejectFollowRequest(ctx context.Context, username, requester string) error
+}
+
+type Auth interface {
+	SetPassword(ctx context.Context, username, password string) error
+	Login(ctx context.Context, username, password string) (PosterrSession, error)
+	Logout(ctx context.Context, token string) error
+	Authenticate(ctx context.Context, token string) (string, error)
+}
+
+// Notifications are written by the other backends along with the actions causing them,
+// except for the actions of users on their own posts. Notifications of deleted posts are hidden
+type Notifications interface {
+	ListNotifications(ctx context.Context, username string, unreadOnly bool, page Page) (PosterrNotificationPage, error)
+	MarkNotificationRead(ctx context.Context, username string, notificationId int64) error
+	MarkAllNotificationsRead(ctx context.Context, username string) error
 }
@@ -1,12 +1,14 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: posterr/src/types (interfaces: Posterr,Users)
+// Source: posterr/src/types (interfaces: Posterr,Users,Auth,Notifications)
 
 // Package mocks is a generated GoMock package.
 package mocks
 
 import (
+	context "context"
 	types "posterr/src/types"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -34,94 +36,313 @@ func (m *MockPosterr) EXPECT() *MockPosterrMockRecorder {
 	return m.recorder
 }
 
+// BookmarkPost mocks base method.
+func (m *MockPosterr) BookmarkPost(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BookmarkPost", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BookmarkPost indicates an expected call of BookmarkPost.
+func (mr *MockPosterrMockRecorder) BookmarkPost(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BookmarkPost", reflect.TypeOf((*MockPosterr)(nil).BookmarkPost), arg0, arg1, arg2)
+}
+
+// DeleteContent mocks base method.
+func (m *MockPosterr) DeleteContent(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteContent", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteContent indicates an expected call of DeleteContent.
+func (mr *MockPosterrMockRecorder) DeleteContent(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteContent", reflect.TypeOf((*MockPosterr)(nil).DeleteContent), arg0, arg1, arg2)
+}
+
+// EditContent mocks base method.
+func (m *MockPosterr) EditContent(arg0 context.Context, arg1, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EditContent", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EditContent indicates an expected call of EditContent.
+func (mr *MockPosterrMockRecorder) EditContent(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditContent", reflect.TypeOf((*MockPosterr)(nil).EditContent), arg0, arg1, arg2, arg3)
+}
+
+// GetContent mocks base method.
+func (m *MockPosterr) GetContent(arg0 context.Context, arg1 string) (types.PosterrContent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContent", arg0, arg1)
+	ret0, _ := ret[0].(types.PosterrContent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContent indicates an expected call of GetContent.
+func (mr *MockPosterrMockRecorder) GetContent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContent", reflect.TypeOf((*MockPosterr)(nil).GetContent), arg0, arg1)
+}
+
+// LikePost mocks base method.
+func (m *MockPosterr) LikePost(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LikePost", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LikePost indicates an expected call of LikePost.
+func (mr *MockPosterrMockRecorder) LikePost(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LikePost", reflect.TypeOf((*MockPosterr)(nil).LikePost), arg0, arg1, arg2)
+}
+
+// ListBookmarkedContent mocks base method.
+func (m *MockPosterr) ListBookmarkedContent(arg0 context.Context, arg1 string, arg2 types.Page) (types.PosterrPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBookmarkedContent", arg0, arg1, arg2)
+	ret0, _ := ret[0].(types.PosterrPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBookmarkedContent indicates an expected call of ListBookmarkedContent.
+func (mr *MockPosterrMockRecorder) ListBookmarkedContent(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBookmarkedContent", reflect.TypeOf((*MockPosterr)(nil).ListBookmarkedContent), arg0, arg1, arg2)
+}
+
+// ListContentRevisions mocks base method.
+func (m *MockPosterr) ListContentRevisions(arg0 context.Context, arg1 string) ([]types.PosterrRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListContentRevisions", arg0, arg1)
+	ret0, _ := ret[0].([]types.PosterrRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListContentRevisions indicates an expected call of ListContentRevisions.
+func (mr *MockPosterrMockRecorder) ListContentRevisions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListContentRevisions", reflect.TypeOf((*MockPosterr)(nil).ListContentRevisions), arg0, arg1)
+}
+
+// ListHashtagContent mocks base method.
+func (m *MockPosterr) ListHashtagContent(arg0 context.Context, arg1 string, arg2 types.Page) (types.PosterrPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListHashtagContent", arg0, arg1, arg2)
+	ret0, _ := ret[0].(types.PosterrPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListHashtagContent indicates an expected call of ListHashtagContent.
+func (mr *MockPosterrMockRecorder) ListHashtagContent(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHashtagContent", reflect.TypeOf((*MockPosterr)(nil).ListHashtagContent), arg0, arg1, arg2)
+}
+
 // ListHomePageContent mocks base method.
-func (m *MockPosterr) ListHomePageContent(arg0 string, arg1 int, arg2 bool) ([]types.PosterrContent, error) {
+func (m *MockPosterr) ListHomePageContent(arg0 context.Context, arg1 string, arg2 types.Page, arg3 bool) (types.PosterrPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListHomePageContent", arg0, arg1, arg2)
-	ret0, _ := ret[0].([]types.PosterrContent)
+	ret := m.ctrl.Call(m, "ListHomePageContent", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(types.PosterrPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListHomePageContent indicates an expected call of ListHomePageContent.
-func (mr *MockPosterrMockRecorder) ListHomePageContent(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockPosterrMockRecorder) ListHomePageContent(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHomePageContent", reflect.TypeOf((*MockPosterr)(nil).ListHomePageContent), arg0, arg1, arg2, arg3)
+}
+
+// ListMentionContent mocks base method.
+func (m *MockPosterr) ListMentionContent(arg0 context.Context, arg1 string, arg2 types.Page) (types.PosterrPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMentionContent", arg0, arg1, arg2)
+	ret0, _ := ret[0].(types.PosterrPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMentionContent indicates an expected call of ListMentionContent.
+func (mr *MockPosterrMockRecorder) ListMentionContent(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMentionContent", reflect.TypeOf((*MockPosterr)(nil).ListMentionContent), arg0, arg1, arg2)
+}
+
+// ListPostLikers mocks base method.
+func (m *MockPosterr) ListPostLikers(arg0 context.Context, arg1 string) ([]types.PosterrUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPostLikers", arg0, arg1)
+	ret0, _ := ret[0].([]types.PosterrUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPostLikers indicates an expected call of ListPostLikers.
+func (mr *MockPosterrMockRecorder) ListPostLikers(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHomePageContent", reflect.TypeOf((*MockPosterr)(nil).ListHomePageContent), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPostLikers", reflect.TypeOf((*MockPosterr)(nil).ListPostLikers), arg0, arg1)
 }
 
 // ListProfileContent mocks base method.
-func (m *MockPosterr) ListProfileContent(arg0 string, arg1 int) ([]types.PosterrContent, error) {
+func (m *MockPosterr) ListProfileContent(arg0 context.Context, arg1 string, arg2 types.Page) (types.PosterrPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListProfileContent", arg0, arg1)
-	ret0, _ := ret[0].([]types.PosterrContent)
+	ret := m.ctrl.Call(m, "ListProfileContent", arg0, arg1, arg2)
+	ret0, _ := ret[0].(types.PosterrPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListProfileContent indicates an expected call of ListProfileContent.
-func (mr *MockPosterrMockRecorder) ListProfileContent(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockPosterrMockRecorder) ListProfileContent(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProfileContent", reflect.TypeOf((*MockPosterr)(nil).ListProfileContent), arg0, arg1, arg2)
+}
+
+// ListReplies mocks base method.
+func (m *MockPosterr) ListReplies(arg0 context.Context, arg1, arg2 string) ([]types.PosterrReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReplies", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]types.PosterrReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReplies indicates an expected call of ListReplies.
+func (mr *MockPosterrMockRecorder) ListReplies(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReplies", reflect.TypeOf((*MockPosterr)(nil).ListReplies), arg0, arg1, arg2)
+}
+
+// ListTrendingHashtags mocks base method.
+func (m *MockPosterr) ListTrendingHashtags(arg0 context.Context, arg1 time.Duration, arg2 int) ([]types.PosterrHashtag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrendingHashtags", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]types.PosterrHashtag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTrendingHashtags indicates an expected call of ListTrendingHashtags.
+func (mr *MockPosterrMockRecorder) ListTrendingHashtags(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProfileContent", reflect.TypeOf((*MockPosterr)(nil).ListProfileContent), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrendingHashtags", reflect.TypeOf((*MockPosterr)(nil).ListTrendingHashtags), arg0, arg1, arg2)
 }
 
 // SearchContent mocks base method.
-func (m *MockPosterr) SearchContent(arg0 string, arg1, arg2 int) ([]types.PosterrContent, error) {
+func (m *MockPosterr) SearchContent(arg0 context.Context, arg1 types.SearchQuery, arg2 int, arg3 types.Page) (types.PosterrPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SearchContent", arg0, arg1, arg2)
-	ret0, _ := ret[0].([]types.PosterrContent)
+	ret := m.ctrl.Call(m, "SearchContent", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(types.PosterrPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SearchContent indicates an expected call of SearchContent.
-func (mr *MockPosterrMockRecorder) SearchContent(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockPosterrMockRecorder) SearchContent(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchContent", reflect.TypeOf((*MockPosterr)(nil).SearchContent), arg0, arg1, arg2, arg3)
+}
+
+// UnbookmarkPost mocks base method.
+func (m *MockPosterr) UnbookmarkPost(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnbookmarkPost", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnbookmarkPost indicates an expected call of UnbookmarkPost.
+func (mr *MockPosterrMockRecorder) UnbookmarkPost(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnbookmarkPost", reflect.TypeOf((*MockPosterr)(nil).UnbookmarkPost), arg0, arg1, arg2)
+}
+
+// UnlikePost mocks base method.
+func (m *MockPosterr) UnlikePost(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlikePost", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlikePost indicates an expected call of UnlikePost.
+func (mr *MockPosterrMockRecorder) UnlikePost(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchContent", reflect.TypeOf((*MockPosterr)(nil).SearchContent), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlikePost", reflect.TypeOf((*MockPosterr)(nil).UnlikePost), arg0, arg1, arg2)
 }
 
 // WriteContent mocks base method.
-func (m *MockPosterr) WriteContent(arg0, arg1 string) (string, error) {
+func (m *MockPosterr) WriteContent(arg0 context.Context, arg1, arg2 string) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WriteContent", arg0, arg1)
+	ret := m.ctrl.Call(m, "WriteContent", arg0, arg1, arg2)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // WriteContent indicates an expected call of WriteContent.
-func (mr *MockPosterrMockRecorder) WriteContent(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockPosterrMockRecorder) WriteContent(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteContent", reflect.TypeOf((*MockPosterr)(nil).WriteContent), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteContent", reflect.TypeOf((*MockPosterr)(nil).WriteContent), arg0, arg1, arg2)
 }
 
 // WriteQuoteRepostContent mocks base method.
-func (m *MockPosterr) WriteQuoteRepostContent(arg0, arg1, arg2 string) (string, error) {
+func (m *MockPosterr) WriteQuoteRepostContent(arg0 context.Context, arg1, arg2, arg3 string) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WriteQuoteRepostContent", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "WriteQuoteRepostContent", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // WriteQuoteRepostContent indicates an expected call of WriteQuoteRepostContent.
-func (mr *MockPosterrMockRecorder) WriteQuoteRepostContent(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockPosterrMockRecorder) WriteQuoteRepostContent(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteQuoteRepostContent", reflect.TypeOf((*MockPosterr)(nil).WriteQuoteRepostContent), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteQuoteRepostContent", reflect.TypeOf((*MockPosterr)(nil).WriteQuoteRepostContent), arg0, arg1, arg2, arg3)
+}
+
+// WriteReplyContent mocks base method.
+func (m *MockPosterr) WriteReplyContent(arg0 context.Context, arg1, arg2, arg3 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteReplyContent", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WriteReplyContent indicates an expected call of WriteReplyContent.
+func (mr *MockPosterrMockRecorder) WriteReplyContent(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteReplyContent", reflect.TypeOf((*MockPosterr)(nil).WriteReplyContent), arg0, arg1, arg2, arg3)
 }
 
 // WriteRepostContent mocks base method.
-func (m *MockPosterr) WriteRepostContent(arg0, arg1 string) (string, error) {
+func (m *MockPosterr) WriteRepostContent(arg0 context.Context, arg1, arg2 string) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WriteRepostContent", arg0, arg1)
+	ret := m.ctrl.Call(m, "WriteRepostContent", arg0, arg1, arg2)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // WriteRepostContent indicates an expected call of WriteRepostContent.
-func (mr *MockPosterrMockRecorder) WriteRepostContent(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockPosterrMockRecorder) WriteRepostContent(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteRepostContent", reflect.TypeOf((*MockPosterr)(nil).WriteRepostContent), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteRepostContent", reflect.TypeOf((*MockPosterr)(nil).WriteRepostContent), arg0, arg1, arg2)
 }
 
 // MockUsers is a mock of Users interface.
@@ -147,134 +368,452 @@ func (m *MockUsers) EXPECT() *MockUsersMockRecorder {
 	return m.recorder
 }
 
+// ApproveFollowRequest mocks base method.
+func (m *MockUsers) ApproveFollowRequest(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveFollowRequest", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApproveFollowRequest indicates an expected call of ApproveFollowRequest.
+func (mr *MockUsersMockRecorder) ApproveFollowRequest(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveFollowRequest", reflect.TypeOf((*MockUsers)(nil).ApproveFollowRequest), arg0, arg1, arg2)
+}
+
+// BlockUser mocks base method.
+func (m *MockUsers) BlockUser(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockUser", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BlockUser indicates an expected call of BlockUser.
+func (mr *MockUsersMockRecorder) BlockUser(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockUser", reflect.TypeOf((*MockUsers)(nil).BlockUser), arg0, arg1, arg2)
+}
+
 // CountUserFollowers mocks base method.
-func (m *MockUsers) CountUserFollowers(arg0 string) (int, error) {
+func (m *MockUsers) CountUserFollowers(arg0 context.Context, arg1 string) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CountUserFollowers", arg0)
+	ret := m.ctrl.Call(m, "CountUserFollowers", arg0, arg1)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CountUserFollowers indicates an expected call of CountUserFollowers.
-func (mr *MockUsersMockRecorder) CountUserFollowers(arg0 interface{}) *gomock.Call {
+func (mr *MockUsersMockRecorder) CountUserFollowers(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUserFollowers", reflect.TypeOf((*MockUsers)(nil).CountUserFollowers), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUserFollowers", reflect.TypeOf((*MockUsers)(nil).CountUserFollowers), arg0, arg1)
 }
 
 // CountUserFollowing mocks base method.
-func (m *MockUsers) CountUserFollowing(arg0 string) (int, error) {
+func (m *MockUsers) CountUserFollowing(arg0 context.Context, arg1 string) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CountUserFollowing", arg0)
+	ret := m.ctrl.Call(m, "CountUserFollowing", arg0, arg1)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CountUserFollowing indicates an expected call of CountUserFollowing.
-func (mr *MockUsersMockRecorder) CountUserFollowing(arg0 interface{}) *gomock.Call {
+func (mr *MockUsersMockRecorder) CountUserFollowing(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUserFollowing", reflect.TypeOf((*MockUsers)(nil).CountUserFollowing), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUserFollowing", reflect.TypeOf((*MockUsers)(nil).CountUserFollowing), arg0, arg1)
 }
 
 // CountUserPosts mocks base method.
-func (m *MockUsers) CountUserPosts(arg0 string) (int, error) {
+func (m *MockUsers) CountUserPosts(arg0 context.Context, arg1 string) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CountUserPosts", arg0)
+	ret := m.ctrl.Call(m, "CountUserPosts", arg0, arg1)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CountUserPosts indicates an expected call of CountUserPosts.
-func (mr *MockUsersMockRecorder) CountUserPosts(arg0 interface{}) *gomock.Call {
+func (mr *MockUsersMockRecorder) CountUserPosts(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUserPosts", reflect.TypeOf((*MockUsers)(nil).CountUserPosts), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUserPosts", reflect.TypeOf((*MockUsers)(nil).CountUserPosts), arg0, arg1)
 }
 
 // CreateUser mocks base method.
-func (m *MockUsers) CreateUser(arg0 string) error {
+func (m *MockUsers) CreateUser(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateUser", arg0)
+	ret := m.ctrl.Call(m, "CreateUser", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateUser indicates an expected call of CreateUser.
-func (mr *MockUsersMockRecorder) CreateUser(arg0 interface{}) *gomock.Call {
+func (mr *MockUsersMockRecorder) CreateUser(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockUsers)(nil).CreateUser), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockUsers)(nil).CreateUser), arg0, arg1)
 }
 
 // FollowUser mocks base method.
-func (m *MockUsers) FollowUser(arg0, arg1 string) error {
+func (m *MockUsers) FollowUser(arg0 context.Context, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FollowUser", arg0, arg1)
+	ret := m.ctrl.Call(m, "FollowUser", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // FollowUser indicates an expected call of FollowUser.
-func (mr *MockUsersMockRecorder) FollowUser(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockUsersMockRecorder) FollowUser(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FollowUser", reflect.TypeOf((*MockUsers)(nil).FollowUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FollowUser", reflect.TypeOf((*MockUsers)(nil).FollowUser), arg0, arg1, arg2)
 }
 
 // GetUserProfile mocks base method.
-func (m *MockUsers) GetUserProfile(arg0 string) (types.PosterrUserDetailed, error) {
+func (m *MockUsers) GetUserProfile(arg0 context.Context, arg1 string) (types.PosterrUserDetailed, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserProfile", arg0)
+	ret := m.ctrl.Call(m, "GetUserProfile", arg0, arg1)
 	ret0, _ := ret[0].(types.PosterrUserDetailed)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetUserProfile indicates an expected call of GetUserProfile.
-func (mr *MockUsersMockRecorder) GetUserProfile(arg0 interface{}) *gomock.Call {
+func (mr *MockUsersMockRecorder) GetUserProfile(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserProfile", reflect.TypeOf((*MockUsers)(nil).GetUserProfile), arg0, arg1)
+}
+
+// GetUserRelations mocks base method.
+func (m *MockUsers) GetUserRelations(arg0 context.Context, arg1 string) (types.PosterrRelations, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserRelations", arg0, arg1)
+	ret0, _ := ret[0].(types.PosterrRelations)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserRelations indicates an expected call of GetUserRelations.
+func (mr *MockUsersMockRecorder) GetUserRelations(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserProfile", reflect.TypeOf((*MockUsers)(nil).GetUserProfile), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserRelations", reflect.TypeOf((*MockUsers)(nil).GetUserRelations), arg0, arg1)
 }
 
 // IsFollowingUser mocks base method.
-func (m *MockUsers) IsFollowingUser(arg0, arg1 string) (bool, error) {
+func (m *MockUsers) IsFollowingUser(arg0 context.Context, arg1, arg2 string) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IsFollowingUser", arg0, arg1)
+	ret := m.ctrl.Call(m, "IsFollowingUser", arg0, arg1, arg2)
 	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // IsFollowingUser indicates an expected call of IsFollowingUser.
-func (mr *MockUsersMockRecorder) IsFollowingUser(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockUsersMockRecorder) IsFollowingUser(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsFollowingUser", reflect.TypeOf((*MockUsers)(nil).IsFollowingUser), arg0, arg1, arg2)
+}
+
+// ListFollowRequests mocks base method.
+func (m *MockUsers) ListFollowRequests(arg0 context.Context, arg1 string, arg2 types.Page) (types.PosterrFollowRequestPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFollowRequests", arg0, arg1, arg2)
+	ret0, _ := ret[0].(types.PosterrFollowRequestPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFollowRequests indicates an expected call of ListFollowRequests.
+func (mr *MockUsersMockRecorder) ListFollowRequests(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsFollowingUser", reflect.TypeOf((*MockUsers)(nil).IsFollowingUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFollowRequests", reflect.TypeOf((*MockUsers)(nil).ListFollowRequests), arg0, arg1, arg2)
 }
 
 // ListFollowers mocks base method.
-func (m *MockUsers) ListFollowers(arg0 string) ([]types.PosterrUser, error) {
+func (m *MockUsers) ListFollowers(arg0 context.Context, arg1 string, arg2 types.Page) (types.PosterrFollowPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListFollowers", arg0)
-	ret0, _ := ret[0].([]types.PosterrUser)
+	ret := m.ctrl.Call(m, "ListFollowers", arg0, arg1, arg2)
+	ret0, _ := ret[0].(types.PosterrFollowPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListFollowers indicates an expected call of ListFollowers.
-func (mr *MockUsersMockRecorder) ListFollowers(arg0 interface{}) *gomock.Call {
+func (mr *MockUsersMockRecorder) ListFollowers(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFollowers", reflect.TypeOf((*MockUsers)(nil).ListFollowers), arg0, arg1, arg2)
+}
+
+// ListFollowing mocks base method.
+func (m *MockUsers) ListFollowing(arg0 context.Context, arg1 string, arg2 types.Page) (types.PosterrFollowPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFollowing", arg0, arg1, arg2)
+	ret0, _ := ret[0].(types.PosterrFollowPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFollowing indicates an expected call of ListFollowing.
+func (mr *MockUsersMockRecorder) ListFollowing(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFollowing", reflect.TypeOf((*MockUsers)(nil).ListFollowing), arg0, arg1, arg2)
+}
+
+// MuteUser mocks base method.
+func (m *MockUsers) MuteUser(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MuteUser", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MuteUser indicates an expected call of MuteUser.
+func (mr *MockUsersMockRecorder) MuteUser(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MuteUser", reflect.TypeOf((*MockUsers)(nil).MuteUser), arg0, arg1, arg2)
+}
+
+// RegisterUser mocks base method.
+func (m *MockUsers) RegisterUser(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterUser", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RegisterUser indicates an expected call of RegisterUser.
+func (mr *MockUsersMockRecorder) RegisterUser(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFollowers", reflect.TypeOf((*MockUsers)(nil).ListFollowers), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterUser", reflect.TypeOf((*MockUsers)(nil).RegisterUser), arg0, arg1, arg2)
+}
+
+// RejectFollowRequest mocks base method.
+func (m *MockUsers) RejectFollowRequest(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RejectFollowRequest", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RejectFollowRequest indicates an expected call of RejectFollowRequest.
+func (mr *MockUsersMockRecorder) RejectFollowRequest(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectFollowRequest", reflect.TypeOf((*MockUsers)(nil).RejectFollowRequest), arg0, arg1, arg2)
+}
+
+// SetUserPrivate mocks base method.
+func (m *MockUsers) SetUserPrivate(arg0 context.Context, arg1 string, arg2 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserPrivate", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUserPrivate indicates an expected call of SetUserPrivate.
+func (mr *MockUsersMockRecorder) SetUserPrivate(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserPrivate", reflect.TypeOf((*MockUsers)(nil).SetUserPrivate), arg0, arg1, arg2)
+}
+
+// UnblockUser mocks base method.
+func (m *MockUsers) UnblockUser(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnblockUser", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnblockUser indicates an expected call of UnblockUser.
+func (mr *MockUsersMockRecorder) UnblockUser(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnblockUser", reflect.TypeOf((*MockUsers)(nil).UnblockUser), arg0, arg1, arg2)
 }
 
 // UnfollowUser mocks base method.
-func (m *MockUsers) UnfollowUser(arg0, arg1 string) error {
+func (m *MockUsers) UnfollowUser(arg0 context.Context, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UnfollowUser", arg0, arg1)
+	ret := m.ctrl.Call(m, "UnfollowUser", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UnfollowUser indicates an expected call of UnfollowUser.
-func (mr *MockUsersMockRecorder) UnfollowUser(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockUsersMockRecorder) UnfollowUser(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnfollowUser", reflect.TypeOf((*MockUsers)(nil).UnfollowUser), arg0, arg1, arg2)
+}
+
+// UnmuteUser mocks base method.
+func (m *MockUsers) UnmuteUser(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnmuteUser", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnmuteUser indicates an expected call of UnmuteUser.
+func (mr *MockUsersMockRecorder) UnmuteUser(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnmuteUser", reflect.TypeOf((*MockUsers)(nil).UnmuteUser), arg0, arg1, arg2)
+}
+
+// UpdateUserProfile mocks base method.
+func (m *MockUsers) UpdateUserProfile(arg0 context.Context, arg1 string, arg2 types.PosterrProfileUpdate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserProfile", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserProfile indicates an expected call of UpdateUserProfile.
+func (mr *MockUsersMockRecorder) UpdateUserProfile(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserProfile", reflect.TypeOf((*MockUsers)(nil).UpdateUserProfile), arg0, arg1, arg2)
+}
+
+// MockAuth is a mock of Auth interface.
+type MockAuth struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthMockRecorder
+}
+
+// MockAuthMockRecorder is the mock recorder for MockAuth.
+type MockAuthMockRecorder struct {
+	mock *MockAuth
+}
+
+// NewMockAuth creates a new mock instance.
+func NewMockAuth(ctrl *gomock.Controller) *MockAuth {
+	mock := &MockAuth{ctrl: ctrl}
+	mock.recorder = &MockAuthMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuth) EXPECT() *MockAuthMockRecorder {
+	return m.recorder
+}
+
+// Authenticate mocks base method.
+func (m *MockAuth) Authenticate(arg0 context.Context, arg1 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authenticate", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authenticate indicates an expected call of Authenticate.
+func (mr *MockAuthMockRecorder) Authenticate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockAuth)(nil).Authenticate), arg0, arg1)
+}
+
+// Login mocks base method.
+func (m *MockAuth) Login(arg0 context.Context, arg1, arg2 string) (types.PosterrSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Login", arg0, arg1, arg2)
+	ret0, _ := ret[0].(types.PosterrSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Login indicates an expected call of Login.
+func (mr *MockAuthMockRecorder) Login(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockAuth)(nil).Login), arg0, arg1, arg2)
+}
+
+// Logout mocks base method.
+func (m *MockAuth) Logout(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logout", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockAuthMockRecorder) Logout(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockAuth)(nil).Logout), arg0, arg1)
+}
+
+// SetPassword mocks base method.
+func (m *MockAuth) SetPassword(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPassword", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPassword indicates an expected call of SetPassword.
+func (mr *MockAuthMockRecorder) SetPassword(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPassword", reflect.TypeOf((*MockAuth)(nil).SetPassword), arg0, arg1, arg2)
+}
+
+// MockNotifications is a mock of Notifications interface.
+type MockNotifications struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationsMockRecorder
+}
+
+// MockNotificationsMockRecorder is the mock recorder for MockNotifications.
+type MockNotificationsMockRecorder struct {
+	mock *MockNotifications
+}
+
+// NewMockNotifications creates a new mock instance.
+func NewMockNotifications(ctrl *gomock.Controller) *MockNotifications {
+	mock := &MockNotifications{ctrl: ctrl}
+	mock.recorder = &MockNotificationsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotifications) EXPECT() *MockNotificationsMockRecorder {
+	return m.recorder
+}
+
+// ListNotifications mocks base method.
+func (m *MockNotifications) ListNotifications(arg0 context.Context, arg1 string, arg2 bool, arg3 types.Page) (types.PosterrNotificationPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNotifications", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(types.PosterrNotificationPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNotifications indicates an expected call of ListNotifications.
+func (mr *MockNotificationsMockRecorder) ListNotifications(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNotifications", reflect.TypeOf((*MockNotifications)(nil).ListNotifications), arg0, arg1, arg2, arg3)
+}
+
+// MarkAllNotificationsRead mocks base method.
+func (m *MockNotifications) MarkAllNotificationsRead(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAllNotificationsRead", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkAllNotificationsRead indicates an expected call of MarkAllNotificationsRead.
+func (mr *MockNotificationsMockRecorder) MarkAllNotificationsRead(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAllNotificationsRead", reflect.TypeOf((*MockNotifications)(nil).MarkAllNotificationsRead), arg0, arg1)
+}
+
+// MarkNotificationRead mocks base method.
+func (m *MockNotifications) MarkNotificationRead(arg0 context.Context, arg1 string, arg2 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkNotificationRead", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkNotificationRead indicates an expected call of MarkNotificationRead.
+func (mr *MockNotificationsMockRecorder) MarkNotificationRead(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnfollowUser", reflect.TypeOf((*MockUsers)(nil).UnfollowUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationRead", reflect.TypeOf((*MockNotifications)(nil).MarkNotificationRead), arg0, arg1, arg2)
 }